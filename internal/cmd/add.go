@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/scrape"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addCmd.Flags().StringP("file", "f", "", "Read URLs from a file, one per line or a browser bookmarks export (- for stdin)")
+	addCmd.Flags().String("llm-model", "", "Model for parsing ingredients (default from the config)")
+	addCmd.Flags().Bool("no-llm", false, "Parse ingredients without the LLM")
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add <url>...",
+	Short: "Add recipes from web pages",
+	Long: `Scrape recipes from their web pages and add them to the cookbook, like the Add from URL
+dialog of the TUI. Pages are read with the scraper backends of the config, and ingredients are
+parsed by the configured LLM when one is available.
+
+URLs can also be read from a file: a plain list with one URL per line (lines starting with #
+are comments) or an HTML bookmarks export from a browser. Recipes whose URL is already in the
+cookbook are skipped.`,
+	Example: `
+		# Add a recipe
+		yummy add https://example.com/chicken-curry
+
+		# Add every recipe of a bookmarks export, without the LLM
+		yummy add --file bookmarks.html --no-llm
+
+		# Parse ingredients with a different model
+		yummy add https://example.com/pho --llm-model llama3.2
+  	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		llmModel, _ := cmd.Flags().GetString("llm-model")
+		noLLM, _ := cmd.Flags().GetBool("no-llm")
+		if noLLM && llmModel != "" {
+			return fmt.Errorf("--llm-model and --no-llm cannot be used together")
+		}
+
+		urls := args
+		if file != "" {
+			fileURLs, err := readURLFile(file)
+			if err != nil {
+				return err
+			}
+			urls = append(urls, fileURLs...)
+		}
+		urls = uniqueURLs(urls)
+		if len(urls) == 0 {
+			return fmt.Errorf("at least one URL is required (or use --file)")
+		}
+
+		cfg, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		// Same model as the Add from URL dialog: its own, or the chat default
+		if noLLM {
+			llmModel = ""
+		} else if llmModel == "" {
+			llmModel = cfg.AddRecipeFromURLDialog.LLMIngredientModel
+			if llmModel == "" {
+				llmModel = cfg.Chat.DefaultModel
+			}
+		}
+		opts := scrape.Options{PythonPath: cfg.AddRecipeFromURLDialog.PythonPath}
+
+		var added, skipped, failed int
+		for i, rawURL := range urls {
+			fmt.Printf("[%d/%d] %s\n", i+1, len(urls), rawURL)
+
+			if u, err := url.Parse(rawURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				fmt.Println("      ❌ not a web address")
+				failed++
+				continue
+			}
+
+			existingID, err := cookbook.RecipeExistsByURL(rawURL)
+			if err != nil {
+				fmt.Printf("      ❌ failed to check the cookbook: %v\n", err)
+				failed++
+				continue
+			}
+			if existingID != 0 {
+				fmt.Printf("      ⏭️  already in the cookbook as recipe #%d\n", existingID)
+				skipped++
+				continue
+			}
+
+			backends := scrape.BackendsForURL(rawURL, cfg.Scraper.Backends, cfg.Scraper.HostBackends)
+			result, err := scrape.Scrape(rawURL, backends, opts)
+			if err != nil {
+				slog.Error("Failed to scrape recipe", "url", rawURL, "error", err)
+				fmt.Printf("      ❌ %s\n", scrapeFailure(err))
+				failed++
+				continue
+			}
+
+			if llmModel != "" {
+				fmt.Printf("      🧠 parsing ingredients with %s…\n", llmModel)
+			}
+			recipe := scrape.RecipeRawFromScraper(result.Scraper, rawURL, llmModel)
+			recipeID, err := cookbook.SaveScrapedRecipe(recipe)
+			if err != nil {
+				slog.Error("Failed to save recipe from URL", "url", rawURL, "error", err)
+				fmt.Printf("      ❌ failed to save recipe: %v\n", err)
+				failed++
+				continue
+			}
+			fmt.Printf("      ✅ added %q (ID: %d, via %s)\n", recipe.RecipeName, recipeID, result.Backend)
+			added++
+		}
+
+		noun := "URLs"
+		if len(urls) == 1 {
+			noun = "URL"
+		}
+		fmt.Println()
+		fmt.Printf("%d %s: %d added, %d skipped, %d failed\n", len(urls), noun, added, skipped, failed)
+
+		slog.Info("Add from URLs finished", "urls", len(urls), "added", added, "failed", failed)
+		if failed > 0 {
+			return fmt.Errorf("%d of %d URLs failed to import", failed, len(urls))
+		}
+		return nil
+	},
+}
+
+// bookmarkLinkRe matches the links of an HTML bookmarks export. The href
+// may be in double quotes, single quotes or none; the URL is in whichever
+// group matched.
+var bookmarkLinkRe = regexp.MustCompile(`(?i)<a\s[^>]*href\s*=\s*(?:"(https?://[^"]+)"|'(https?://[^']+)'|(https?://[^\s>]+))`)
+
+// readURLFile reads the URLs of a file: the links of an HTML bookmarks
+// export, or else the first word of every line that is not a # comment
+func readURLFile(path string) ([]string, error) {
+	var (
+		content []byte
+		err     error
+	)
+	if path == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read URLs from %s: %v", path, err)
+	}
+
+	var urls []string
+	if matches := bookmarkLinkRe.FindAllStringSubmatch(string(content), -1); len(matches) > 0 {
+		for _, match := range matches {
+			urls = append(urls, html.UnescapeString(match[1]+match[2]+match[3]))
+		}
+		return urls, nil
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		urls = append(urls, fields[0])
+	}
+	return urls, nil
+}
+
+// uniqueURLs drops repeated URLs, keeping the first of each
+func uniqueURLs(urls []string) []string {
+	seen := make(map[string]bool, len(urls))
+	unique := make([]string, 0, len(urls))
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		unique = append(unique, u)
+	}
+	return unique
+}
+
+// scrapeFailure explains why no scraper backend could read a page
+func scrapeFailure(err error) string {
+	var scrapeErr *scrape.Error
+	if !errors.As(err, &scrapeErr) || len(scrapeErr.Attempts) == 0 {
+		return scrape.FriendlyError(err)
+	}
+	if len(scrapeErr.Attempts) == 1 {
+		return scrape.FriendlyError(scrapeErr.Attempts[0].Err)
+	}
+	reasons := make([]string, len(scrapeErr.Attempts))
+	for i, attempt := range scrapeErr.Attempts {
+		reason := strings.Join(strings.Fields(scrape.FriendlyError(attempt.Err)), " ")
+		reasons[i] = fmt.Sprintf("%s: %s", attempt.Backend, reason)
+	}
+	return "No scraper could read this recipe (" + strings.Join(reasons, "; ") + ")"
+}
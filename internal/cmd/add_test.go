@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadURLFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "netscape bookmarks",
+			content: `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+    <DT><H3>Recipes</H3>
+    <DL><p>
+        <DT><A HREF="https://example.com/curry?a=1&amp;b=2" ADD_DATE="1700000000">Curry</A>
+        <DT><a href='https://example.com/soup'>Soup</a>
+        <DT><A HREF=https://example.com/bread ADD_DATE=1700000000>Bread</A>
+        <DT><A HREF=https://example.com/cake>Cake</A>
+        <DT><A HREF="place:sort=8">Recent</A>
+    </DL><p>
+</DL><p>`,
+			want: []string{
+				"https://example.com/curry?a=1&b=2",
+				"https://example.com/soup",
+				"https://example.com/bread",
+				"https://example.com/cake",
+			},
+		},
+		{
+			name: "plain list",
+			content: `# weeknight dinners
+https://example.com/curry  spicy
+
+  https://example.com/soup
+#https://example.com/skipped
+`,
+			want: []string{"https://example.com/curry", "https://example.com/soup"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeRecipeFile(t, t.TempDir(), "urls", tt.content)
+			got, err := readURLFile(path)
+			if err != nil {
+				t.Fatalf("readURLFile: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readURLFile = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := readURLFile(t.TempDir() + "/missing"); err == nil {
+		t.Error("readURLFile of a missing file: want an error")
+	}
+}
+
+func TestUniqueURLs(t *testing.T) {
+	got := uniqueURLs([]string{"https://a.example", " https://b.example ", "", "https://a.example", "https://b.example"})
+	want := []string{"https://a.example", "https://b.example"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("uniqueURLs = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	dbMigrateCmd.Flags().Bool("status", false, "Show applied and pending migrations without changing anything")
+	dbMigrateCmd.Flags().Int("to", -1, "Migrate to a specific schema version (default: latest)")
+	dbMigrateCmd.Flags().String("database", "cookbook", "Database to migrate: cookbook or session")
+
+	dbCmd.AddCommand(dbMigrateCmd)
+}
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage the Yummy databases",
+	Long:  `Inspect and maintain the SQLite databases stored in ~/.yummy/db.`,
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply or inspect schema migrations",
+	Long: `Apply versioned schema migrations to the cookbook or session log database.
+A backup of the database file is written next to it before any pending migration runs.`,
+	Example: `
+		# Show the migration status of the cookbook
+		yummy db migrate --status
+
+		# Apply all pending migrations
+		yummy db migrate
+
+		# Migrate the session log to version 1
+		yummy db migrate --database session --to 1
+  	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		showStatus, _ := cmd.Flags().GetBool("status")
+		target, _ := cmd.Flags().GetInt("to")
+		database, _ := cmd.Flags().GetString("database")
+
+		// The cookbook is not opened, as opening it migrates to the latest version
+		datadir, cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		var migrator *db.Migrator
+		switch database {
+		case "cookbook":
+			migrator, err = db.NewCookbookMigrator(datadir, &cfg.Database)
+		case "session":
+			migrator, err = db.NewSessionLogMigrator(datadir, &cfg.Database)
+		default:
+			return fmt.Errorf("unknown database %q. Supported databases: cookbook, session", database)
+		}
+		if err != nil {
+			slog.Error("Failed to open database", "database", database, "error", err)
+			return fmt.Errorf("failed to open %s database: %v", database, err)
+		}
+
+		if showStatus {
+			return printMigrationStatus(migrator)
+		}
+
+		if target < 0 {
+			target = migrator.LatestVersion()
+		}
+
+		before, err := migrator.CurrentVersion()
+		if err != nil {
+			return fmt.Errorf("failed to read schema version: %v", err)
+		}
+
+		if err := migrator.MigrateTo(target); err != nil {
+			slog.Error("Migration failed", "database", database, "target", target, "error", err)
+			return fmt.Errorf("migration failed: %v", err)
+		}
+
+		if before == target {
+			fmt.Printf("✅ %s database already at version %d\n", database, target)
+			return nil
+		}
+
+		slog.Info("Database migrated", "database", database, "from", before, "to", target)
+		fmt.Printf("✅ %s database migrated from version %d to %d\n", database, before, target)
+		return nil
+	},
+}
+
+// printMigrationStatus prints every known migration with its applied state.
+func printMigrationStatus(migrator *db.Migrator) error {
+	current, err := migrator.CurrentVersion()
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %v", err)
+	}
+
+	statuses, err := migrator.Status()
+	if err != nil {
+		return fmt.Errorf("failed to read migration status: %v", err)
+	}
+
+	fmt.Printf("Current version: %d (latest: %d)\n\n", current, migrator.LatestVersion())
+	for _, s := range statuses {
+		if s.Applied {
+			fmt.Printf("  ✅ %3d  %-40s applied %s\n", s.Version, s.Name, s.AppliedAt.Format("2006-01-02 15:04"))
+		} else {
+			fmt.Printf("  ⏳ %3d  %-40s pending\n", s.Version, s.Name)
+		}
+	}
+	return nil
+}
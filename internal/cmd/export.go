@@ -1,73 +1,362 @@
 package cmd
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
-	"github.com/GarroshIcecream/yummy/internal/config"
 	db "github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/GarroshIcecream/yummy/internal/units"
+	"github.com/GarroshIcecream/yummy/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+func init() {
+	exportCmd.Flags().BoolP("all", "a", false, "Export every recipe in the cookbook")
+	exportCmd.Flags().String("category", "", "Only export recipes in this category")
+	exportCmd.Flags().String("author", "", "Only export recipes by this author")
+	exportCmd.Flags().Bool("favourite", false, "Only export favourite recipes")
+	exportCmd.Flags().StringP("collection", "c", "", "Export the recipes of this collection or saved search")
+	exportCmd.Flags().StringP("format", "f", "md", "Export format: md, json or jsonld (schema.org Recipe)")
+	exportCmd.Flags().StringP("output", "o", ".", "Directory to write exported files to")
+	exportCmd.Flags().String("archive", "", "Write all exported files into a single .zip or .tar.gz archive")
+	exportCmd.Flags().Float64("servings", 0, "Scale ingredient amounts to this many servings")
+	exportCmd.Flags().String("units", "", "Convert ingredient amounts: original, metric, us or uk (default from config)")
+}
+
 var exportCmd = &cobra.Command{
-	Use:   "export [recipe_id]",
-	Short: "Export a recipe to a file",
-	Long:  `Export a recipe to a file. The recipe can be provided as an argument.`,
+	Use:   "export [recipe_id...]",
+	Short: "Export recipes to files",
+	Long: `Export one or more recipes to markdown (.md), JSON (.json) or schema.org Recipe JSON-LD (.jsonld) files.
+Recipes can be selected by ID, or in bulk with --all, --collection and the category, author and favourite filters.
+The bulk selections cannot be combined with recipe IDs.
+Exported files can be imported again with yummy import.`,
 	Example: `
 		# Export a recipe to a file
 		yummy export 123
+
+		# Export the whole cookbook as JSON into ./backup
+		yummy export --all --format json --output ./backup
+
+		# Export a recipe as schema.org JSON-LD
+		yummy export 123 --format jsonld
+
+		# Export all favourite desserts into a single archive
+		yummy export --category dessert --favourite --archive desserts.zip
+
+		# Export a whole collection into a single archive
+		yummy export --collection thanksgiving-2026 --archive thanksgiving.zip
+
+		# Export a recipe scaled to 6 servings in metric units
+		yummy export 123 --servings 6 --units metric
   	`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) == 0 {
+		all, _ := cmd.Flags().GetBool("all")
+		format, _ := cmd.Flags().GetString("format")
+		outputDir, _ := cmd.Flags().GetString("output")
+		archivePath, _ := cmd.Flags().GetString("archive")
+		servings, _ := cmd.Flags().GetFloat64("servings")
+		unitSystem, _ := cmd.Flags().GetString("units")
+		collection, _ := cmd.Flags().GetString("collection")
+		filter := db.RecipeFilter{}
+		filter.Category, _ = cmd.Flags().GetString("category")
+		filter.Author, _ = cmd.Flags().GetString("author")
+		filter.FavouritesOnly, _ = cmd.Flags().GetBool("favourite")
+
+		format = strings.ToLower(strings.TrimPrefix(format, "."))
+		switch format {
+		case "md", "json", "jsonld":
+		default:
+			return fmt.Errorf("unsupported export format: %s. Supported formats: md, json, jsonld", format)
+		}
+		if servings < 0 {
+			return fmt.Errorf("servings must be positive, got %v", servings)
+		}
+
+		hasFilter := filter != (db.RecipeFilter{})
+		if len(args) == 0 && !all && !hasFilter && collection == "" {
 			slog.Error("Recipe ID is required", "args", args)
-			return fmt.Errorf("recipe ID is required")
+			return fmt.Errorf("recipe ID is required (or use --all, --collection, --category, --author, --favourite)")
+		}
+		if len(args) > 0 && (all || hasFilter || collection != "") {
+			return fmt.Errorf("recipe IDs cannot be combined with --all, --collection, --category, --author or --favourite")
 		}
 
-		// Parse recipe ID from arguments
-		var recipe_id uint
-		if _, err := fmt.Sscanf(args[0], "%d", &recipe_id); err != nil {
-			slog.Error("Invalid recipe ID", "recipeID", args[0], "error", err)
-			return fmt.Errorf("invalid recipe ID: %s", args[0])
+		// Parse recipe IDs from arguments
+		var recipeIDs []uint
+		for _, arg := range args {
+			var recipeID uint
+			if _, err := fmt.Sscanf(arg, "%d", &recipeID); err != nil {
+				slog.Error("Invalid recipe ID", "recipeID", arg, "error", err)
+				return fmt.Errorf("invalid recipe ID: %s", arg)
+			}
+			recipeIDs = append(recipeIDs, recipeID)
 		}
 
-		// Resolve user directory for data storage
-		datadir, err := resolveUserDir()
+		cfg, cookbook, err := openCookbook()
 		if err != nil {
-			return fmt.Errorf("failed to resolve user directory: %v", err)
+			return err
 		}
 
-		// Load configuration
-		cfg, err := config.LoadConfig(datadir)
+		if !cmd.Flags().Changed("units") {
+			unitSystem = cfg.General.UnitSystem
+		}
+		system, err := units.ParseSystem(unitSystem)
 		if err != nil {
-			return fmt.Errorf("failed to load configuration: %v", err)
+			return err
 		}
 
-		cookbook, err := db.NewCookBook(datadir, &cfg.Database)
-		if err != nil {
-			slog.Error("Failed to initialize cookbook", "error", err)
-			return fmt.Errorf("failed to initialize cookbook: %v", err)
+		if len(recipeIDs) == 0 && collection != "" {
+			// Export in the collection's order, narrowed by any other filter
+			recipes, err := cookbook.RecipesInCollection(collection)
+			if err != nil {
+				return err
+			}
+			for _, recipe := range recipes {
+				if filter.Matches(recipe) {
+					recipeIDs = append(recipeIDs, recipe.RecipeID)
+				}
+			}
+		} else if len(recipeIDs) == 0 {
+			recipes, err := cookbook.FilterRecipes(filter)
+			if err != nil {
+				slog.Error("Failed to fetch recipes", "error", err)
+				return fmt.Errorf("failed to fetch recipes: %v", err)
+			}
+			for _, recipe := range recipes {
+				recipeIDs = append(recipeIDs, recipe.RecipeID)
+			}
 		}
 
-		recipe, err := cookbook.GetFullRecipe(recipe_id)
+		if len(recipeIDs) == 0 {
+			fmt.Println("No recipes matched, nothing to export")
+			return nil
+		}
+
+		sink, err := newExportSink(outputDir, archivePath)
 		if err != nil {
-			slog.Error("Failed to fetch recipe", "recipeID", recipe_id, "error", err)
-			return fmt.Errorf("failed to fetch recipe: %v", err)
+			slog.Error("Failed to create export destination", "error", err)
+			return fmt.Errorf("failed to create export destination: %v", err)
 		}
 
-		// Export the recipe to a file
-		normalizedName := strings.ToLower(strings.ReplaceAll(recipe.RecipeName, " ", "_"))
-		filename := fmt.Sprintf("%s.md", normalizedName)
-		content := recipe.FormatRecipeMarkdown()
+		exported, err := exportRecipes(cookbook, recipeIDs, exportOptions{format: format, servings: servings, units: system}, sink)
+		if closeErr := sink.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
 
-		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
-			slog.Error("Failed to write file", "filename", filename, "error", err)
-			return fmt.Errorf("failed to write file %s: %v", filename, err)
+		destination := outputDir
+		if archivePath != "" {
+			destination = archivePath
 		}
 
-		slog.Info("Recipe exported successfully", "filename", filename)
-		fmt.Printf("✅ Recipe exported to %s\n", filename)
+		slog.Info("Recipes exported successfully", "count", exported, "destination", destination)
+		if len(args) == 1 && exported == 1 {
+			fmt.Printf("✅ Recipe exported to %s\n", sink.LastPath())
+		} else {
+			fmt.Printf("✅ %d recipes exported to %s\n", exported, destination)
+		}
 		return nil
 	},
 }
+
+// exportOptions controls how exported recipes are rendered.
+type exportOptions struct {
+	format   string  // md, json or jsonld
+	servings float64 // scale recipes to this many servings; 0 keeps them as saved
+	units    units.System
+}
+
+// exportRecipes renders each recipe in the given format and writes it to the sink.
+// File names are derived from recipe names and de-duplicated with the recipe ID.
+func exportRecipes(cookbook *db.CookBook, recipeIDs []uint, opts exportOptions, sink exportSink) (int, error) {
+	format := opts.format
+	usedNames := make(map[string]bool)
+	exported := 0
+
+	for _, recipeID := range recipeIDs {
+		recipe, err := cookbook.GetFullRecipe(recipeID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe", "recipeID", recipeID, "error", err)
+			return exported, fmt.Errorf("failed to fetch recipe %d: %v", recipeID, err)
+		}
+
+		if opts.servings > 0 {
+			scaled, err := recipe.ScaledToServings(opts.servings)
+			if err != nil {
+				slog.Warn("Exporting recipe unscaled", "recipeID", recipeID, "error", err)
+				fmt.Printf("⚠️  %s has no known servings, exported unscaled\n", recipe.RecipeName)
+			} else {
+				recipe = scaled
+			}
+		}
+		recipe = recipe.ConvertedTo(opts.units)
+
+		var content string
+		switch format {
+		case "json":
+			content, err = recipe.FormatRecipeJSON()
+			if err != nil {
+				return exported, err
+			}
+		case "jsonld":
+			content, err = recipe.FormatRecipeJSONLD()
+			if err != nil {
+				return exported, err
+			}
+		default:
+			content = recipe.FormatRecipeMarkdown()
+		}
+
+		stem := utils.RecipeFileSlug(recipe.RecipeName)
+		filename := fmt.Sprintf("%s.%s", stem, format)
+		if usedNames[filename] {
+			filename = fmt.Sprintf("%s_%d.%s", stem, recipe.RecipeID, format)
+		}
+		usedNames[filename] = true
+
+		if err := sink.Write(filename, []byte(content)); err != nil {
+			slog.Error("Failed to write file", "filename", filename, "error", err)
+			return exported, fmt.Errorf("failed to write file %s: %v", filename, err)
+		}
+		exported++
+	}
+
+	return exported, nil
+}
+
+// exportSink is the destination for exported recipe files.
+type exportSink interface {
+	Write(name string, data []byte) error
+	LastPath() string
+	Close() error
+}
+
+// newExportSink returns an archive sink when archivePath is set, otherwise a
+// sink writing plain files into outputDir.
+func newExportSink(outputDir string, archivePath string) (exportSink, error) {
+	if archivePath == "" {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return nil, err
+		}
+		return &dirSink{dir: outputDir}, nil
+	}
+
+	lower := strings.ToLower(archivePath)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		f, err := os.Create(archivePath)
+		if err != nil {
+			return nil, err
+		}
+		return &zipSink{file: f, writer: zip.NewWriter(f)}, nil
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		f, err := os.Create(archivePath)
+		if err != nil {
+			return nil, err
+		}
+		gz := gzip.NewWriter(f)
+		return &tarSink{file: f, gzip: gz, writer: tar.NewWriter(gz)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported archive type: %s. Supported archives: .zip, .tar.gz", archivePath)
+	}
+}
+
+// dirSink writes each file into a directory.
+type dirSink struct {
+	dir  string
+	last string
+}
+
+func (s *dirSink) Write(name string, data []byte) error {
+	s.last = filepath.Join(s.dir, name)
+	return os.WriteFile(s.last, data, 0644)
+}
+
+func (s *dirSink) LastPath() string {
+	return s.last
+}
+
+func (s *dirSink) Close() error {
+	return nil
+}
+
+// zipSink writes every file into a single zip archive.
+type zipSink struct {
+	file   *os.File
+	writer *zip.Writer
+	last   string
+}
+
+func (s *zipSink) Write(name string, data []byte) error {
+	w, err := s.writer.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	s.last = name
+	_, err = w.Write(data)
+	return err
+}
+
+func (s *zipSink) LastPath() string {
+	return s.file.Name() + ":" + s.last
+}
+
+func (s *zipSink) Close() error {
+	return closeAll(s.writer, s.file)
+}
+
+// tarSink writes every file into a single gzipped tar archive.
+type tarSink struct {
+	file   *os.File
+	gzip   *gzip.Writer
+	writer *tar.Writer
+	last   string
+}
+
+func (s *tarSink) Write(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := s.writer.WriteHeader(header); err != nil {
+		return err
+	}
+	s.last = name
+	_, err := s.writer.Write(data)
+	return err
+}
+
+func (s *tarSink) LastPath() string {
+	return s.file.Name() + ":" + s.last
+}
+
+func (s *tarSink) Close() error {
+	return closeAll(s.writer, s.gzip, s.file)
+}
+
+// closeAll closes each closer in order and returns the first error.
+func closeAll(closers ...io.Closer) error {
+	var firstErr error
+	for _, c := range closers {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
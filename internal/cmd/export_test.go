@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportRejectsIDsWithBulkSelection(t *testing.T) {
+	for _, flag := range []string{"all", "favourite", "category", "author", "collection"} {
+		t.Run(flag, func(t *testing.T) {
+			value := "true"
+			if flag != "all" && flag != "favourite" {
+				value = "dessert"
+			}
+			if err := exportCmd.Flags().Set(flag, value); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				exportFlag := exportCmd.Flags().Lookup(flag)
+				exportFlag.Value.Set(exportFlag.DefValue)
+				exportFlag.Changed = false
+			})
+
+			err := exportCmd.RunE(exportCmd, []string{"1"})
+			if err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+				t.Errorf("export 1 --%s: err = %v, want cannot be combined", flag, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// outputFormat is how the recipe commands print their results
+type outputFormat string
+
+const (
+	formatTable    outputFormat = "table"
+	formatJSON     outputFormat = "json"
+	formatMarkdown outputFormat = "md"
+)
+
+// addFormatFlag adds the --format flag read by getOutputFormat
+func addFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().String("format", string(formatTable), "Output format: table, json or md")
+}
+
+func getOutputFormat(cmd *cobra.Command) (outputFormat, error) {
+	format, _ := cmd.Flags().GetString("format")
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "table", "text", "":
+		return formatTable, nil
+	case "json":
+		return formatJSON, nil
+	case "md", "markdown":
+		return formatMarkdown, nil
+	}
+	return "", fmt.Errorf("unsupported output format: %s. Supported formats: table, json, md", format)
+}
+
+// recipeSummaryJSON is the JSON shape of a recipe in listings
+type recipeSummaryJSON struct {
+	ID           uint     `json:"id"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	Author       string   `json:"author,omitempty"`
+	Favourite    bool     `json:"favourite"`
+	Rating       int8     `json:"rating,omitempty"`
+	TotalMinutes int      `json:"total_minutes,omitempty"`
+	Quantity     string   `json:"quantity,omitempty"`
+	Categories   []string `json:"categories,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	LastCooked   string   `json:"last_cooked,omitempty"`
+	Match        string   `json:"match,omitempty"`
+}
+
+// recipeDetailJSON is the JSON shape of a whole recipe: its export shape
+// with the fields only the cookbook knows
+type recipeDetailJSON struct {
+	ID uint `json:"id"`
+	utils.RecipeJSON
+}
+
+// totalDuration returns the total time of a recipe, or its prep and cook time
+func totalDuration(recipe utils.RecipeRaw) time.Duration {
+	if recipe.Metadata.TotalTime > 0 {
+		return recipe.Metadata.TotalTime
+	}
+	return recipe.Metadata.PrepTime + recipe.Metadata.CookTime
+}
+
+func totalTime(recipe utils.RecipeRaw) string {
+	return utils.FormatDurationHuman(totalDuration(recipe))
+}
+
+func toSummaryJSON(recipe utils.RecipeRaw, match string) recipeSummaryJSON {
+	return recipeSummaryJSON{
+		ID:           recipe.RecipeID,
+		Name:         recipe.RecipeName,
+		Description:  recipe.RecipeDescription,
+		Author:       recipe.Metadata.Author,
+		Favourite:    recipe.IsFavourite,
+		Rating:       recipe.Metadata.Rating,
+		TotalMinutes: int(totalDuration(recipe).Minutes()),
+		Quantity:     recipe.Metadata.Quantity,
+		Categories:   recipe.Metadata.Categories,
+		URL:          recipe.Metadata.URL,
+		LastCooked:   lastCooked(recipe),
+		Match:        match,
+	}
+}
+
+func lastCooked(recipe utils.RecipeRaw) string {
+	if recipe.Metadata.LastCooked.IsZero() {
+		return ""
+	}
+	return recipe.Metadata.LastCooked.Format(utils.PlanDateLayout)
+}
+
+// printRecipes prints recipes as a table, a JSON array or a markdown table.
+// matches, when given, holds where each recipe matched a search.
+func printRecipes(format outputFormat, recipes []utils.RecipeRaw, matches []string) error {
+	match := func(i int) string {
+		if i < len(matches) {
+			return strings.Join(strings.Fields(matches[i]), " ")
+		}
+		return ""
+	}
+
+	switch format {
+	case formatJSON:
+		summaries := make([]recipeSummaryJSON, len(recipes))
+		for i, recipe := range recipes {
+			summaries[i] = toSummaryJSON(recipe, match(i))
+		}
+		return printJSON(summaries)
+
+	case formatMarkdown:
+		header := "| ID | Name | Rating | Time | Last cooked |"
+		rule := "|---:|---|---|---|---|"
+		if matches != nil {
+			header += " Match |"
+			rule += "---|"
+		}
+		fmt.Println(header)
+		fmt.Println(rule)
+		for i, recipe := range recipes {
+			name := markdownCell(recipe.RecipeName)
+			if recipe.IsFavourite {
+				name += " ⭐"
+			}
+			row := fmt.Sprintf("| %d | %s | %s | %s | %s |", recipe.RecipeID, name,
+				utils.FormatRating(recipe.Metadata.Rating), totalTime(recipe), lastCooked(recipe))
+			if matches != nil {
+				row += " " + markdownCell(match(i)) + " |"
+			}
+			fmt.Println(row)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := "ID\tNAME\tRATING\tTIME\tLAST COOKED"
+	if matches != nil {
+		header += "\tMATCH"
+	}
+	fmt.Fprintln(w, header)
+	for i, recipe := range recipes {
+		name := recipe.RecipeName
+		if recipe.IsFavourite {
+			name += " ⭐"
+		}
+		row := fmt.Sprintf("%d\t%s\t%s\t%s\t%s", recipe.RecipeID, name,
+			utils.FormatRating(recipe.Metadata.Rating), totalTime(recipe), lastCooked(recipe))
+		if matches != nil {
+			row += "\t" + match(i)
+		}
+		fmt.Fprintln(w, row)
+	}
+	return w.Flush()
+}
+
+// printRecipe prints a whole recipe as text, JSON or the markdown of export
+func printRecipe(format outputFormat, recipe *utils.RecipeRaw) error {
+	switch format {
+	case formatJSON:
+		return printJSON(recipeDetailJSON{
+			ID:         recipe.RecipeID,
+			RecipeJSON: recipe.ToRecipeJSON(),
+		})
+	case formatMarkdown:
+		fmt.Print(recipe.FormatRecipeMarkdown())
+		return nil
+	}
+
+	meta := recipe.Metadata
+	fmt.Printf("%s (#%d)\n\n", recipe.RecipeName, recipe.RecipeID)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, field := range [][2]string{
+		{"Author", meta.Author},
+		{"Servings", meta.Quantity},
+		{"Prep time", utils.FormatDurationHuman(meta.PrepTime)},
+		{"Cook time", utils.FormatDurationHuman(meta.CookTime)},
+		{"Total time", totalTime(*recipe)},
+		{"Rating", utils.FormatRating(meta.Rating)},
+		{"Categories", strings.Join(meta.Categories, ", ")},
+		{"Source", meta.URL},
+	} {
+		if field[1] != "" {
+			fmt.Fprintf(w, "%s\t%s\n", field[0], field[1])
+		}
+	}
+	if meta.Favourite {
+		fmt.Fprintln(w, "Favourite\t⭐")
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if recipe.RecipeDescription != "" {
+		fmt.Printf("\n%s\n", recipe.RecipeDescription)
+	}
+
+	fmt.Println("\nIngredients")
+	group := ""
+	for _, ing := range meta.Ingredients {
+		if ing.Group != group {
+			group = ing.Group
+			if group != "" {
+				fmt.Printf("  %s:\n", group)
+			}
+		}
+		fmt.Printf("  • %s\n", utils.FormatIngredientLine(ing))
+	}
+
+	fmt.Println("\nInstructions")
+	for i, step := range meta.Instructions {
+		if title, starts := meta.InstructionSectionAt(i); starts {
+			fmt.Printf("  %s:\n", title)
+		}
+		fmt.Printf("  %d. %s\n", i+1, step)
+		if note := meta.StepNoteAt(i); note != "" {
+			fmt.Printf("     💡 %s\n", note)
+		}
+	}
+
+	if meta.Notes != "" {
+		fmt.Printf("\nNotes\n%s\n", meta.Notes)
+	}
+	return nil
+}
+
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// markdownCell escapes the pipes of a markdown table cell
+func markdownCell(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+)
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	fnErr := fn()
+	w.Close()
+	out := <-done
+	if fnErr != nil {
+		t.Fatalf("printing failed: %v", fnErr)
+	}
+	return out
+}
+
+func testRecipes() []utils.RecipeRaw {
+	return []utils.RecipeRaw{
+		{
+			RecipeID:    7,
+			RecipeName:  "Chicken Curry",
+			IsFavourite: true,
+			Metadata: utils.RecipeMetadata{
+				Author:     "Ana",
+				PrepTime:   15 * time.Minute,
+				CookTime:   30 * time.Minute,
+				Rating:     4,
+				Categories: []string{"dinner"},
+				LastCooked: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{RecipeID: 12, RecipeName: "Toast | Jam"},
+	}
+}
+
+func TestToSummaryJSON(t *testing.T) {
+	recipes := testRecipes()
+	tests := []struct {
+		recipe utils.RecipeRaw
+		match  string
+		want   recipeSummaryJSON
+	}{
+		{
+			recipe: recipes[0],
+			match:  "**chicken** thighs",
+			want: recipeSummaryJSON{
+				ID: 7, Name: "Chicken Curry", Author: "Ana", Favourite: true, Rating: 4,
+				TotalMinutes: 45, Categories: []string{"dinner"}, LastCooked: "2026-03-01",
+				Match: "**chicken** thighs",
+			},
+		},
+		{
+			recipe: recipes[1],
+			want:   recipeSummaryJSON{ID: 12, Name: "Toast | Jam"},
+		},
+	}
+	for _, tt := range tests {
+		if got := toSummaryJSON(tt.recipe, tt.match); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("toSummaryJSON(%q) = %+v, want %+v", tt.recipe.RecipeName, got, tt.want)
+		}
+	}
+}
+
+func TestPrintRecipesJSON(t *testing.T) {
+	out := captureStdout(t, func() error {
+		return printRecipes(formatJSON, testRecipes(), []string{"in the  name\n", ""})
+	})
+
+	var got []map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, out)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d recipes, want 2", len(got))
+	}
+	if got[0]["id"] != 7.0 || got[0]["total_minutes"] != 45.0 || got[0]["match"] != "in the name" {
+		t.Errorf("first recipe = %v", got[0])
+	}
+	// Empty fields are left out, favourite is always there
+	want := map[string]any{"id": 12.0, "name": "Toast | Jam", "favourite": false}
+	if !reflect.DeepEqual(got[1], want) {
+		t.Errorf("second recipe = %v, want %v", got[1], want)
+	}
+}
+
+func TestPrintRecipesTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  outputFormat
+		matches []string
+		want    []string
+	}{
+		{
+			name:   "table",
+			format: formatTable,
+			want: []string{
+				"ID  NAME             RATING  TIME    LAST COOKED",
+				"7   Chicken Curry ⭐  ★★★★☆   45 min  2026-03-01",
+				"12  Toast | Jam",
+			},
+		},
+		{
+			name:    "table with matches",
+			format:  formatTable,
+			matches: []string{"curry", ""},
+			want: []string{
+				"ID  NAME             RATING  TIME    LAST COOKED  MATCH",
+				"7   Chicken Curry ⭐  ★★★★☆   45 min  2026-03-01   curry",
+				"12  Toast | Jam",
+			},
+		},
+		{
+			name:   "markdown",
+			format: formatMarkdown,
+			want: []string{
+				"| ID | Name | Rating | Time | Last cooked |",
+				"|---:|---|---|---|---|",
+				"| 7 | Chicken Curry ⭐ | ★★★★☆ | 45 min | 2026-03-01 |",
+				`| 12 | Toast \| Jam |  |  |  |`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := captureStdout(t, func() error {
+				return printRecipes(tt.format, testRecipes(), tt.matches)
+			})
+			lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+			for i := range lines {
+				lines[i] = strings.TrimRight(lines[i], " ")
+			}
+			if !reflect.DeepEqual(lines, tt.want) {
+				t.Errorf("printRecipes(%s) =\n%s\nwant\n%s", tt.format, strings.Join(lines, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
@@ -2,26 +2,42 @@ package cmd
 
 import (
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
-	"github.com/GarroshIcecream/yummy/internal/config"
 	db "github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/GarroshIcecream/yummy/internal/scrape"
 	"github.com/GarroshIcecream/yummy/internal/utils"
 	"github.com/spf13/cobra"
 )
 
+// Duplicate policies for --on-duplicate
+const (
+	onDuplicateSkip    = "skip"
+	onDuplicateReplace = "replace"
+	onDuplicateRename  = "rename"
+)
+
 func init() {
-	importCmd.Flags().StringP("name", "n", "", "Custom name for the imported recipe")
+	importCmd.Flags().StringP("name", "n", "", "Custom name for the imported recipe (single file only)")
+	importCmd.Flags().BoolP("recursive", "r", false, "Import files from sub-directories as well")
+	importCmd.Flags().Bool("dry-run", false, "Report what would be imported without changing the cookbook")
+	importCmd.Flags().String("on-duplicate", onDuplicateSkip, "What to do with recipes that already exist: skip, replace or rename")
 }
 
 var importCmd = &cobra.Command{
-	Use:   "import [file_path]",
-	Short: "Import a recipe from a markdown or JSON file",
-	Long: `Import a recipe from a file. Supports both markdown (.md) and JSON (.json) formats.
-The markdown format should match the export format used by yummy export command.`,
+	Use:   "import [path...]",
+	Short: "Import recipes from markdown, JSON or HTML files",
+	Long: `Import recipes from files or directories. Supports markdown (.md), JSON (.json), schema.org Recipe JSON-LD (.json, .jsonld)
+and saved web pages (.html) containing schema.org Recipe JSON-LD or microdata.
+The markdown format should match the export format used by yummy export command.
+
+A recipe is considered a duplicate when a recipe with the same source URL or the same name
+already exists. Use --on-duplicate to skip it, replace the existing recipe or import it under a new name.`,
 	Example: `
 		# Import a recipe from markdown file
 		yummy import recipe.md
@@ -29,70 +45,349 @@ The markdown format should match the export format used by yummy export command.
 		# Import a recipe from JSON file
 		yummy import recipe.json
 
+		# Import a schema.org Recipe JSON-LD file
+		yummy import recipe.jsonld
+
+		# Import a saved recipe web page, no Python or network needed
+		yummy import page.html
+
 		# Import with custom name
 		yummy import recipe.md --name "My Custom Recipe"
+
+		# Preview importing a whole directory tree
+		yummy import ./recipes --recursive --dry-run
+
+		# Import a directory, replacing recipes that already exist
+		yummy import ./recipes --on-duplicate=replace
   	`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		customName, _ := cmd.Flags().GetString("name")
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		onDuplicate, _ := cmd.Flags().GetString("on-duplicate")
 
 		if len(args) == 0 {
 			slog.Error("File path is required", "args", args)
 			return fmt.Errorf("file path is required")
 		}
 
-		filePath := args[0]
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			slog.Error("File does not exist", "path", filePath, "error", err)
-			return fmt.Errorf("file does not exist: %s", filePath)
-		}
-
-		// Determine file format
-		ext := strings.ToLower(filepath.Ext(filePath))
-		var recipeRaw *utils.RecipeRaw
-		var err error
-
-		switch ext {
-		case ".md":
-			recipeRaw, err = utils.ParseMarkdownRecipe(filePath, customName)
-		case ".json":
-			recipeRaw, err = utils.ParseJSONRecipe(filePath, customName)
+		switch onDuplicate {
+		case onDuplicateSkip, onDuplicateReplace, onDuplicateRename:
 		default:
-			slog.Error("Unsupported file format", "format", ext)
-			return fmt.Errorf("unsupported file format: %s. Supported formats: .md, .json", ext)
+			return fmt.Errorf("unknown duplicate policy: %s. Supported policies: skip, replace, rename", onDuplicate)
 		}
 
+		files, err := collectImportFiles(args, recursive)
 		if err != nil {
-			slog.Error("Failed to parse recipe", "filePath", filePath, "error", err)
-			return fmt.Errorf("failed to parse recipe: %v", err)
+			return err
+		}
+		if len(files) == 0 {
+			fmt.Println("No recipe files (.md, .json, .jsonld, .html) found, nothing to import")
+			return nil
+		}
+		if customName != "" && len(files) > 1 {
+			return fmt.Errorf("--name can only be used when importing a single file")
 		}
 
-		// Resolve user directory for data storage
-		datadir, err := resolveUserDir()
+		_, cookbook, err := openCookbook()
 		if err != nil {
-			return fmt.Errorf("failed to resolve user directory: %v", err)
+			return err
+		}
+
+		importer := &recipeImporter{
+			cookbook:    cookbook,
+			onDuplicate: onDuplicate,
+			dryRun:      dryRun,
+			names:       make(map[string]uint),
+			urls:        make(map[string]uint),
+		}
+
+		if dryRun {
+			fmt.Println("🔍 Dry run — the cookbook will not be changed")
 		}
 
-		// Load configuration
-		cfg, err := config.LoadConfig(datadir)
+		summary := map[importStatus]int{}
+		for _, file := range files {
+			result := importer.importFile(file, customName)
+			summary[result.status]++
+			printImportResult(result, dryRun)
+		}
+
+		fmt.Println()
+		fmt.Printf("%d files: %d imported, %d replaced, %d renamed, %d skipped, %d failed\n",
+			len(files), summary[importImported], summary[importReplaced], summary[importRenamed],
+			summary[importSkipped], summary[importFailed])
+
+		slog.Info("Import finished", "files", len(files), "dryRun", dryRun, "failed", summary[importFailed])
+		if summary[importFailed] > 0 {
+			return fmt.Errorf("%d of %d files failed to import", summary[importFailed], len(files))
+		}
+		return nil
+	},
+}
+
+// collectImportFiles expands the given paths into a sorted list of recipe files.
+// Explicit file arguments are always kept so that unsupported formats are
+// reported; directories only contribute supported recipe files.
+func collectImportFiles(paths []string, recursive bool) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
 		if err != nil {
-			return fmt.Errorf("failed to load configuration: %v", err)
+			slog.Error("File does not exist", "path", path, "error", err)
+			return nil, fmt.Errorf("file does not exist: %s", path)
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
 		}
 
-		cookbook, err := db.NewCookBook(datadir, &cfg.Database)
+		var found []string
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != path && !recursive {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if isImportableFile(p) {
+				found = append(found, p)
+			}
+			return nil
+		})
 		if err != nil {
-			slog.Error("Failed to initialize cookbook", "error", err)
-			return fmt.Errorf("failed to initialize cookbook: %v", err)
+			slog.Error("Failed to read directory", "path", path, "error", err)
+			return nil, fmt.Errorf("failed to read directory %s: %v", path, err)
+		}
+		sort.Strings(found)
+		files = append(files, found...)
+	}
+	return files, nil
+}
+
+func isImportableFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".json", ".jsonld", ".html", ".htm":
+		return true
+	}
+	return false
+}
+
+// parseRecipeFile parses a recipe file based on its extension.
+func parseRecipeFile(filePath string, customName string) (*utils.RecipeRaw, error) {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	switch ext {
+	case ".md":
+		return utils.ParseMarkdownRecipe(filePath, customName)
+	case ".json", ".jsonld":
+		return utils.ParseJSONRecipe(filePath, customName)
+	case ".html", ".htm":
+		return scrape.ParseHTMLRecipeFile(filePath, customName)
+	default:
+		slog.Error("Unsupported file format", "format", ext)
+		return nil, fmt.Errorf("unsupported file format: %s. Supported formats: .md, .json, .jsonld, .html", ext)
+	}
+}
+
+type importStatus int
+
+const (
+	importImported importStatus = iota
+	importReplaced
+	importRenamed
+	importSkipped
+	importFailed
+)
+
+// importResult is the outcome of importing a single file.
+type importResult struct {
+	file        string
+	status      importStatus
+	recipeName  string
+	recipeID    uint
+	duplicateOf uint
+	err         error
+}
+
+// recipeImporter imports files one at a time, applying the duplicate policy.
+// names and urls map the recipes imported earlier in the same run to the ID
+// they were saved under (0 in a dry run), so a later duplicate replaces that
+// recipe and a dry run reports the same duplicates a real import would hit.
+type recipeImporter struct {
+	cookbook    *db.CookBook
+	onDuplicate string
+	dryRun      bool
+	names       map[string]uint
+	urls        map[string]uint
+}
+
+func (imp *recipeImporter) importFile(filePath string, customName string) importResult {
+	result := importResult{file: filePath}
+
+	recipeRaw, err := parseRecipeFile(filePath, customName)
+	if err != nil {
+		slog.Error("Failed to parse recipe", "filePath", filePath, "error", err)
+		result.status = importFailed
+		result.err = fmt.Errorf("failed to parse recipe: %v", err)
+		return result
+	}
+	if strings.TrimSpace(recipeRaw.RecipeName) == "" {
+		result.status = importFailed
+		result.err = fmt.Errorf("recipe has no name")
+		return result
+	}
+	result.recipeName = recipeRaw.RecipeName
+
+	duplicateOf, seen, err := imp.findDuplicate(recipeRaw)
+	if err != nil {
+		result.status = importFailed
+		result.err = fmt.Errorf("failed to check for duplicates: %v", err)
+		return result
+	}
+	result.duplicateOf = duplicateOf
+
+	isDuplicate := duplicateOf != 0 || seen
+	result.status = importImported
+	if isDuplicate {
+		switch imp.onDuplicate {
+		case onDuplicateSkip:
+			result.status = importSkipped
+			return result
+		case onDuplicateReplace:
+			result.status = importReplaced
+		case onDuplicateRename:
+			name, err := imp.uniqueName(recipeRaw.RecipeName)
+			if err != nil {
+				result.status = importFailed
+				result.err = fmt.Errorf("failed to pick a new name: %v", err)
+				return result
+			}
+			recipeRaw.RecipeName = name
+			result.recipeName = name
+			result.status = importRenamed
+		}
+	}
+
+	if imp.dryRun {
+		if result.status == importReplaced {
+			imp.remember(recipeRaw, duplicateOf)
+		} else {
+			imp.remember(recipeRaw, 0)
+		}
+		return result
+	}
+
+	if result.status == importReplaced {
+		recipeRaw.RecipeID = duplicateOf
+		if err := imp.cookbook.UpdateRecipe(recipeRaw); err != nil {
+			slog.Error("Failed to replace recipe", "filePath", filePath, "id", duplicateOf, "error", err)
+			result.status = importFailed
+			result.err = fmt.Errorf("failed to replace recipe: %v", err)
+			return result
 		}
+		result.recipeID = duplicateOf
+		imp.remember(recipeRaw, duplicateOf)
+		return result
+	}
+
+	recipeID, err := imp.cookbook.SaveScrapedRecipe(recipeRaw)
+	if err != nil {
+		slog.Error("Failed to save recipe", "filePath", filePath, "error", err)
+		result.status = importFailed
+		result.err = fmt.Errorf("failed to save recipe: %v", err)
+		return result
+	}
+	result.recipeID = recipeID
+	imp.remember(recipeRaw, recipeID)
+	slog.Info("Recipe imported successfully", "filePath", filePath, "id", recipeID)
+	return result
+}
 
-		// Save the recipe
-		recipeID, err := cookbook.SaveScrapedRecipe(recipeRaw)
+// remember records a recipe imported in this run under the ID it was saved as
+func (imp *recipeImporter) remember(recipeRaw *utils.RecipeRaw, recipeID uint) {
+	imp.names[strings.ToLower(recipeRaw.RecipeName)] = recipeID
+	if recipeRaw.Metadata.URL != "" {
+		imp.urls[recipeRaw.Metadata.URL] = recipeID
+	}
+}
+
+// findDuplicate returns the ID of a recipe with the same source URL or name,
+// and whether it was imported earlier in this run. A recipe of this run is
+// preferred, as the cookbook may still hold an older one with the same name.
+// In a dry run the ID of a recipe of this run is 0.
+func (imp *recipeImporter) findDuplicate(recipeRaw *utils.RecipeRaw) (uint, bool, error) {
+	if url := recipeRaw.Metadata.URL; url != "" {
+		if id, seen := imp.urls[url]; seen {
+			return id, true, nil
+		}
+		id, err := imp.cookbook.RecipeExistsByURL(url)
+		if err != nil || id != 0 {
+			return id, false, err
+		}
+	}
+
+	if id, seen := imp.names[strings.ToLower(recipeRaw.RecipeName)]; seen {
+		return id, true, nil
+	}
+	id, err := imp.cookbook.RecipeExistsByName(recipeRaw.RecipeName)
+	return id, false, err
+}
+
+// uniqueName appends " (2)", " (3)", ... until the name is unused.
+func (imp *recipeImporter) uniqueName(name string) (string, error) {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		if _, seen := imp.names[strings.ToLower(candidate)]; seen {
+			continue
+		}
+		id, err := imp.cookbook.RecipeExistsByName(candidate)
 		if err != nil {
-			slog.Error("Failed to save recipe", "error", err)
-			return fmt.Errorf("failed to save recipe: %v", err)
+			return "", err
+		}
+		if id == 0 {
+			return candidate, nil
 		}
+	}
+}
 
-		slog.Info("Recipe imported successfully", "filePath", filePath, "id", recipeID)
-		fmt.Printf("✅ Recipe imported successfully! ID: %d\n", recipeID)
-		return nil
-	},
+func printImportResult(result importResult, dryRun bool) {
+	verb := map[importStatus]string{
+		importImported: "imported",
+		importReplaced: "replaced",
+		importRenamed:  "imported as",
+	}
+	if dryRun {
+		verb = map[importStatus]string{
+			importImported: "would import",
+			importReplaced: "would replace",
+			importRenamed:  "would import as",
+		}
+	}
+
+	switch result.status {
+	case importFailed:
+		fmt.Printf("❌ %s: %v\n", result.file, result.err)
+	case importSkipped:
+		if result.duplicateOf != 0 {
+			fmt.Printf("⏭️  %s: skipped %q, duplicate of recipe #%d\n", result.file, result.recipeName, result.duplicateOf)
+		} else {
+			fmt.Printf("⏭️  %s: skipped %q, duplicate of an earlier file\n", result.file, result.recipeName)
+		}
+	case importReplaced:
+		if result.duplicateOf != 0 {
+			fmt.Printf("♻️  %s: %s recipe #%d with %q\n", result.file, verb[result.status], result.duplicateOf, result.recipeName)
+		} else {
+			fmt.Printf("♻️  %s: %s the recipe of an earlier file with %q\n", result.file, verb[result.status], result.recipeName)
+		}
+	default:
+		if result.recipeID != 0 {
+			fmt.Printf("✅ %s: %s %q (ID: %d)\n", result.file, verb[result.status], result.recipeName, result.recipeID)
+		} else {
+			fmt.Printf("✅ %s: %s %q\n", result.file, verb[result.status], result.recipeName)
+		}
+	}
 }
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+)
+
+// newTestCookbook opens an empty cookbook in a temporary directory
+func newTestCookbook(t *testing.T) *db.CookBook {
+	t.Helper()
+	dbConfig := config.NewDefaultDatabaseConfig()
+	cookbook, err := db.NewCookBook(t.TempDir(), &dbConfig)
+	if err != nil {
+		t.Fatalf("NewCookBook: %v", err)
+	}
+	return cookbook
+}
+
+// writeRecipeFile writes a JSON recipe to the directory and returns its path
+func writeRecipeFile(t *testing.T, dir, file, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, file)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", file, err)
+	}
+	return path
+}
+
+func newTestImporter(cookbook *db.CookBook, onDuplicate string, dryRun bool) *recipeImporter {
+	return &recipeImporter{
+		cookbook:    cookbook,
+		onDuplicate: onDuplicate,
+		dryRun:      dryRun,
+		names:       make(map[string]uint),
+		urls:        make(map[string]uint),
+	}
+}
+
+func TestImportReplaceDuplicateOfSameRun(t *testing.T) {
+	cookbook := newTestCookbook(t)
+	dir := t.TempDir()
+	first := writeRecipeFile(t, dir, "soup.json", `{"name": "Tomato Soup", "description": "first", "url": "https://example.com/soup",
+		"author": "Ana", "prep_time": "10m", "rating": 2,
+		"ingredients": [{"amount": "1", "unit": "kg", "name": "tomatoes"}], "instructions": ["Simmer."]}`)
+	second := writeRecipeFile(t, dir, "soup-again.json", `{"name": "tomato soup", "description": "second", "rating": 5, "favourite": true,
+		"ingredients": [{"amount": "2", "unit": "kg", "name": "tomatoes"}], "instructions": ["Simmer longer."]}`)
+
+	// A dry run reports the second file as replacing the first
+	dry := newTestImporter(cookbook, onDuplicateReplace, true)
+	if result := dry.importFile(first, ""); result.status != importImported {
+		t.Fatalf("dry run of the first file: status %v, err %v", result.status, result.err)
+	}
+	if result := dry.importFile(second, ""); result.status != importReplaced {
+		t.Errorf("dry run of the second file: status %v, want replaced", result.status)
+	}
+
+	imp := newTestImporter(cookbook, onDuplicateReplace, false)
+	firstResult := imp.importFile(first, "")
+	if firstResult.status != importImported || firstResult.recipeID == 0 {
+		t.Fatalf("first file: status %v, ID %d, err %v", firstResult.status, firstResult.recipeID, firstResult.err)
+	}
+	secondResult := imp.importFile(second, "")
+	if secondResult.status != importReplaced || secondResult.recipeID != firstResult.recipeID {
+		t.Fatalf("second file: status %v, ID %d, err %v; want replaced #%d",
+			secondResult.status, secondResult.recipeID, secondResult.err, firstResult.recipeID)
+	}
+
+	recipes, err := cookbook.AllRecipes()
+	if err != nil {
+		t.Fatalf("AllRecipes: %v", err)
+	}
+	if len(recipes) != 1 {
+		t.Fatalf("cookbook has %d recipes, want 1", len(recipes))
+	}
+	recipe, err := cookbook.GetFullRecipe(firstResult.recipeID)
+	if err != nil {
+		t.Fatalf("GetFullRecipe: %v", err)
+	}
+	if recipe.RecipeDescription != "second" {
+		t.Errorf("description = %q, want the second file's", recipe.RecipeDescription)
+	}
+	// Fields the second file leaves empty are emptied, not kept from the first
+	meta := recipe.Metadata
+	if meta.Author != "" || meta.URL != "" || meta.PrepTime != 0 {
+		t.Errorf("author %q, URL %q, prep time %v; want them empty as in the second file", meta.Author, meta.URL, meta.PrepTime)
+	}
+	if meta.Rating != 5 || !meta.Favourite {
+		t.Errorf("rating %d, favourite %v; want 5 and true from the second file", meta.Rating, meta.Favourite)
+	}
+}
+
+func TestImportSkipAndRenameDuplicates(t *testing.T) {
+	cookbook := newTestCookbook(t)
+	dir := t.TempDir()
+	file := writeRecipeFile(t, dir, "bread.json", `{"name": "Bread", "ingredients": [{"amount": "500", "unit": "g", "name": "flour"}], "instructions": ["Bake."]}`)
+
+	imp := newTestImporter(cookbook, onDuplicateSkip, false)
+	first := imp.importFile(file, "")
+	if first.status != importImported {
+		t.Fatalf("first import: status %v, err %v", first.status, first.err)
+	}
+	if result := imp.importFile(file, ""); result.status != importSkipped || result.duplicateOf != first.recipeID {
+		t.Errorf("second import with skip: status %v, duplicate of %d; want skipped, #%d", result.status, result.duplicateOf, first.recipeID)
+	}
+
+	imp.onDuplicate = onDuplicateRename
+	result := imp.importFile(file, "")
+	if result.status != importRenamed || result.recipeName != "Bread (2)" {
+		t.Errorf("import with rename: status %v, name %q; want renamed to Bread (2)", result.status, result.recipeName)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	listCmd.Flags().StringP("collection", "c", "", "Only recipes in this collection or saved search")
+	listCmd.Flags().StringP("sort", "s", "", "Sort by name or last-cooked")
+	addFormatFlag(listCmd)
+}
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List recipes",
+	Long: `List the recipes of the cookbook by name, or the recipes of a collection in its order.
+A saved search works as a collection of the recipes its query matches, best match first;
+see yummy saved-search.`,
+	Example: `
+		# Every recipe
+		yummy list
+
+		# The recipes of a collection or saved search
+		yummy list --collection quick-weeknight
+
+		# What was cooked lately
+		yummy list --sort last-cooked
+
+		# Every recipe as JSON, for scripts
+		yummy list --format json
+  	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		collection, _ := cmd.Flags().GetString("collection")
+		sortFlag, _ := cmd.Flags().GetString("sort")
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		var sortBy utils.RecipeSort
+		if sortFlag != "" {
+			var err error
+			if sortBy, err = utils.ParseRecipeSort(sortFlag); err != nil {
+				return err
+			}
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		var recipes []utils.RecipeRaw
+		if collection != "" {
+			if recipes, err = cookbook.RecipesInCollection(collection); err != nil {
+				return err
+			}
+		} else if recipes, err = cookbook.AllRecipes(); err != nil {
+			return fmt.Errorf("failed to fetch recipes: %v", err)
+		}
+
+		if len(recipes) == 0 && format != formatJSON {
+			fmt.Println("No recipes found")
+			return nil
+		}
+		if sortBy != "" {
+			utils.SortRecipes(recipes, sortBy)
+		}
+		return printRecipes(format, recipes, nil)
+	},
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	pantryAddCmd.Flags().StringP("expires", "e", "", "Expiry date (YYYY-MM-DD)")
+	pantryCookCmd.Flags().IntP("limit", "l", 10, "Number of recipes to show")
+	pantryCookCmd.Flags().Float64P("min-coverage", "m", 0.5, "Only show recipes with at least this share of ingredients on hand (0-1)")
+
+	pantryCmd.AddCommand(pantryListCmd)
+	pantryCmd.AddCommand(pantryAddCmd)
+	pantryCmd.AddCommand(pantryRemoveCmd)
+	pantryCmd.AddCommand(pantryCookCmd)
+}
+
+var pantryCmd = &cobra.Command{
+	Use:   "pantry",
+	Short: "Manage the ingredients you have on hand",
+	Long: `Keep track of the ingredients in your pantry, with quantities and optional expiry dates,
+and find the recipes you can cook with them. Without a subcommand the pantry is listed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return pantryListCmd.RunE(cmd, args)
+	},
+}
+
+var pantryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the pantry",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		items, err := cookbook.PantryItems()
+		if err != nil {
+			return fmt.Errorf("failed to fetch pantry: %v", err)
+		}
+		if len(items) == 0 {
+			fmt.Println("The pantry is empty. Add items with yummy pantry add")
+			return nil
+		}
+
+		now := time.Now()
+		for _, item := range items {
+			amount := strings.TrimSpace(item.Amount + " " + item.Unit)
+			expiry := ""
+			if item.ExpiresAt != nil {
+				expiry = "expires " + item.ExpiresAt.Format(utils.PantryDateLayout)
+				if item.Expired(now) {
+					expiry = "⚠️  expired " + item.ExpiresAt.Format(utils.PantryDateLayout)
+				}
+			}
+			line := fmt.Sprintf("%4d  %-24s %-14s %s", item.ID, item.Name, amount, expiry)
+			fmt.Println(strings.TrimRight(line, " "))
+		}
+		return nil
+	},
+}
+
+var pantryAddCmd = &cobra.Command{
+	Use:   "add <item>...",
+	Short: "Add items to the pantry",
+	Long: `Add one item per argument, written like an ingredient line ("2 kg potatoes").
+An item already in the pantry is replaced, so adding it again updates its quantity.`,
+	Example: `
+		# Add a few items
+		yummy pantry add "2 kg potatoes" eggs "500 ml milk"
+
+		# Add an item with an expiry date
+		yummy pantry add "200 g feta" --expires 2026-10-30
+  	`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		expires, _ := cmd.Flags().GetString("expires")
+
+		var items []utils.PantryItem
+		for _, arg := range args {
+			item, err := utils.ParsePantryEntry(arg)
+			if err != nil {
+				return err
+			}
+			if expires != "" {
+				date, err := time.ParseInLocation(utils.PantryDateLayout, expires, time.Local)
+				if err != nil {
+					return fmt.Errorf("invalid expiry date %q, use YYYY-MM-DD", expires)
+				}
+				item.ExpiresAt = &date
+			}
+			items = append(items, item)
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			id, err := cookbook.AddPantryItem(item)
+			if err != nil {
+				slog.Error("Failed to add pantry item", "item", item.Name, "error", err)
+				return fmt.Errorf("failed to add %s: %v", item.Name, err)
+			}
+			fmt.Printf("✅ Added %s (ID: %d)\n", item.Name, id)
+		}
+		return nil
+	},
+}
+
+var pantryRemoveCmd = &cobra.Command{
+	Use:     "remove <item_id>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove items from the pantry",
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var ids []uint
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid pantry item ID %q", arg)
+			}
+			ids = append(ids, uint(id))
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := cookbook.DeletePantryItem(id); err != nil {
+				return fmt.Errorf("failed to remove pantry item %d: %v", id, err)
+			}
+			fmt.Printf("✅ Removed pantry item %d\n", id)
+		}
+		return nil
+	},
+}
+
+var pantryCookCmd = &cobra.Command{
+	Use:   "cook",
+	Short: "Show the recipes you can cook with what is in the pantry",
+	Long:  `Rank recipes by how much of their ingredient list the pantry covers. Expired items do not count.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		minCoverage, _ := cmd.Flags().GetFloat64("min-coverage")
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		matches, err := cookbook.RankRecipesByPantry()
+		if err != nil {
+			return fmt.Errorf("failed to match recipes: %v", err)
+		}
+
+		shown := 0
+		for _, match := range matches {
+			if match.Coverage < minCoverage || (limit > 0 && shown >= limit) {
+				break
+			}
+			fmt.Printf("%3.0f%%  %s (ID: %d)\n", match.Coverage*100, match.RecipeName, match.RecipeID)
+			if len(match.Missing) > 0 {
+				fmt.Printf("      missing: %s\n", strings.Join(match.Missing, ", "))
+			}
+			shown++
+		}
+		if shown == 0 {
+			fmt.Println("No recipe is covered well enough by the pantry. Try a lower --min-coverage")
+		}
+		return nil
+	},
+}
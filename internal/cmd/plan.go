@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{planListCmd, planClearCmd, planExportCmd} {
+		cmd.Flags().StringP("week", "w", "", "A day in the first week to use (default: this week)")
+		cmd.Flags().IntP("weeks", "n", 1, "Number of weeks")
+	}
+	planClearCmd.Flags().StringP("slot", "s", "", "Only clear this meal slot (breakfast, lunch or dinner)")
+	planExportCmd.Flags().StringP("output", "o", "meal-plan.ics", "File to write the calendar to (- for stdout)")
+
+	planCmd.AddCommand(planAddCmd)
+	planCmd.AddCommand(planListCmd)
+	planCmd.AddCommand(planClearCmd)
+	planCmd.AddCommand(planExportCmd)
+}
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Plan meals for the week",
+	Long: `Assign recipes to the breakfast, lunch and dinner slots of each day.
+The plan is stored in the cookbook, shown as a week grid in the TUI and can be exported to iCalendar.`,
+}
+
+var planAddCmd = &cobra.Command{
+	Use:   "add <date> <slot> <recipe_id|recipe_name>",
+	Short: "Plan a recipe for a meal",
+	Long:  `Plan a recipe for a meal. The date is YYYY-MM-DD, today, tomorrow or a weekday name; a recipe already planned for the slot is replaced.`,
+	Example: `
+		# Pancakes for breakfast tomorrow
+		yummy plan add tomorrow breakfast Pancakes
+
+		# Recipe 12 for dinner on Friday
+		yummy plan add fri dinner 12
+  	`,
+	Args: cobra.MinimumNArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		day, err := utils.ParsePlanDate(args[0], time.Now())
+		if err != nil {
+			return err
+		}
+		slot, err := utils.ParseMealSlot(args[1])
+		if err != nil {
+			return err
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		recipeID, err := resolveRecipe(cookbook, strings.Join(args[2:], " "))
+		if err != nil {
+			return err
+		}
+		if _, err := cookbook.PlanMeal(day, slot, recipeID); err != nil {
+			slog.Error("Failed to plan meal", "error", err)
+			return fmt.Errorf("failed to plan meal: %v", err)
+		}
+
+		fmt.Printf("✅ Planned for %s on %s\n", strings.ToLower(slot.Label()), day.Format("Mon 2006-01-02"))
+		return nil
+	},
+}
+
+var planListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the meal plan",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to, err := planRange(cmd)
+		if err != nil {
+			return err
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		entries, err := cookbook.MealPlan(from, to)
+		if err != nil {
+			return fmt.Errorf("failed to fetch meal plan: %v", err)
+		}
+		if len(entries) == 0 {
+			fmt.Printf("Nothing planned from %s to %s. Add meals with yummy plan add\n",
+				from.Format(utils.PlanDateLayout), to.AddDate(0, 0, -1).Format(utils.PlanDateLayout))
+			return nil
+		}
+
+		var lastDay time.Time
+		for _, entry := range entries {
+			if !entry.Date.Equal(lastDay) {
+				if !lastDay.IsZero() {
+					fmt.Println()
+				}
+				fmt.Println(entry.Date.Format("Monday 2006-01-02"))
+				lastDay = entry.Date
+			}
+			fmt.Printf("  %-10s %s (ID: %d)\n", entry.Slot.Label(), entry.RecipeName, entry.RecipeID)
+		}
+		return nil
+	},
+}
+
+var planClearCmd = &cobra.Command{
+	Use:   "clear [date]",
+	Short: "Remove meals from the plan",
+	Long:  `Remove the meals planned for a day, or for the selected weeks when no date is given.`,
+	Example: `
+		# Clear this week
+		yummy plan clear
+
+		# Clear Friday's dinner
+		yummy plan clear fri --slot dinner
+  	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to, err := planRange(cmd)
+		if err != nil {
+			return err
+		}
+		if len(args) == 1 {
+			if from, err = utils.ParsePlanDate(args[0], time.Now()); err != nil {
+				return err
+			}
+			to = from.AddDate(0, 0, 1)
+		}
+
+		var slot utils.MealSlot
+		if s, _ := cmd.Flags().GetString("slot"); s != "" {
+			if slot, err = utils.ParseMealSlot(s); err != nil {
+				return err
+			}
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		removed, err := cookbook.ClearMealPlan(from, to, slot)
+		if err != nil {
+			return fmt.Errorf("failed to clear meal plan: %v", err)
+		}
+		fmt.Printf("✅ Removed %d planned meals\n", removed)
+		return nil
+	},
+}
+
+var planExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the meal plan to iCalendar (.ics)",
+	Example: `
+		# Export this week and the next three
+		yummy plan export --weeks 4 --output meals.ics
+  	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		from, to, err := planRange(cmd)
+		if err != nil {
+			return err
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		entries, err := cookbook.MealPlan(from, to)
+		if err != nil {
+			return fmt.Errorf("failed to fetch meal plan: %v", err)
+		}
+
+		ics := utils.FormatICS(entries, time.Now())
+		if output == "-" {
+			fmt.Print(ics)
+			return nil
+		}
+		if err := os.WriteFile(output, []byte(ics), 0644); err != nil {
+			slog.Error("Failed to write calendar", "error", err)
+			return fmt.Errorf("failed to write %s: %v", output, err)
+		}
+		fmt.Printf("✅ Exported %d planned meals to %s\n", len(entries), output)
+		return nil
+	},
+}
+
+// planRange returns the days selected by the --week and --weeks flags
+func planRange(cmd *cobra.Command) (time.Time, time.Time, error) {
+	week, _ := cmd.Flags().GetString("week")
+	weeks, _ := cmd.Flags().GetInt("weeks")
+	if weeks < 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("--weeks must be at least 1")
+	}
+
+	day := time.Now()
+	if week != "" {
+		var err error
+		if day, err = utils.ParsePlanDate(week, day); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	from := utils.WeekStart(day)
+	return from, from.AddDate(0, 0, 7*weeks), nil
+}
+
+// resolveRecipe returns the ID of the recipe given by ID or (case-insensitive)
+// name. A number is an ID when a recipe has it and a name otherwise, so a
+// recipe named "1984" can still be found. A name shared by several recipes
+// is an error listing their IDs.
+func resolveRecipe(cookbook *db.CookBook, arg string) (uint, error) {
+	id, err := strconv.ParseUint(arg, 10, 32)
+	isID := err == nil
+	if isID {
+		exists, err := cookbook.RecipeExists(uint(id))
+		if err != nil {
+			return 0, fmt.Errorf("failed to look up recipe %d: %v", id, err)
+		}
+		if exists {
+			return uint(id), nil
+		}
+	}
+
+	ids, err := cookbook.RecipeIDsByName(arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up recipe %q: %v", arg, err)
+	}
+	switch {
+	case len(ids) == 1:
+		return ids[0], nil
+	case len(ids) > 1:
+		idTexts := make([]string, len(ids))
+		for i, id := range ids {
+			idTexts[i] = strconv.FormatUint(uint64(id), 10)
+		}
+		return 0, fmt.Errorf("%d recipes are named %q (IDs %s), give the ID instead", len(ids), arg, strings.Join(idTexts, ", "))
+	case isID:
+		return 0, fmt.Errorf("no recipe with ID or name %q", arg)
+	}
+	return 0, fmt.Errorf("no recipe named %q", arg)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+)
+
+func TestResolveRecipe(t *testing.T) {
+	cookbook := newTestCookbook(t)
+	for _, name := range []string{"Pancakes", "1984", "Soup", "soup", "1"} {
+		if _, err := cookbook.SaveScrapedRecipe(&utils.RecipeRaw{RecipeName: name}); err != nil {
+			t.Fatalf("SaveScrapedRecipe(%q): %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		arg     string
+		want    uint
+		wantErr string
+	}{
+		{arg: "1", want: 1}, // an ID wins over a recipe named "1"
+		{arg: "2", want: 2},
+		{arg: "1984", want: 2}, // no recipe has ID 1984, so it is a name
+		{arg: "pancakes", want: 1},
+		{arg: "Soup", wantErr: "2 recipes are named \"Soup\" (IDs 3, 4)"},
+		{arg: "99", wantErr: "no recipe with ID or name \"99\""},
+		{arg: "Waffles", wantErr: "no recipe named \"Waffles\""},
+	}
+	for _, tt := range tests {
+		got, err := resolveRecipe(cookbook, tt.arg)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveRecipe(%q) = %d, %v; want error %q", tt.arg, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("resolveRecipe(%q) = %d, %v; want %d", tt.arg, got, err, tt.want)
+		}
+	}
+}
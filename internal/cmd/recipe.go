@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{showCmd, randomCmd, favouriteCmd, rateCmd} {
+		addFormatFlag(cmd)
+	}
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show <id|name>",
+	Short: "Show a recipe",
+	Long: `Show a recipe, given by its ID or name, with its ingredients, instructions and notes.
+As md it prints the same markdown as yummy export.`,
+	Example: `
+		# Show a recipe by ID
+		yummy show 12
+
+		# Show a recipe by name, as markdown
+		yummy show "Chicken Curry" --format md
+  	`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		recipeID, err := resolveRecipe(cookbook, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+		recipe, err := cookbook.GetFullRecipe(recipeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("no recipe with ID %d", recipeID)
+			}
+			return fmt.Errorf("failed to fetch recipe %d: %v", recipeID, err)
+		}
+		return printRecipe(format, recipe)
+	},
+}
+
+var randomCmd = &cobra.Command{
+	Use:   "random",
+	Short: "Show a random recipe",
+	Long:  `Pick a recipe at random for when you can't decide what to cook, and show it like yummy show.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		picked, err := cookbook.RandomRecipe()
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("no recipes yet. Add some with yummy import or in the TUI")
+			}
+			return fmt.Errorf("failed to pick a recipe: %v", err)
+		}
+		recipe, err := cookbook.GetFullRecipe(picked.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch recipe %d: %v", picked.ID, err)
+		}
+		return printRecipe(format, recipe)
+	},
+}
+
+var favouriteCmd = &cobra.Command{
+	Use:     "favourite <id|name>",
+	Aliases: []string{"fav", "favorite"},
+	Short:   "Mark or unmark a recipe as a favourite",
+	Long:    `Toggle whether a recipe, given by its ID or name, is one of your favourites.`,
+	Example: `
+		# Mark recipe 12 as a favourite, or unmark it if it already is one
+		yummy favourite 12
+  	`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		recipeID, err := resolveRecipe(cookbook, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+		if _, err := cookbook.RecipeSummary(recipeID); err != nil {
+			return err
+		}
+		if _, err := cookbook.SetFavourite(recipeID); err != nil {
+			return fmt.Errorf("failed to set favourite: %v", err)
+		}
+		return printUpdatedRecipe(cookbook, format, recipeID)
+	},
+}
+
+var rateCmd = &cobra.Command{
+	Use:   "rate <id|name> <rating>",
+	Short: "Rate a recipe from 1 to 5 stars",
+	Long:  `Rate a recipe, given by its ID or name, from 1 to 5 stars. A rating of 0 clears it.`,
+	Example: `
+		# Five stars for recipe 12
+		yummy rate 12 5
+
+		# Clear the rating of a recipe by name
+		yummy rate "Chicken Curry" 0
+  	`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		ratingArg := args[len(args)-1]
+		rating, err := strconv.Atoi(ratingArg)
+		if err != nil || rating < 0 || rating > 5 {
+			return fmt.Errorf("invalid rating: %s. Use a number from 1 to 5, or 0 to clear it", ratingArg)
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		recipeID, err := resolveRecipe(cookbook, strings.Join(args[:len(args)-1], " "))
+		if err != nil {
+			return err
+		}
+		if _, err := cookbook.RecipeSummary(recipeID); err != nil {
+			return err
+		}
+		if err := cookbook.SetRating(recipeID, int8(rating)); err != nil {
+			return fmt.Errorf("failed to rate recipe: %v", err)
+		}
+		return printUpdatedRecipe(cookbook, format, recipeID)
+	},
+}
+
+// printUpdatedRecipe confirms a change to a recipe, or prints the recipe as
+// it is now for json and md
+func printUpdatedRecipe(cookbook *db.CookBook, format outputFormat, recipeID uint) error {
+	recipe, err := cookbook.RecipeSummary(recipeID)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case formatJSON:
+		return printJSON(toSummaryJSON(recipe, ""))
+	case formatMarkdown:
+		return printRecipes(format, []utils.RecipeRaw{recipe}, nil)
+	}
+
+	status := "not a favourite"
+	if recipe.Metadata.Favourite {
+		status = "⭐ favourite"
+	}
+	rating := utils.FormatRating(recipe.Metadata.Rating)
+	if rating == "" {
+		rating = "unrated"
+	}
+	fmt.Printf("✅ %s: %s, %s\n", recipe.RecipeName, rating, status)
+	return nil
+}
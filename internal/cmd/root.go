@@ -12,7 +12,6 @@ import (
 	log "github.com/GarroshIcecream/yummy/internal/log"
 	themes "github.com/GarroshIcecream/yummy/internal/themes"
 	tui "github.com/GarroshIcecream/yummy/internal/tui"
-	"github.com/GarroshIcecream/yummy/internal/tui/chat"
 	"github.com/GarroshIcecream/yummy/internal/version"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/fang"
@@ -24,6 +23,18 @@ func init() {
 
 	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(dbCmd)
+	rootCmd.AddCommand(shoppingListCmd)
+	rootCmd.AddCommand(pantryCmd)
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(randomCmd)
+	rootCmd.AddCommand(favouriteCmd)
+	rootCmd.AddCommand(rateCmd)
+	rootCmd.AddCommand(savedSearchCmd)
 }
 
 var rootCmd = &cobra.Command{
@@ -37,7 +48,7 @@ Yummy brings a beautiful terminal-first experience to every home cook, developer
 🚀 Core Features:
 • Recipe Management: Add, edit, and organize recipes with ingredient lists, measures, instructions, and metadata
 • Powerful Search: Quick search and categorization to find the recipe you need
-• Export Options: Export collections to JSON or CSV for sharing or migration
+• Export Options: Export and import Markdown, JSON and schema.org Recipe JSON-LD for sharing or migration
 • Clean TUI: Navigable interface with list/detail views, editable forms, and status indicators
 • Developer Friendly: Small codebase with clear package boundaries — ideal for contributors and experimentation
 
@@ -99,6 +110,39 @@ func resolveUserDir() (string, error) {
 	return datadir, nil
 }
 
+// loadConfig resolves the data directory and loads the configuration from it.
+func loadConfig() (string, *config.Config, error) {
+	datadir, err := resolveUserDir()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve user directory: %v", err)
+	}
+
+	cfg, err := config.LoadConfig(datadir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load configuration: %v", err)
+	}
+	config.SetGlobalConfig(cfg)
+
+	return datadir, cfg, nil
+}
+
+// openCookbook loads the configuration and opens the cookbook database, as
+// needed by every non-interactive command.
+func openCookbook() (*config.Config, *db.CookBook, error) {
+	datadir, cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cookbook, err := db.NewCookBook(datadir, &cfg.Database)
+	if err != nil {
+		slog.Error("Failed to initialize cookbook", "error", err)
+		return nil, nil, fmt.Errorf("failed to initialize cookbook: %v", err)
+	}
+
+	return cfg, cookbook, nil
+}
+
 func setupApp(cmd *cobra.Command) (*tui.Manager, error) {
 	ctx := cmd.Context()
 
@@ -145,13 +189,6 @@ func setupApp(cmd *cobra.Command) (*tui.Manager, error) {
 		return nil, fmt.Errorf("failed to initialize session log: %v", err)
 	}
 
-	chatConfig := config.GetChatConfig()
-	_, err = chat.GetOllamaServiceStatus(chatConfig.DefaultModel)
-	if err != nil {
-		slog.Error("Failed to get ollama service status", "error", err)
-		return nil, fmt.Errorf("failed to get ollama service status: %v", err)
-	}
-
 	tuiInstance, err := tui.New(cookbook, sessionLog, themeManager, ctx)
 	if err != nil {
 		slog.Error("Failed to create tui instance", "error", err)
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	savedSearchCmd.AddCommand(savedSearchListCmd)
+	savedSearchCmd.AddCommand(savedSearchAddCmd)
+	savedSearchCmd.AddCommand(savedSearchRemoveCmd)
+}
+
+var savedSearchCmd = &cobra.Command{
+	Use:   "saved-search",
+	Short: "Manage saved searches",
+	Long: `Save list filter queries under a name, like "Quick weeknight" = @time<30m @category dinner.
+A saved search works as a smart collection: list its recipes with yummy list --collection,
+or pick it from the main menu or command palette to filter the cookbook in the TUI.
+Without a subcommand the saved searches are listed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return savedSearchListCmd.RunE(cmd, args)
+	},
+}
+
+var savedSearchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the saved searches",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		searches, err := cookbook.SavedSearches()
+		if err != nil {
+			return fmt.Errorf("failed to fetch saved searches: %v", err)
+		}
+		if len(searches) == 0 {
+			fmt.Println("No saved searches yet. Save one with yummy saved-search add")
+			return nil
+		}
+
+		for _, search := range searches {
+			fmt.Printf("%-24s %-24s %s\n", search.Slug, search.Name, search.Query)
+		}
+		return nil
+	},
+}
+
+var savedSearchAddCmd = &cobra.Command{
+	Use:   "add <name> <query>...",
+	Short: "Save a filter query under a name",
+	Long: `Save a filter query under a name. The query uses the list filter's language: words,
+"quoted phrases", OR, -negations, @author, @category, @ingredients and the other field
+commands, @fav, @time<30m and @rating>=4. Saving under an existing name replaces its query.`,
+	Example: `
+		# Quick dinners
+		yummy saved-search add "Quick weeknight" "@time<30m @category dinner"
+
+		# Favourite desserts without nuts
+		yummy saved-search add "Safe treats" @fav @category dessert -@ingredients nuts
+  	`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		name, query := args[0], strings.Join(args[1:], " ")
+		if _, err := cookbook.SaveSearch(name, query); err != nil {
+			return fmt.Errorf("failed to save search: %v", err)
+		}
+		fmt.Printf("✅ Saved search %s: %s\n", name, query)
+		return nil
+	},
+}
+
+var savedSearchRemoveCmd = &cobra.Command{
+	Use:     "remove <name>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove saved searches",
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		for _, name := range args {
+			search, err := cookbook.SavedSearchBySlug(name)
+			if err != nil {
+				return err
+			}
+			if err := cookbook.DeleteSavedSearch(search.ID); err != nil {
+				return fmt.Errorf("failed to remove saved search %s: %v", search.Name, err)
+			}
+			fmt.Printf("✅ Removed saved search %s\n", search.Name)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	searchCmd.Flags().StringP("category", "c", "", "Only recipes in this category")
+	searchCmd.Flags().StringP("author", "a", "", "Only recipes by this author")
+	searchCmd.Flags().BoolP("favourite", "f", false, "Only favourite recipes")
+	searchCmd.Flags().StringSliceP("in", "i", nil, fmt.Sprintf("Only match in these fields (%s)", strings.Join(db.SearchFields, ", ")))
+	searchCmd.Flags().IntP("limit", "l", 20, "Number of recipes to show (0 for all)")
+	addFormatFlag(searchCmd)
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <words>...",
+	Short: "Search recipes by their full text",
+	Long: `Search the names, descriptions, authors, ingredients, instructions and categories of every
+recipe, best match first. Every word must match; words match by prefix and stem, so "fry" also
+finds "frying". Each result shows where it matched.`,
+	Example: `
+		# Find recipes mentioning chickpeas and spinach
+		yummy search chickpea spinach
+
+		# Only match ingredients, among favourite recipes
+		yummy search garlic --in ingredients --favourite
+
+		# The matches as JSON, for scripts
+		yummy search curry --format json
+  	`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		category, _ := cmd.Flags().GetString("category")
+		author, _ := cmd.Flags().GetString("author")
+		favourite, _ := cmd.Flags().GetBool("favourite")
+		fields, _ := cmd.Flags().GetStringSlice("in")
+		limit, _ := cmd.Flags().GetInt("limit")
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		filter := db.SearchFilter{
+			RecipeFilter: db.RecipeFilter{Category: category, Author: author, FavouritesOnly: favourite},
+			Fields:       fields,
+			Limit:        limit,
+		}
+		query := strings.Join(args, " ")
+		results, err := cookbook.Search(query, filter)
+		if err != nil {
+			return fmt.Errorf("failed to search recipes: %v", err)
+		}
+		if len(results) == 0 && format != formatJSON {
+			fmt.Printf("No recipes found matching %q\n", query)
+			return nil
+		}
+
+		recipes := make([]utils.RecipeRaw, len(results))
+		snippets := make([]string, len(results))
+		for i, result := range results {
+			recipes[i], snippets[i] = result.Recipe, result.Snippet
+		}
+		return printRecipes(format, recipes, snippets)
+	},
+}
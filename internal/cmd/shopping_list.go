@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	shoppingListCreateCmd.Flags().StringP("name", "n", "", "Name of the shopping list")
+	shoppingListExportCmd.Flags().StringP("format", "f", "md", "Export format: md or text")
+	shoppingListExportCmd.Flags().StringP("output", "o", "", "File to write the list to (default: stdout)")
+
+	shoppingListCmd.AddCommand(shoppingListCreateCmd)
+	shoppingListCmd.AddCommand(shoppingListListCmd)
+	shoppingListCmd.AddCommand(shoppingListExportCmd)
+	shoppingListCmd.AddCommand(shoppingListDeleteCmd)
+}
+
+var shoppingListCmd = &cobra.Command{
+	Use:     "shopping-list",
+	Aliases: []string{"shop"},
+	Short:   "Build shopping lists from recipes",
+	Long: `Merge the ingredients of one or more recipes into a shopping list grouped by store aisle.
+Lists are saved in the cookbook, can be ticked off in the TUI and exported as markdown or plain text.`,
+}
+
+var shoppingListCreateCmd = &cobra.Command{
+	Use:   "create <recipe_id[xFACTOR]>...",
+	Short: "Create a shopping list from recipes",
+	Example: `
+		# Shopping list for two recipes
+		yummy shopping-list create 12 15
+
+		# Double recipe 12 and halve recipe 15
+		yummy shopping-list create 12x2 15x0.5 --name "Weekend"
+  	`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+
+		var recipes []utils.ShoppingListRecipe
+		for _, arg := range args {
+			recipe, err := parseShoppingListRecipe(arg)
+			if err != nil {
+				return err
+			}
+			recipes = append(recipes, recipe)
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		listID, err := cookbook.CreateShoppingList(name, recipes)
+		if err != nil {
+			slog.Error("Failed to create shopping list", "error", err)
+			return fmt.Errorf("failed to create shopping list: %v", err)
+		}
+
+		list, err := cookbook.GetShoppingList(listID)
+		if err != nil {
+			return fmt.Errorf("failed to load shopping list: %v", err)
+		}
+		fmt.Printf("✅ Shopping list %q created (ID: %d) with %d items\n", list.Name, list.ID, len(list.Items))
+		return nil
+	},
+}
+
+var shoppingListListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved shopping lists",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		lists, err := cookbook.ShoppingLists()
+		if err != nil {
+			return fmt.Errorf("failed to fetch shopping lists: %v", err)
+		}
+		if len(lists) == 0 {
+			fmt.Println("No shopping lists yet. Create one with yummy shopping-list create")
+			return nil
+		}
+		for _, list := range lists {
+			fmt.Printf("%4d  %-30s %s\n", list.ID, list.Name, list.CreatedAt.Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+}
+
+var shoppingListExportCmd = &cobra.Command{
+	Use:   "export [list_id]",
+	Short: "Export a shopping list as markdown or plain text",
+	Long:  `Export a shopping list (the most recent one by default) as a markdown checklist or plain text.`,
+	Example: `
+		# Print the latest shopping list as markdown
+		yummy shopping-list export
+
+		# Write list 3 to a text file
+		yummy shopping-list export 3 --format text --output groceries.txt
+  	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		format = strings.ToLower(strings.TrimPrefix(format, "."))
+		switch format {
+		case "md", "markdown", "text", "txt":
+		default:
+			return fmt.Errorf("unsupported export format: %s. Supported formats: md, text", format)
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+
+		var list *utils.ShoppingList
+		if len(args) == 1 {
+			listID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid shopping list ID: %s", args[0])
+			}
+			list, err = cookbook.GetShoppingList(uint(listID))
+			if err != nil {
+				return fmt.Errorf("failed to fetch shopping list %d: %v", listID, err)
+			}
+		} else {
+			list, err = cookbook.LatestShoppingList()
+			if err != nil {
+				return fmt.Errorf("failed to fetch shopping list: %v", err)
+			}
+			if list == nil {
+				return fmt.Errorf("no shopping lists yet. Create one with yummy shopping-list create")
+			}
+		}
+
+		content := list.FormatMarkdown()
+		if format == "text" || format == "txt" {
+			content = list.FormatText()
+		}
+
+		if output == "" {
+			fmt.Print(content)
+			return nil
+		}
+		if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+			slog.Error("Failed to write file", "filename", output, "error", err)
+			return fmt.Errorf("failed to write file %s: %v", output, err)
+		}
+		fmt.Printf("✅ Shopping list exported to %s\n", output)
+		return nil
+	},
+}
+
+var shoppingListDeleteCmd = &cobra.Command{
+	Use:   "delete <list_id>",
+	Short: "Delete a shopping list",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listID, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid shopping list ID: %s", args[0])
+		}
+
+		_, cookbook, err := openCookbook()
+		if err != nil {
+			return err
+		}
+		if err := cookbook.DeleteShoppingList(uint(listID)); err != nil {
+			return fmt.Errorf("failed to delete shopping list %d: %v", listID, err)
+		}
+		fmt.Printf("✅ Shopping list %d deleted\n", listID)
+		return nil
+	},
+}
+
+// parseShoppingListRecipe parses "12" or "12x2" (recipe 12, doubled).
+func parseShoppingListRecipe(arg string) (utils.ShoppingListRecipe, error) {
+	idPart, scalePart, hasScale := strings.Cut(strings.ToLower(arg), "x")
+	recipeID, err := strconv.ParseUint(idPart, 10, 64)
+	if err != nil {
+		return utils.ShoppingListRecipe{}, fmt.Errorf("invalid recipe ID: %s", arg)
+	}
+
+	recipe := utils.ShoppingListRecipe{RecipeID: uint(recipeID), Scale: 1}
+	if hasScale {
+		scale, err := strconv.ParseFloat(scalePart, 64)
+		if err != nil || scale <= 0 {
+			return utils.ShoppingListRecipe{}, fmt.Errorf("invalid scale factor in %s", arg)
+		}
+		recipe.Scale = scale
+	}
+	return recipe, nil
+}
@@ -57,6 +57,9 @@ type Config struct {
 	// Add Recipe From URL Dialog Settings
 	AddRecipeFromURLDialog AddRecipeFromURLDialogConfig `json:"add_recipe_from_url_dialog"`
 
+	// Recipe Scraper Settings
+	Scraper ScraperConfig `json:"scraper"`
+
 	// Recipe Selector Dialog Settings
 	RecipeSelectorDialog RecipeSelectorDialogConfig `json:"recipe_selector_dialog"`
 
@@ -73,6 +76,7 @@ func NewDefaultConfig() *Config {
 		ModelSelectorDialog:    NewDefaultModelSelectorDialogConfig(),
 		ThemeSelectorDialog:    NewDefaultThemeSelectorDialogConfig(),
 		AddRecipeFromURLDialog: NewDefaultAddRecipeFromURLDialogConfig(),
+		Scraper:                NewDefaultScraperConfig(),
 		RecipeSelectorDialog:   NewDefaultRecipeSelectorDialogConfig(),
 		CommandPaletteDialog:   NewDefaultCommandPaletteDialogConfig(),
 		Chat:                   NewDefaultChatConfig(),
@@ -197,6 +201,19 @@ func NewDefaultAddRecipeFromURLDialogConfig() AddRecipeFromURLDialogConfig {
 	}
 }
 
+// ScraperConfig contains recipe scraping settings
+type ScraperConfig struct {
+	Backends     []string            `json:"backends"`      // backends tried in order: "jsonld" (built-in schema.org parser), "recipe-scrapers" (Python)
+	HostBackends map[string][]string `json:"host_backends"` // per-host backend order, keyed by "www.example.com", "example.com" or ".example.com"
+}
+
+func NewDefaultScraperConfig() ScraperConfig {
+	return ScraperConfig{
+		Backends:     []string{"jsonld", "recipe-scrapers"},
+		HostBackends: map[string][]string{},
+	}
+}
+
 // RecipeSelectorDialogConfig contains recipe selector dialog settings
 type RecipeSelectorDialogConfig struct {
 	Height int `json:"height"`
@@ -226,6 +243,10 @@ func NewDefaultCommandPaletteDialogConfig() CommandPaletteDialogConfig {
 // ChatConfig contains chat-related settings
 type ChatConfig struct {
 	DefaultModel             string  `json:"default_model"`
+	Provider                 string  `json:"provider"`    // LLM provider: ollama, or openai for any OpenAI-compatible server
+	BaseURL                  string  `json:"base_url"`    // URL of the provider's API, e.g. http://localhost:8080/v1 for llama.cpp server
+	APIKeyEnv                string  `json:"api_key_env"` // environment variable holding the API key, default OPENAI_API_KEY
+	OllamaHost               string  `json:"ollama_host"` // URL of the Ollama server, default $OLLAMA_HOST or http://localhost:11434
 	Temperature              float64 `json:"temperature"`
 	MaxTokens                int     `json:"max_tokens"`
 	MaxIterations            int     `json:"max_iterations"`
@@ -239,6 +260,7 @@ type ChatConfig struct {
 	AssistantAvatar          string  `json:"assistant_avatar"`
 	UserAvatar               string  `json:"user_avatar"`
 	AssistantThinkingMessage string  `json:"assistant_thinking_message"`
+	LLMCheckInterval         int     `json:"llm_check_interval"` // seconds between checks for Ollama, 0 to check only at startup
 
 	// UI Layout constants
 	UILayout UILayoutConfig `json:"ui_layout"`
@@ -247,6 +269,7 @@ type ChatConfig struct {
 func NewDefaultChatConfig() ChatConfig {
 	return ChatConfig{
 		DefaultModel:  "gemma3:4b",
+		Provider:      "ollama",
 		Temperature:   0.9,
 		MaxTokens:     1000,
 		MaxIterations: 15,
@@ -259,8 +282,10 @@ func NewDefaultChatConfig() ChatConfig {
 		- Answering questions about cooking techniques and food preparation
 
 		Available tools:
-		- searchRecipeByName: Search for recipes by name (case-insensitive partial match). Use this to find recipes when the user mentions a recipe name or asks about a specific dish.
+		- searchRecipeByName: Search for recipes by name (case-insensitive, words match by prefix). Use this to find recipes when the user mentions a recipe name or asks about a specific dish.
+		- searchRecipes: Full-text search over recipe names, descriptions, authors, ingredients, instructions and categories, best match first. Use this when the user describes what they want rather than naming it (e.g. "something with chickpeas and spinach").
 		- getRecipeById: Get a specific recipe by its unique ID. Use this after finding a recipe with searchRecipeByName to get the full recipe details.
+		- whatCanICook: List the ingredients in the user's pantry and the recipes they can cook with them, best match first. Use this when the user asks what they can make with what they have (e.g. "what can I make tonight?").
 
 		Guidelines for responses:
 		- Always format your responses using markdown for better readability
@@ -282,6 +307,7 @@ func NewDefaultChatConfig() ChatConfig {
 		AssistantAvatar:          "",
 		UserAvatar:               "",
 		AssistantThinkingMessage: "Thinking...",
+		LLMCheckInterval:         30,
 		UILayout:                 NewDefaultUILayoutConfig(),
 	}
 }
@@ -395,6 +421,21 @@ type KeymapConfig struct {
 	ResetTimer           []string `json:"reset_timer"`
 	ChatScrollUp         []string `json:"chat_scroll_up"`
 	ChatScrollDown       []string `json:"chat_scroll_down"`
+	ScaleUp              []string `json:"scale_up"`
+	ScaleDown            []string `json:"scale_down"`
+	ResetScale           []string `json:"reset_scale"`
+	ToggleUnits          []string `json:"toggle_units"`
+	AddToShoppingList    []string `json:"add_to_shopping_list"`
+	ToggleItem           []string `json:"toggle_item"`
+	SwitchPane           []string `json:"switch_pane"`
+	CursorLeft           []string `json:"cursor_left"`
+	CursorRight          []string `json:"cursor_right"`
+	PrevWeek             []string `json:"prev_week"`
+	NextWeek             []string `json:"next_week"`
+	SaveSearch           []string `json:"save_search"`
+	ManageCollections    []string `json:"manage_collections"`
+	LogCook              []string `json:"log_cook"`
+	SortList             []string `json:"sort_list"`
 }
 
 func NewDefaultKeyBindings() KeymapConfig {
@@ -442,6 +483,21 @@ func NewDefaultKeyBindings() KeymapConfig {
 		ResetTimer:           []string{"r"},
 		ChatScrollUp:         []string{"ctrl+u"},
 		ChatScrollDown:       []string{"ctrl+d"},
+		ScaleUp:              []string{"+", "="},
+		ScaleDown:            []string{"-"},
+		ResetScale:           []string{"0"},
+		ToggleUnits:          []string{"u"},
+		AddToShoppingList:    []string{"s"},
+		ToggleItem:           []string{" "},
+		SwitchPane:           []string{"tab"},
+		CursorLeft:           []string{"h", "left"},
+		CursorRight:          []string{"l", "right"},
+		PrevWeek:             []string{"["},
+		NextWeek:             []string{"]"},
+		SaveSearch:           []string{"ctrl+b"},
+		ManageCollections:    []string{"m"},
+		LogCook:              []string{"l"},
+		SortList:             []string{"o"},
 	}
 }
 
@@ -456,6 +512,10 @@ type GeneralConfig struct {
 	ContentWidth int `json:"status_line_content_width"`
 	ScrollSpeed  int `json:"scroll_speed"`
 	MoveSpeed    int `json:"move_speed"`
+
+	// UnitSystem is how ingredient amounts are displayed and exported:
+	// original (as written), metric, us or uk
+	UnitSystem string `json:"unit_system"`
 }
 
 func NewDefaultGeneralConfig() GeneralConfig {
@@ -465,6 +525,7 @@ func NewDefaultGeneralConfig() GeneralConfig {
 		ContentWidth: 0,
 		ScrollSpeed:  3,
 		MoveSpeed:    1,
+		UnitSystem:   "original",
 	}
 }
 
@@ -490,9 +551,11 @@ type ListConfig struct {
 	ViewStatusMessageFavouriteRemoved string `json:"list_view_status_message_favourite_removed"`
 	ViewStatusMessageRecipeDeleted    string `json:"list_view_status_message_recipe_deleted"`
 	ViewStatusMessageRecipeAdded      string `json:"list_view_status_message_recipe_added"`
+	ViewStatusMessageSearchSaved      string `json:"list_view_status_message_search_saved"`
 	Title                             string `json:"list_title"`
 	ItemNameSingular                  string `json:"list_item_name_singular"`
 	ItemNamePlural                    string `json:"list_item_name_plural"`
+	Sort                              string `json:"list_sort"` // name or last-cooked
 }
 
 func NewDefaultListConfig() ListConfig {
@@ -502,9 +565,11 @@ func NewDefaultListConfig() ListConfig {
 		ViewStatusMessageFavouriteRemoved: " ❌ Favourite removed!",
 		ViewStatusMessageRecipeDeleted:    " ❌ Recipe deleted!",
 		ViewStatusMessageRecipeAdded:      " ✅ Recipe added!",
+		ViewStatusMessageSearchSaved:      " 🔖 Search saved!",
 		Title:                             "📚 My Cookbook",
 		ItemNameSingular:                  "recipe",
 		ItemNamePlural:                    "recipes",
+		Sort:                              "name",
 	}
 }
 
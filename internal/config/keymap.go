@@ -51,6 +51,21 @@ type KeyMap struct {
 	ResetTimer           key.Binding
 	ChatScrollUp         key.Binding
 	ChatScrollDown       key.Binding
+	ScaleUp              key.Binding
+	ScaleDown            key.Binding
+	ResetScale           key.Binding
+	ToggleUnits          key.Binding
+	AddToShoppingList    key.Binding
+	ToggleItem           key.Binding
+	SwitchPane           key.Binding
+	CursorLeft           key.Binding
+	CursorRight          key.Binding
+	PrevWeek             key.Binding
+	NextWeek             key.Binding
+	SaveSearch           key.Binding
+	ManageCollections    key.Binding
+	LogCook              key.Binding
+	SortList             key.Binding
 }
 
 type ManagerKeyMap struct {
@@ -77,6 +92,8 @@ type ListKeyMap struct {
 	Delete                  key.Binding
 	Enter                   key.Binding
 	SetFavourite            key.Binding
+	SaveSearch              key.Binding
+	Sort                    key.Binding
 	ListKeyMap              list.KeyMap
 	AdditionalShortHelpKeys func() []key.Binding
 	AdditionalFullHelpKeys  func() []key.Binding
@@ -88,11 +105,53 @@ type DetailKeyMap struct {
 	Edit        key.Binding
 	SetRating   key.Binding
 	CookingMode key.Binding
+	ScaleUp     key.Binding
+	ScaleDown   key.Binding
+	ResetScale  key.Binding
+	ToggleUnits key.Binding
+	AddToList   key.Binding
+	Collections key.Binding
+	LogCook     key.Binding
 	Back        key.Binding
 	Quit        key.Binding
 	Help        key.Binding
 }
 
+type PantryKeyMap struct {
+	CursorUp   key.Binding
+	CursorDown key.Binding
+	Add        key.Binding
+	Edit       key.Binding
+	Delete     key.Binding
+	SwitchPane key.Binding
+	Enter      key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+}
+
+type MealPlanKeyMap struct {
+	CursorUp    key.Binding
+	CursorDown  key.Binding
+	CursorLeft  key.Binding
+	CursorRight key.Binding
+	PrevWeek    key.Binding
+	NextWeek    key.Binding
+	Add         key.Binding
+	Delete      key.Binding
+	AddToList   key.Binding
+	Enter       key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+type ShoppingListKeyMap struct {
+	CursorUp   key.Binding
+	CursorDown key.Binding
+	ToggleItem key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+}
+
 type CookingKeyMap struct {
 	NextStep          key.Binding
 	PrevStep          key.Binding
@@ -233,11 +292,13 @@ func (k KeyMap) GetListKeyMap() ListKeyMap {
 		Delete:       k.Delete,
 		Enter:        k.Enter,
 		SetFavourite: k.SetFavourite,
+		SaveSearch:   k.SaveSearch,
+		Sort:         k.SortList,
 		AdditionalShortHelpKeys: func() []key.Binding {
 			return []key.Binding{k.Add, k.Delete}
 		},
 		AdditionalFullHelpKeys: func() []key.Binding {
-			return []key.Binding{k.Add, k.Delete, k.SetFavourite}
+			return []key.Binding{k.Add, k.Delete, k.SetFavourite, k.SaveSearch, k.SortList}
 		},
 		ListKeyMap: list.KeyMap{
 			CursorUp:             k.CursorUp,
@@ -279,12 +340,60 @@ func (k KeyMap) GetDetailKeyMap() DetailKeyMap {
 		Edit:        k.Edit,
 		SetRating:   k.SetRating,
 		CookingMode: k.CookingMode,
+		ScaleUp:     k.ScaleUp,
+		ScaleDown:   k.ScaleDown,
+		ResetScale:  k.ResetScale,
+		ToggleUnits: k.ToggleUnits,
+		AddToList:   k.AddToShoppingList,
+		Collections: k.ManageCollections,
+		LogCook:     k.LogCook,
 		Back:        k.Back,
 		Quit:        k.Quit,
 		Help:        k.Help,
 	}
 }
 
+func (k KeyMap) GetPantryKeyMap() PantryKeyMap {
+	return PantryKeyMap{
+		CursorUp:   k.CursorUp,
+		CursorDown: k.CursorDown,
+		Add:        k.EditAdd,
+		Edit:       k.EditEdit,
+		Delete:     k.EditDelete,
+		SwitchPane: k.SwitchPane,
+		Enter:      k.Enter,
+		Back:       k.Back,
+		Quit:       k.Quit,
+	}
+}
+
+func (k KeyMap) GetMealPlanKeyMap() MealPlanKeyMap {
+	return MealPlanKeyMap{
+		CursorUp:    k.CursorUp,
+		CursorDown:  k.CursorDown,
+		CursorLeft:  k.CursorLeft,
+		CursorRight: k.CursorRight,
+		PrevWeek:    k.PrevWeek,
+		NextWeek:    k.NextWeek,
+		Add:         k.EditAdd,
+		Delete:      k.EditDelete,
+		AddToList:   k.AddToShoppingList,
+		Enter:       k.Enter,
+		Back:        k.Back,
+		Quit:        k.Quit,
+	}
+}
+
+func (k KeyMap) GetShoppingListKeyMap() ShoppingListKeyMap {
+	return ShoppingListKeyMap{
+		CursorUp:   k.CursorUp,
+		CursorDown: k.CursorDown,
+		ToggleItem: k.ToggleItem,
+		Back:       k.Back,
+		Quit:       k.Quit,
+	}
+}
+
 func (k KeyMap) GetCookingKeyMap() CookingKeyMap {
 	return CookingKeyMap{
 		NextStep:          k.NextPage,
@@ -590,5 +699,65 @@ func createKeyMap(keymapConfig KeymapConfig) KeyMap {
 			key.WithKeys(keymapConfig.ChatScrollDown...),
 			key.WithHelp(strings.Join(keymapConfig.ChatScrollDown, "/"), "scroll chat down"),
 		),
+		ScaleUp: key.NewBinding(
+			key.WithKeys(keymapConfig.ScaleUp...),
+			key.WithHelp(strings.Join(keymapConfig.ScaleUp, "/"), "more servings"),
+		),
+		ScaleDown: key.NewBinding(
+			key.WithKeys(keymapConfig.ScaleDown...),
+			key.WithHelp(strings.Join(keymapConfig.ScaleDown, "/"), "fewer servings"),
+		),
+		ResetScale: key.NewBinding(
+			key.WithKeys(keymapConfig.ResetScale...),
+			key.WithHelp(strings.Join(keymapConfig.ResetScale, "/"), "original servings"),
+		),
+		ToggleUnits: key.NewBinding(
+			key.WithKeys(keymapConfig.ToggleUnits...),
+			key.WithHelp(strings.Join(keymapConfig.ToggleUnits, "/"), "switch units"),
+		),
+		AddToShoppingList: key.NewBinding(
+			key.WithKeys(keymapConfig.AddToShoppingList...),
+			key.WithHelp(strings.Join(keymapConfig.AddToShoppingList, "/"), "add to shopping list"),
+		),
+		ToggleItem: key.NewBinding(
+			key.WithKeys(keymapConfig.ToggleItem...),
+			key.WithHelp(keyHelpDisplay(keymapConfig.ToggleItem), "tick item"),
+		),
+		SwitchPane: key.NewBinding(
+			key.WithKeys(keymapConfig.SwitchPane...),
+			key.WithHelp(strings.Join(keymapConfig.SwitchPane, "/"), "switch pane"),
+		),
+		CursorLeft: key.NewBinding(
+			key.WithKeys(keymapConfig.CursorLeft...),
+			key.WithHelp(strings.Join(keymapConfig.CursorLeft, "/"), "left"),
+		),
+		CursorRight: key.NewBinding(
+			key.WithKeys(keymapConfig.CursorRight...),
+			key.WithHelp(strings.Join(keymapConfig.CursorRight, "/"), "right"),
+		),
+		PrevWeek: key.NewBinding(
+			key.WithKeys(keymapConfig.PrevWeek...),
+			key.WithHelp(strings.Join(keymapConfig.PrevWeek, "/"), "previous week"),
+		),
+		NextWeek: key.NewBinding(
+			key.WithKeys(keymapConfig.NextWeek...),
+			key.WithHelp(strings.Join(keymapConfig.NextWeek, "/"), "next week"),
+		),
+		SaveSearch: key.NewBinding(
+			key.WithKeys(keymapConfig.SaveSearch...),
+			key.WithHelp(strings.Join(keymapConfig.SaveSearch, "/"), "save search"),
+		),
+		ManageCollections: key.NewBinding(
+			key.WithKeys(keymapConfig.ManageCollections...),
+			key.WithHelp(strings.Join(keymapConfig.ManageCollections, "/"), "collections"),
+		),
+		LogCook: key.NewBinding(
+			key.WithKeys(keymapConfig.LogCook...),
+			key.WithHelp(strings.Join(keymapConfig.LogCook, "/"), "log cook"),
+		),
+		SortList: key.NewBinding(
+			key.WithKeys(keymapConfig.SortList...),
+			key.WithHelp(strings.Join(keymapConfig.SortList, "/"), "sort"),
+		),
 	}
 }
@@ -0,0 +1,239 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"gorm.io/gorm"
+)
+
+// notFoundError is a lookup that found nothing. It wraps
+// gorm.ErrRecordNotFound, so callers can tell it from a failed query.
+type notFoundError struct {
+	message string
+}
+
+func (e notFoundError) Error() string { return e.message }
+
+func (e notFoundError) Unwrap() error { return gorm.ErrRecordNotFound }
+
+// slugOwner says what already uses a slug, "collection" or "saved search",
+// or is empty when nothing does. Collections and saved searches are picked
+// by the same slugs, so one may not take the slug of the other.
+func slugOwner(tx *gorm.DB, slug string) (string, error) {
+	for _, owner := range []struct {
+		model any
+		name  string
+	}{
+		{&Collection{}, "collection"},
+		{&SavedSearch{}, "saved search"},
+	} {
+		var taken int64
+		if err := tx.Model(owner.model).Where("slug = ?", slug).Count(&taken).Error; err != nil {
+			return "", err
+		}
+		if taken > 0 {
+			return owner.name, nil
+		}
+	}
+	return "", nil
+}
+
+// Collections returns every collection with its number of recipes, ordered by name
+func (c *CookBook) Collections() ([]utils.Collection, error) {
+	var collections []utils.Collection
+	err := c.conn.Model(&Collection{}).
+		Select("collections.id, collections.name, collections.slug, COUNT(collection_recipes.id) AS recipe_count").
+		Joins("LEFT JOIN collection_recipes ON collection_recipes.collection_id = collections.id AND collection_recipes.deleted_at IS NULL").
+		Group("collections.id").
+		Order("collections.name").
+		Scan(&collections).Error
+	if err != nil {
+		slog.Error("Error fetching collections", "error", err)
+		return nil, err
+	}
+	return collections, nil
+}
+
+// RecipeCollections returns the IDs of the collections a recipe is in
+func (c *CookBook) RecipeCollections(recipeID uint) ([]uint, error) {
+	var ids []uint
+	if err := c.conn.Model(&CollectionRecipe{}).Where("recipe_id = ?", recipeID).Pluck("collection_id", &ids).Error; err != nil {
+		slog.Error("Error fetching collections of recipe", "id", recipeID, "error", err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CreateCollection creates an empty collection and returns its ID
+func (c *CookBook) CreateCollection(name string) (uint, error) {
+	name = strings.TrimSpace(name)
+	slug := utils.CollectionSlug(name)
+	if slug == "" {
+		return 0, fmt.Errorf("a collection needs a name")
+	}
+
+	owner, err := slugOwner(c.conn, slug)
+	if err != nil {
+		return 0, err
+	}
+	if owner != "" {
+		return 0, fmt.Errorf("a %s named %q already exists", owner, name)
+	}
+
+	collection := Collection{Name: name, Slug: slug}
+	if err := c.conn.Create(&collection).Error; err != nil {
+		slog.Error("Error creating collection", "name", name, "error", err)
+		return 0, err
+	}
+	return collection.ID, nil
+}
+
+// CollectionBySlug finds a collection by its slug or name
+func (c *CookBook) CollectionBySlug(slugOrName string) (utils.Collection, error) {
+	var row Collection
+	err := c.conn.Where("slug = ?", utils.CollectionSlug(slugOrName)).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.Collection{}, notFoundError{fmt.Sprintf("no collection named %q", slugOrName)}
+	}
+	if err != nil {
+		slog.Error("Error fetching collection", "slug", slugOrName, "error", err)
+		return utils.Collection{}, err
+	}
+	return utils.Collection{ID: row.ID, Name: row.Name, Slug: row.Slug}, nil
+}
+
+// DeleteCollection removes a collection. Its recipes stay in the cookbook.
+func (c *CookBook) DeleteCollection(collectionID uint) error {
+	err := c.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&CollectionRecipe{}, "collection_id = ?", collectionID).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&Collection{}, collectionID).Error
+	})
+	if err != nil {
+		slog.Error("Error deleting collection", "id", collectionID, "error", err)
+	}
+	return err
+}
+
+// AddToCollection puts a recipe at the end of a collection. A recipe
+// already in the collection keeps its place.
+func (c *CookBook) AddToCollection(collectionID uint, recipeID uint) error {
+	err := c.conn.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&CollectionRecipe{}).Where("collection_id = ? AND recipe_id = ?", collectionID, recipeID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return nil
+		}
+
+		var last int
+		if err := tx.Model(&CollectionRecipe{}).Where("collection_id = ?", collectionID).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		return tx.Create(&CollectionRecipe{CollectionID: collectionID, RecipeID: recipeID, Position: last + 1}).Error
+	})
+	if err != nil {
+		slog.Error("Error adding recipe to collection", "collection", collectionID, "recipe", recipeID, "error", err)
+	}
+	return err
+}
+
+// RemoveFromCollection takes a recipe out of a collection
+func (c *CookBook) RemoveFromCollection(collectionID uint, recipeID uint) error {
+	err := c.conn.Unscoped().Delete(&CollectionRecipe{}, "collection_id = ? AND recipe_id = ?", collectionID, recipeID).Error
+	if err != nil {
+		slog.Error("Error removing recipe from collection", "collection", collectionID, "recipe", recipeID, "error", err)
+	}
+	return err
+}
+
+// MoveInCollection moves a recipe up (negative offset) or down a collection
+// by swapping it with its neighbour
+func (c *CookBook) MoveInCollection(collectionID uint, recipeID uint, offset int) error {
+	err := c.conn.Transaction(func(tx *gorm.DB) error {
+		var rows []CollectionRecipe
+		if err := tx.Where("collection_id = ?", collectionID).Order("position").Find(&rows).Error; err != nil {
+			return err
+		}
+
+		from := -1
+		for i, row := range rows {
+			if row.RecipeID == recipeID {
+				from = i
+			}
+		}
+		to := from + offset
+		if from < 0 || to < 0 || to >= len(rows) {
+			return nil
+		}
+
+		rows[from], rows[to] = rows[to], rows[from]
+		for i := min(from, to); i <= max(from, to); i++ {
+			if err := tx.Model(&rows[i]).Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Error moving recipe in collection", "collection", collectionID, "recipe", recipeID, "error", err)
+	}
+	return err
+}
+
+// CollectionRecipes returns the recipes of a collection in its order
+func (c *CookBook) CollectionRecipes(collectionID uint) ([]utils.RecipeRaw, error) {
+	var ids []uint
+	if err := c.conn.Model(&CollectionRecipe{}).Where("collection_id = ?", collectionID).Order("position").Pluck("recipe_id", &ids).Error; err != nil {
+		slog.Error("Error fetching collection recipes", "collection", collectionID, "error", err)
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []utils.RecipeRaw{}, nil
+	}
+
+	recipes, err := c.recipeSummaries(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]utils.RecipeRaw, len(recipes))
+	for _, recipe := range recipes {
+		byID[recipe.RecipeID] = recipe
+	}
+
+	ordered := make([]utils.RecipeRaw, 0, len(recipes))
+	for _, id := range ids {
+		if recipe, ok := byID[id]; ok {
+			ordered = append(ordered, recipe)
+		}
+	}
+	return ordered, nil
+}
+
+// RecipesInCollection returns the recipes of the collection or saved search
+// with the given slug or name: a collection's in its order, a saved search's
+// best match first.
+func (c *CookBook) RecipesInCollection(slugOrName string) ([]utils.RecipeRaw, error) {
+	collection, err := c.CollectionBySlug(slugOrName)
+	if err == nil {
+		return c.CollectionRecipes(collection.ID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	search, err := c.SavedSearchBySlug(slugOrName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("no collection or saved search named %q", slugOrName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c.SavedSearchRecipes(search)
+}
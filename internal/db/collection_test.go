@@ -0,0 +1,40 @@
+package db
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCollectionAndSavedSearchSlugs(t *testing.T) {
+	cookbook := newTestCookBook(t)
+	if _, err := cookbook.CreateCollection("Quick weeknight"); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	if _, err := cookbook.SaveSearch("quick weeknight", "@time<30m"); err == nil || !strings.Contains(err.Error(), "a collection named") {
+		t.Errorf("SaveSearch with a collection's name: err = %v, want a collection named ... already exists", err)
+	}
+
+	if _, err := cookbook.SaveSearch("Favourites", "@fav"); err != nil {
+		t.Fatalf("SaveSearch: %v", err)
+	}
+	if _, err := cookbook.SaveSearch("favourites", "@fav @rating>=4"); err != nil {
+		t.Errorf("SaveSearch again under the same name: %v", err)
+	}
+	if _, err := cookbook.CreateCollection("Favourites"); err == nil || !strings.Contains(err.Error(), "a saved search named") {
+		t.Errorf("CreateCollection with a saved search's name: err = %v, want a saved search named ... already exists", err)
+	}
+
+	if _, err := cookbook.RecipesInCollection("nothing"); err == nil || err.Error() != `no collection or saved search named "nothing"` {
+		t.Errorf("RecipesInCollection(nothing): err = %v", err)
+	}
+
+	// A failing query is reported as such, not as a missing collection
+	sqlDB, err := cookbook.conn.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.Close()
+	if _, err := cookbook.RecipesInCollection("favourites"); err == nil || strings.Contains(err.Error(), "no collection") {
+		t.Errorf("RecipesInCollection on a closed database: err = %v, want the database error", err)
+	}
+}
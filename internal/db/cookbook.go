@@ -1,45 +1,26 @@
 package db
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
-	"os"
-	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/GarroshIcecream/yummy/internal/config"
-	"github.com/GarroshIcecream/yummy/internal/log"
 	utils "github.com/GarroshIcecream/yummy/internal/utils"
-	"github.com/glebarez/sqlite"
 	"gorm.io/gorm"
-	gormlogger "gorm.io/gorm/logger"
 )
 
-// Creates new instance of CookBook struct
+// Creates new instance of CookBook struct and brings its schema up to date
 func NewCookBook(dbPath string, config *config.DatabaseConfig, opts ...gorm.Option) (*CookBook, error) {
-	dbDir := filepath.Join(dbPath, "db")
-	if err := os.MkdirAll(dbDir, 0755); err != nil {
-		slog.Error("Failed to create database directory", "dir", dbDir, "error", err)
-		return nil, err
-	}
-
-	dbPath = filepath.Join(dbDir, config.RecipeDBName)
-	_, err := os.Stat(dbPath)
-	if err != nil {
-		slog.Info("Database does not exist at %s, creating new database...", "dbPath", dbPath, "error", err)
-	}
-
-	dbCon, err := gorm.Open(sqlite.Open(dbPath), opts...)
+	dbCon, dbFile, err := openDatabase(dbPath, config.RecipeDBName, opts...)
 	if err != nil {
-		slog.Error("Error opening database", "dbPath", dbPath, "error", err)
 		return nil, err
 	}
 
-	// Configure GORM to use slog logger (logs to file via slog setup, not stdout)
-	dbCon.Logger = log.NewGormLogger(200*time.Millisecond, true, gormlogger.Info)
-
-	if err := dbCon.AutoMigrate(GetCookbookModels()...); err != nil {
-		slog.Error("Error migrating cookbook models", "error", err)
+	if err := NewMigrator(dbCon, dbFile, GetCookbookMigrations()).MigrateLatest(); err != nil {
+		slog.Error("Error migrating cookbook database", "error", err)
 		return nil, err
 	}
 
@@ -81,10 +62,23 @@ func (c *CookBook) GetAllAuthors() ([]string, error) {
 	return authors, nil
 }
 
-// RecipeByName gets first matching recipe by name from the database
+// GetAllIngredientNames returns the distinct ingredient names of all recipes, sorted
+func (c *CookBook) GetAllIngredientNames() ([]string, error) {
+	var names []string
+	if err := c.conn.Model(&Ingredients{}).Distinct("ingredient_name").Where("ingredient_name != ''").Order("ingredient_name").Pluck("ingredient_name", &names).Error; err != nil {
+		slog.Error("Error fetching ingredient names", "error", err)
+		return nil, err
+	}
+
+	slog.Debug("Ingredient names fetched", "count", len(names))
+	return names, nil
+}
+
+// RecipeByName gets the first recipe with the given name (compared
+// case-insensitively) from the database
 func (c *CookBook) RecipeByName(recipeName string) (Recipe, error) {
 	var recipe Recipe
-	if err := c.conn.First(&recipe, "RecipeName = ?", recipeName).Error; err != nil {
+	if err := c.conn.First(&recipe, "LOWER(recipe_name) = LOWER(?)", strings.TrimSpace(recipeName)).Error; err != nil {
 		slog.Error("Error fetching recipe by name", "recipe_name", recipeName, "error", err)
 		return Recipe{}, err
 	}
@@ -130,6 +124,35 @@ func (c *CookBook) RecipeExistsByURL(url string) (uint, error) {
 	return meta.RecipeID, nil
 }
 
+// RecipeExistsByName returns the ID of a recipe with the given name (compared
+// case-insensitively), or 0 if not found.
+func (c *CookBook) RecipeExistsByName(name string) (uint, error) {
+	var recipe Recipe
+	err := c.conn.Where("LOWER(recipe_name) = LOWER(?)", strings.TrimSpace(name)).First(&recipe).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return recipe.ID, nil
+}
+
+// RecipeIDsByName returns the IDs of every recipe with the given name
+// (compared case-insensitively), oldest first.
+func (c *CookBook) RecipeIDsByName(name string) ([]uint, error) {
+	var ids []uint
+	err := c.conn.Model(&Recipe{}).Where("LOWER(recipe_name) = LOWER(?)", strings.TrimSpace(name)).Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// RecipeExists reports whether a recipe with the given ID exists
+func (c *CookBook) RecipeExists(recipeID uint) (bool, error) {
+	var count int64
+	err := c.conn.Model(&Recipe{}).Where("id = ?", recipeID).Count(&count).Error
+	return count > 0, err
+}
+
 // DeleteRecipe deletes a recipe from the database by ID
 func (c *CookBook) DeleteRecipe(recipeID uint) error {
 	slog.Debug("Starting deletion of recipe with ID", "id", recipeID)
@@ -181,6 +204,41 @@ func (c *CookBook) DeleteRecipe(recipeID uint) error {
 		return res.Error
 	}
 
+	// Delete keywords, diets and nutrients
+	if err := deleteRecipeDetails(tx, recipeID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := unindexRecipe(tx, recipeID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Take the recipe out of its collections
+	res = tx.Unscoped().Delete(&CollectionRecipe{}, "recipe_id = ?", recipeID)
+	if res.Error != nil {
+		slog.Error("Error removing recipe from collections", "error", res.Error)
+		tx.Rollback()
+		return res.Error
+	}
+
+	// Delete its cook log
+	res = tx.Unscoped().Delete(&CookEvent{}, "recipe_id = ?", recipeID)
+	if res.Error != nil {
+		slog.Error("Error deleting cook log", "error", res.Error)
+		tx.Rollback()
+		return res.Error
+	}
+
+	// Take the recipe off the meal plan
+	res = tx.Unscoped().Delete(&MealPlanEntry{}, "recipe_id = ?", recipeID)
+	if res.Error != nil {
+		slog.Error("Error removing recipe from meal plan", "error", res.Error)
+		tx.Rollback()
+		return res.Error
+	}
+
 	// Delete the main recipe
 	res = tx.Unscoped().Delete(&Recipe{}, "id = ?", recipeID)
 	if res.Error != nil {
@@ -206,6 +264,9 @@ func (c *CookBook) CreateNewRecipe(recipeName string) (uint, error) {
 		slog.Error("Error creating recipe", "error", err)
 		return 0, err
 	}
+	if err := indexRecipe(c.conn, newRecipe.ID); err != nil {
+		return 0, err
+	}
 	return newRecipe.ID, nil
 }
 
@@ -226,8 +287,76 @@ func (c *CookBook) AllFavouriteRecipes() ([]utils.RecipeRaw, error) {
 	return favouriteRecipes, nil
 }
 
+// RecipeFilter narrows AllRecipes down to a subset; zero values match everything.
+type RecipeFilter struct {
+	Category       string
+	Author         string
+	FavouritesOnly bool
+}
+
+// Matches reports whether the recipe satisfies every set field of the filter.
+// Category and author comparisons are case-insensitive.
+func (f RecipeFilter) Matches(recipe utils.RecipeRaw) bool {
+	if f.FavouritesOnly && !recipe.IsFavourite {
+		return false
+	}
+	if f.Author != "" && !strings.EqualFold(recipe.Metadata.Author, f.Author) {
+		return false
+	}
+	if f.Category != "" {
+		found := false
+		for _, category := range recipe.Metadata.Categories {
+			if strings.EqualFold(category, f.Category) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterRecipes returns all recipes matching the filter, ordered by name
+func (c *CookBook) FilterRecipes(filter RecipeFilter) ([]utils.RecipeRaw, error) {
+	allRecipes, err := c.AllRecipes()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]utils.RecipeRaw, 0, len(allRecipes))
+	for _, recipe := range allRecipes {
+		if filter.Matches(recipe) {
+			filtered = append(filtered, recipe)
+		}
+	}
+
+	slog.Debug("Recipes filtered", "filter", filter, "count", len(filtered))
+	return filtered, nil
+}
+
 // AllRecipes returns all recipes with their metadata
 func (c *CookBook) AllRecipes() ([]utils.RecipeRaw, error) {
+	return c.recipeSummaries(nil)
+}
+
+// RecipeSummary returns a recipe with its metadata and categories, as listed
+// by AllRecipes
+func (c *CookBook) RecipeSummary(recipeID uint) (utils.RecipeRaw, error) {
+	recipes, err := c.recipeSummaries([]uint{recipeID})
+	if err != nil {
+		return utils.RecipeRaw{}, err
+	}
+	if len(recipes) == 0 {
+		return utils.RecipeRaw{}, fmt.Errorf("no recipe with ID %d", recipeID)
+	}
+	return recipes[0], nil
+}
+
+// recipeSummaries returns the recipes with the given IDs (all when ids is
+// nil) with their metadata and categories, ordered by name
+func (c *CookBook) recipeSummaries(ids []uint) ([]utils.RecipeRaw, error) {
 	// Build the base query with JOINs to get all data in one query
 	query := c.conn.Table("recipes").
 		Select(`
@@ -240,10 +369,16 @@ func (c *CookBook) AllRecipes() ([]utils.RecipeRaw, error) {
 			COALESCE(recipe_metadata.total_time, 0) as total_time,
 			COALESCE(recipe_metadata.quantity, '') as quantity,
 			COALESCE(recipe_metadata.url, '') as url,
-			COALESCE(recipe_metadata.favourite, 0) as favourite
+			COALESCE(recipe_metadata.favourite, 0) as favourite,
+			COALESCE(recipe_metadata.rating, 0) as rating,
+			COALESCE((SELECT MAX(day) FROM cook_events
+				WHERE cook_events.recipe_id = recipes.id AND cook_events.deleted_at IS NULL), '') as last_cooked
 		`).
 		Joins("LEFT JOIN recipe_metadata ON recipes.id = recipe_metadata.recipe_id").
 		Order("recipes.recipe_name")
+	if ids != nil {
+		query = query.Where("recipes.id IN ?", ids)
+	}
 
 	// Execute the query to get all recipes with metadata
 	type RecipeWithMetadata struct {
@@ -257,6 +392,8 @@ func (c *CookBook) AllRecipes() ([]utils.RecipeRaw, error) {
 		Quantity    string
 		URL         string
 		Favourite   bool
+		Rating      int8
+		LastCooked  string
 	}
 
 	var recipesWithMetadata []RecipeWithMetadata
@@ -322,6 +459,8 @@ func (c *CookBook) AllRecipes() ([]utils.RecipeRaw, error) {
 				Quantity:   r.Quantity,
 				URL:        r.URL,
 				Favourite:  r.Favourite,
+				Rating:     r.Rating,
+				LastCooked: parseDay(r.LastCooked),
 			},
 		}
 
@@ -387,9 +526,13 @@ func (c *CookBook) SaveScrapedRecipe(recipeRaw *utils.RecipeRaw) (uint, error) {
 		TotalTime:   recipeRaw.Metadata.TotalTime,
 		Quantity:    recipeRaw.Metadata.Quantity,
 		URL:         recipeRaw.Metadata.URL,
-		Favourite:   false,
-		Rating:      0,
+		ImageURL:    recipeRaw.Metadata.ImageURL,
+		Language:    recipeRaw.Metadata.Language,
+		Favourite:   recipeRaw.Metadata.Favourite,
+		Rating:      recipeRaw.Metadata.Rating,
+		Notes:       recipeRaw.Metadata.Notes,
 	}
+	metadata.setNutrition(utils.EstimateNutrition(recipeRaw.Metadata.Ingredients, recipeRaw.Metadata.Quantity))
 	if err := c.conn.Create(&metadata).Error; err != nil {
 		slog.Error("Error creating metadata", "error", err)
 		return 0, err
@@ -404,6 +547,7 @@ func (c *CookBook) SaveScrapedRecipe(recipeRaw *utils.RecipeRaw) (uint, error) {
 			Amount:         ingredient.Amount,
 			Unit:           ingredient.Unit,
 			BaseName:       ingredient.BaseName,
+			GroupName:      ingredient.Group,
 		}
 		if err := c.conn.Create(&ing).Error; err != nil {
 			slog.Error("Error creating ingredient", "error", err)
@@ -412,12 +556,16 @@ func (c *CookBook) SaveScrapedRecipe(recipeRaw *utils.RecipeRaw) (uint, error) {
 	}
 
 	// Save instructions
-	// add step number to each instruction
+	// add step number, section and note to each instruction
+	sectionTitles := recipeRaw.Metadata.InstructionSectionTitles()
+	stepNotes := recipeRaw.Metadata.StepNoteList()
 	for i, instruction := range recipeRaw.Metadata.Instructions {
 		inst := Instructions{
 			RecipeID:    recipe.ID,
 			Step:        i + 1,
 			Description: instruction,
+			Section:     sectionTitles[i],
+			Note:        stepNotes[i],
 		}
 		if err := c.conn.Create(&inst).Error; err != nil {
 			slog.Error("Error creating instruction", "error", err)
@@ -437,6 +585,27 @@ func (c *CookBook) SaveScrapedRecipe(recipeRaw *utils.RecipeRaw) (uint, error) {
 		}
 	}
 
+	// Save cuisines
+	for _, cuisineName := range recipeRaw.Metadata.Cuisines {
+		cuisine := Cuisine{
+			RecipeID:    recipe.ID,
+			CuisineName: cuisineName,
+		}
+		if err := c.conn.Create(&cuisine).Error; err != nil {
+			slog.Error("Error creating cuisine", "error", err)
+			return 0, err
+		}
+	}
+
+	// Save keywords, diets and nutrients
+	if err := saveRecipeDetails(c.conn, recipe.ID, recipeRaw.Metadata); err != nil {
+		return 0, err
+	}
+
+	if err := indexRecipe(c.conn, recipe.ID); err != nil {
+		return 0, err
+	}
+
 	slog.Debug("Saved scraped recipe", "id", recipe.ID)
 	return recipe.ID, nil
 }
@@ -457,20 +626,31 @@ func (c *CookBook) UpdateRecipe(recipeRaw *utils.RecipeRaw) error {
 		return err
 	}
 
-	// Update metadata
-	metadata := RecipeMetadata{
-		Description: recipeRaw.RecipeDescription,
-		CookTime:    recipeRaw.Metadata.CookTime,
-		PrepTime:    recipeRaw.Metadata.PrepTime,
-		TotalTime:   recipeRaw.Metadata.TotalTime,
-		Quantity:    recipeRaw.Metadata.Quantity,
-		URL:         recipeRaw.Metadata.URL,
+	// Update metadata. Updates with a struct skips zero values, so every
+	// column is set from a map to let emptied fields replace the old ones.
+	metadata := map[string]any{
+		"description": recipeRaw.RecipeDescription,
+		"author":      recipeRaw.Metadata.Author,
+		"cook_time":   recipeRaw.Metadata.CookTime,
+		"prep_time":   recipeRaw.Metadata.PrepTime,
+		"total_time":  recipeRaw.Metadata.TotalTime,
+		"quantity":    recipeRaw.Metadata.Quantity,
+		"url":         recipeRaw.Metadata.URL,
+		"image_url":   recipeRaw.Metadata.ImageURL,
+		"language":    recipeRaw.Metadata.Language,
+		"favourite":   recipeRaw.Metadata.Favourite,
+		"rating":      recipeRaw.Metadata.Rating,
+		"notes":       recipeRaw.Metadata.Notes,
 	}
 	if err := tx.Model(&RecipeMetadata{}).Where("recipe_id = ?", recipeRaw.RecipeID).Updates(metadata).Error; err != nil {
 		tx.Rollback()
 		slog.Error("Error updating recipe metadata", "error", err)
 		return err
 	}
+	if err := updateNutrition(tx, recipeRaw.RecipeID, recipeRaw.Metadata.Ingredients, recipeRaw.Metadata.Quantity); err != nil {
+		tx.Rollback()
+		return err
+	}
 
 	// Delete existing ingredients
 	if err := tx.Unscoped().Delete(&Ingredients{}, "recipe_id = ?", recipeRaw.RecipeID).Error; err != nil {
@@ -488,6 +668,7 @@ func (c *CookBook) UpdateRecipe(recipeRaw *utils.RecipeRaw) error {
 			Amount:         ingredient.Amount,
 			Unit:           ingredient.Unit,
 			BaseName:       ingredient.BaseName,
+			GroupName:      ingredient.Group,
 		}
 		if err := tx.Create(&ing).Error; err != nil {
 			tx.Rollback()
@@ -504,11 +685,15 @@ func (c *CookBook) UpdateRecipe(recipeRaw *utils.RecipeRaw) error {
 	}
 
 	// Add new instructions
+	sectionTitles := recipeRaw.Metadata.InstructionSectionTitles()
+	stepNotes := recipeRaw.Metadata.StepNoteList()
 	for i, instruction := range recipeRaw.Metadata.Instructions {
 		inst := Instructions{
 			RecipeID:    recipeRaw.RecipeID,
 			Step:        i + 1,
 			Description: instruction,
+			Section:     sectionTitles[i],
+			Note:        stepNotes[i],
 		}
 		if err := tx.Create(&inst).Error; err != nil {
 			tx.Rollback()
@@ -537,6 +722,39 @@ func (c *CookBook) UpdateRecipe(recipeRaw *utils.RecipeRaw) error {
 		}
 	}
 
+	// Replace cuisines
+	if err := tx.Unscoped().Delete(&Cuisine{}, "recipe_id = ?", recipeRaw.RecipeID).Error; err != nil {
+		tx.Rollback()
+		slog.Error("Error deleting existing cuisines", "error", err)
+		return err
+	}
+	for _, cuisineName := range recipeRaw.Metadata.Cuisines {
+		cuisine := Cuisine{
+			RecipeID:    recipeRaw.RecipeID,
+			CuisineName: cuisineName,
+		}
+		if err := tx.Create(&cuisine).Error; err != nil {
+			tx.Rollback()
+			slog.Error("Error creating cuisine", "error", err)
+			return err
+		}
+	}
+
+	// Replace keywords, diets and nutrients
+	if err := deleteRecipeDetails(tx, recipeRaw.RecipeID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := saveRecipeDetails(tx, recipeRaw.RecipeID, recipeRaw.Metadata); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := indexRecipe(tx, recipeRaw.RecipeID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
 		slog.Error("Error committing transaction", "error", err)
@@ -549,47 +767,101 @@ func (c *CookBook) UpdateRecipe(recipeRaw *utils.RecipeRaw) error {
 
 // GetFullRecipe retrieves a complete recipe with all its related data
 func (c *CookBook) GetFullRecipe(recipeID uint) (*utils.RecipeRaw, error) {
+	return getFullRecipe(c.conn, recipeID)
+}
+
+// getFullRecipe loads a recipe through conn, which may be a transaction
+func getFullRecipe(conn *gorm.DB, recipeID uint) (*utils.RecipeRaw, error) {
 	// Get the base recipe
 	slog.Debug("Starting GetFullRecipe for ID", "id", recipeID)
 
 	var recipe_raw Recipe
-	if err := c.conn.First(&recipe_raw, recipeID).Error; err != nil {
+	if err := conn.First(&recipe_raw, recipeID).Error; err != nil {
 		slog.Error("Error fetching base recipe", "error", err)
 		return nil, err
 	}
 
 	// Get metadata
 	var metadata RecipeMetadata
-	if err := c.conn.Where("recipe_id = ?", recipe_raw.ID).First(&metadata).Error; err != nil {
+	if err := conn.Where("recipe_id = ?", recipe_raw.ID).First(&metadata).Error; err != nil {
 		slog.Error("Error fetching metadata", "error", err)
 		return nil, err
 	}
 
 	// Get ingredients
 	var ingredients []Ingredients
-	if err := c.conn.Where("recipe_id = ?", recipe_raw.ID).Find(&ingredients).Error; err != nil {
+	if err := conn.Where("recipe_id = ?", recipe_raw.ID).Order("id").Find(&ingredients).Error; err != nil {
 		slog.Error("Error fetching ingredients", "error", err)
 		return nil, err
 	}
 
 	// Get instructions
 	var instructions []Instructions
-	if err := c.conn.Where("recipe_id = ?", recipe_raw.ID).Find(&instructions).Error; err != nil {
+	if err := conn.Where("recipe_id = ?", recipe_raw.ID).Order("step").Find(&instructions).Error; err != nil {
 		slog.Error("Error fetching instructions", "error", err)
 		return nil, err
 	}
 
 	// Get categories
 	var categories []Category
-	if err := c.conn.Where("recipe_id = ?", recipe_raw.ID).Find(&categories).Error; err != nil {
+	if err := conn.Where("recipe_id = ?", recipe_raw.ID).Find(&categories).Error; err != nil {
 		slog.Error("Error fetching categories", "error", err)
 		return nil, err
 	}
 
+	// Get cuisines
+	var cuisines []Cuisine
+	if err := conn.Where("recipe_id = ?", recipe_raw.ID).Find(&cuisines).Error; err != nil {
+		slog.Error("Error fetching cuisines", "error", err)
+		return nil, err
+	}
+	cuisineNames := make([]string, len(cuisines))
+	for i, cuisine := range cuisines {
+		cuisineNames[i] = cuisine.CuisineName
+	}
+
+	// Get keywords, diets and nutrients
+	var keywords []Keyword
+	if err := conn.Where("recipe_id = ?", recipe_raw.ID).Find(&keywords).Error; err != nil {
+		slog.Error("Error fetching keywords", "error", err)
+		return nil, err
+	}
+	keywordNames := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		keywordNames[i] = keyword.Keyword
+	}
+
+	var diets []Diet
+	if err := conn.Where("recipe_id = ?", recipe_raw.ID).Find(&diets).Error; err != nil {
+		slog.Error("Error fetching diets", "error", err)
+		return nil, err
+	}
+	dietNames := make([]string, len(diets))
+	for i, diet := range diets {
+		dietNames[i] = diet.DietName
+	}
+
+	var nutrients []Nutrient
+	if err := conn.Where("recipe_id = ?", recipe_raw.ID).Find(&nutrients).Error; err != nil {
+		slog.Error("Error fetching nutrients", "error", err)
+		return nil, err
+	}
+	var nutrientValues map[string]string
+	if len(nutrients) > 0 {
+		nutrientValues = make(map[string]string, len(nutrients))
+		for _, nutrient := range nutrients {
+			nutrientValues[nutrient.Name] = nutrient.Value
+		}
+	}
+
 	// Convert instructions
 	instructionDescriptions := make([]string, len(instructions))
+	instructionSections := make([]string, len(instructions))
+	instructionNotes := make([]string, len(instructions))
 	for i, inst := range instructions {
 		instructionDescriptions[i] = inst.Description
+		instructionSections[i] = inst.Section
+		instructionNotes[i] = inst.Note
 	}
 
 	// Convert categories
@@ -599,16 +871,7 @@ func (c *CookBook) GetFullRecipe(recipeID uint) (*utils.RecipeRaw, error) {
 	}
 
 	// Convert ingredients
-	parsedIngredients := make([]utils.Ingredient, len(ingredients))
-	for i, ing := range ingredients {
-		parsedIngredients[i] = utils.Ingredient{
-			Name:     ing.IngredientName,
-			Details:  ing.Detail,
-			Amount:   ing.Amount,
-			Unit:     ing.Unit,
-			BaseName: ing.BaseName,
-		}
-	}
+	parsedIngredients := toIngredients(ingredients)
 
 	// Convert to RecipeRaw
 	recipeRaw := &utils.RecipeRaw{
@@ -622,16 +885,65 @@ func (c *CookBook) GetFullRecipe(recipeID uint) (*utils.RecipeRaw, error) {
 			TotalTime:    metadata.TotalTime,
 			Quantity:     metadata.Quantity,
 			URL:          metadata.URL,
+			ImageURL:     metadata.ImageURL,
+			Language:     metadata.Language,
 			Favourite:    metadata.Favourite,
 			Rating:       metadata.Rating,
 			CreatedAt:    metadata.CreatedAt,
 			UpdatedAt:    metadata.UpdatedAt,
 			Categories:   categoryNames,
+			Cuisines:     cuisineNames,
+			Keywords:     keywordNames,
+			Diets:        dietNames,
+			Nutrients:    nutrientValues,
+			Nutrition:    metadata.nutritionEstimate(),
 			Instructions: instructionDescriptions,
 			Ingredients:  parsedIngredients,
+			Notes:        metadata.Notes,
+			StepNotes:    utils.StepNotesFromList(instructionNotes),
+
+			InstructionSections: utils.InstructionSectionsFromTitles(instructionSections),
 		},
 	}
 
 	slog.Debug("GetFullRecipe completed successfully", "id", recipeID)
 	return recipeRaw, nil
 }
+
+// saveRecipeDetails stores the keywords, diets and nutrients of a recipe
+func saveRecipeDetails(tx *gorm.DB, recipeID uint, metadata utils.RecipeMetadata) error {
+	for _, keyword := range metadata.Keywords {
+		if err := tx.Create(&Keyword{RecipeID: recipeID, Keyword: keyword}).Error; err != nil {
+			slog.Error("Error creating keyword", "error", err)
+			return err
+		}
+	}
+
+	for _, dietName := range metadata.Diets {
+		if err := tx.Create(&Diet{RecipeID: recipeID, DietName: dietName}).Error; err != nil {
+			slog.Error("Error creating diet", "error", err)
+			return err
+		}
+	}
+
+	for _, name := range utils.SortedNutrientKeys(metadata.Nutrients) {
+		nutrient := Nutrient{RecipeID: recipeID, Name: name, Value: metadata.Nutrients[name]}
+		if err := tx.Create(&nutrient).Error; err != nil {
+			slog.Error("Error creating nutrient", "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteRecipeDetails removes the keywords, diets and nutrients of a recipe
+func deleteRecipeDetails(tx *gorm.DB, recipeID uint) error {
+	for _, model := range []any{&Keyword{}, &Diet{}, &Nutrient{}} {
+		if err := tx.Unscoped().Delete(model, "recipe_id = ?", recipeID).Error; err != nil {
+			slog.Error("Error deleting recipe details", "error", err)
+			return err
+		}
+	}
+	return nil
+}
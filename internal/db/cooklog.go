@@ -0,0 +1,101 @@
+package db
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+)
+
+// LogCook records a recipe being cooked and returns the event's ID. An
+// event with an ID replaces that event, so its notes can be filled in later.
+func (c *CookBook) LogCook(event utils.CookEvent) (uint, error) {
+	if event.Rating < 0 || event.Rating > 5 {
+		return 0, fmt.Errorf("rating must be between 0 and 5")
+	}
+	if event.Servings < 0 {
+		return 0, fmt.Errorf("servings cannot be negative")
+	}
+
+	var exists int64
+	if err := c.conn.Model(&Recipe{}).Where("id = ?", event.RecipeID).Count(&exists).Error; err != nil {
+		return 0, err
+	}
+	if exists == 0 {
+		return 0, fmt.Errorf("recipe %d not found", event.RecipeID)
+	}
+
+	row := CookEvent{
+		RecipeID: event.RecipeID,
+		Day:      event.Date.Format(utils.PlanDateLayout),
+		Servings: event.Servings,
+		Rating:   event.Rating,
+		Notes:    event.Notes,
+		Tweaks:   event.Tweaks,
+	}
+	row.ID = event.ID
+
+	var err error
+	if row.ID == 0 {
+		err = c.conn.Create(&row).Error
+	} else {
+		// Only an event of this recipe can be replaced
+		result := c.conn.Model(&CookEvent{}).Where("id = ? AND recipe_id = ?", row.ID, row.RecipeID).
+			Select("day", "servings", "rating", "notes", "tweaks").
+			Updates(&row)
+		err = result.Error
+		if err == nil && result.RowsAffected == 0 {
+			err = fmt.Errorf("cook event %d of recipe %d not found", row.ID, row.RecipeID)
+		}
+	}
+	if err != nil {
+		slog.Error("Error logging cook", "recipe", event.RecipeID, "error", err)
+		return 0, err
+	}
+
+	slog.Debug("Cook logged", "recipe", event.RecipeID, "day", row.Day)
+	return row.ID, nil
+}
+
+// CookEvents returns the cook log of a recipe, newest first
+func (c *CookBook) CookEvents(recipeID uint) ([]utils.CookEvent, error) {
+	var rows []CookEvent
+	if err := c.conn.Where("recipe_id = ?", recipeID).Order("day DESC, id DESC").Find(&rows).Error; err != nil {
+		slog.Error("Error fetching cook log", "recipe", recipeID, "error", err)
+		return nil, err
+	}
+
+	events := make([]utils.CookEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, utils.CookEvent{
+			ID:       row.ID,
+			RecipeID: row.RecipeID,
+			Date:     parseDay(row.Day),
+			Servings: row.Servings,
+			Rating:   row.Rating,
+			Notes:    row.Notes,
+			Tweaks:   row.Tweaks,
+		})
+	}
+	return events, nil
+}
+
+// DeleteCookEvent removes one entry from a recipe's cook log
+func (c *CookBook) DeleteCookEvent(eventID uint) error {
+	if err := c.conn.Unscoped().Delete(&CookEvent{}, eventID).Error; err != nil {
+		slog.Error("Error deleting cook event", "id", eventID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// parseDay parses a day stored as YYYY-MM-DD, returning the zero time for
+// an empty or invalid day
+func parseDay(day string) time.Time {
+	date, err := time.ParseInLocation(utils.PlanDateLayout, day, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
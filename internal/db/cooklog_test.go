@@ -0,0 +1,41 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+)
+
+func TestLogCookReplacesOnlyOwnEvents(t *testing.T) {
+	cookbook := newTestCookBook(t)
+	curryID := saveTestRecipe(t, cookbook, "Chicken Curry", 0)
+	soupID := saveTestRecipe(t, cookbook, "Tomato Soup", 0)
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local)
+
+	id, err := cookbook.LogCook(utils.CookEvent{RecipeID: curryID, Date: day, Notes: "too spicy"})
+	if err != nil {
+		t.Fatalf("LogCook: %v", err)
+	}
+
+	if _, err := cookbook.LogCook(utils.CookEvent{ID: id, RecipeID: curryID, Date: day, Rating: 4, Notes: "less chili"}); err != nil {
+		t.Fatalf("LogCook(replace): %v", err)
+	}
+	if _, err := cookbook.LogCook(utils.CookEvent{ID: id, RecipeID: soupID, Date: day, Notes: "wrong recipe"}); err == nil {
+		t.Error("LogCook with another recipe's event ID succeeded, want error")
+	}
+	if _, err := cookbook.LogCook(utils.CookEvent{ID: id + 100, RecipeID: curryID, Date: day}); err == nil {
+		t.Error("LogCook with an unknown event ID succeeded, want error")
+	}
+
+	events, err := cookbook.CookEvents(curryID)
+	if err != nil {
+		t.Fatalf("CookEvents: %v", err)
+	}
+	if len(events) != 1 || events[0].Rating != 4 || events[0].Notes != "less chili" {
+		t.Errorf("CookEvents = %+v, want the one replaced event", events)
+	}
+	if events, _ := cookbook.CookEvents(soupID); len(events) != 0 {
+		t.Errorf("CookEvents(soup) = %+v, want none", events)
+	}
+}
@@ -6,25 +6,6 @@ import (
 	"gorm.io/gorm"
 )
 
-func GetCookbookModels() []any {
-	return []any{
-		&Recipe{},
-		&Category{},
-		&Cuisine{},
-		&RecipeMetadata{},
-		&Instructions{},
-		&Ingredients{},
-	}
-}
-
-func GetSessionLogModels() []any {
-	return []any{
-		&SessionHistory{},
-		&SessionMessage{},
-	}
-
-}
-
 type CookBook struct {
 	conn *gorm.DB
 }
@@ -33,6 +14,9 @@ type SessionLog struct {
 	conn *gorm.DB
 }
 
+// The cookbook tables are created by GetCookbookMigrations. A new model, or a
+// new field on one, needs its own migration there.
+
 type Recipe struct {
 	gorm.Model
 	RecipeName string
@@ -50,6 +34,27 @@ type Cuisine struct {
 	CuisineName string
 }
 
+type Keyword struct {
+	gorm.Model
+	RecipeID uint
+	Keyword  string
+}
+
+type Diet struct {
+	gorm.Model
+	RecipeID uint
+	DietName string
+}
+
+// Nutrient is one NutritionInformation value, keyed by its schema.org
+// property name (e.g. "proteinContent" → "7 g").
+type Nutrient struct {
+	gorm.Model
+	RecipeID uint
+	Name     string
+	Value    string
+}
+
 type Ingredients struct {
 	gorm.Model
 	RecipeID       uint
@@ -58,6 +63,7 @@ type Ingredients struct {
 	Amount         string
 	Unit           string
 	BaseName       string // core ingredient word(s) for highlighting (e.g. "thyme" from "dried thyme")
+	GroupName      string // ingredient group, e.g. "For the sauce"
 }
 
 type RecipeMetadata struct {
@@ -70,8 +76,24 @@ type RecipeMetadata struct {
 	TotalTime   time.Duration
 	Quantity    string
 	URL         string
+	ImageURL    string
+	Language    string
 	Favourite   bool
 	Rating      int8
+	Notes       string `gorm:"type:text"` // personal notes, markdown
+
+	// Nutrition of the whole recipe, estimated from its ingredients on save
+	Calories          float64
+	FatGrams          float64
+	SaturatedFatGrams float64
+	CarbohydrateGrams float64
+	SugarGrams        float64
+	FiberGrams        float64
+	ProteinGrams      float64
+	SodiumMilligrams  float64
+	NutritionServings float64
+	NutritionCounted  int
+	NutritionMissing  string // newline-separated ingredients left out of the estimate
 }
 
 type Instructions struct {
@@ -79,8 +101,96 @@ type Instructions struct {
 	RecipeID    uint
 	Step        int
 	Description string
+	Section     string // title of the section this step belongs to, e.g. "For the sauce"
+	Note        string // personal annotation, e.g. "use less salt next time"
+}
+
+type ShoppingList struct {
+	gorm.Model
+	Name string
+}
+
+// ShoppingListRecipe records a recipe added to a shopping list and the factor
+// its ingredients were scaled by, so the list can be rebuilt.
+type ShoppingListRecipe struct {
+	gorm.Model
+	ShoppingListID uint
+	RecipeID       uint
+	RecipeName     string
+	Scale          float64
 }
 
+type ShoppingListItem struct {
+	gorm.Model
+	ShoppingListID uint
+	Name           string
+	BaseName       string
+	Amount         string
+	Unit           string
+	Extra          string // amounts that could not be added up ("a pinch")
+	Aisle          string
+	Recipes        string // newline-separated names of the recipes needing the item
+	Checked        bool
+}
+
+// PantryItem is an ingredient on hand, used to find recipes that can be
+// cooked right now.
+type PantryItem struct {
+	gorm.Model
+	Name      string
+	Amount    string
+	Unit      string
+	ExpiresAt *time.Time
+}
+
+// MealPlanEntry is a recipe planned for a meal. Day is stored as YYYY-MM-DD
+// so entries stay on their day whatever the time zone.
+type MealPlanEntry struct {
+	gorm.Model
+	Day      string `gorm:"index"`
+	Slot     string
+	RecipeID uint // the recipe's name is read from recipes when listing the plan
+}
+
+// SavedSearch is a named filter query, picked by its slug on the command line
+type SavedSearch struct {
+	gorm.Model
+	Name  string
+	Slug  string `gorm:"uniqueIndex"`
+	Query string
+}
+
+// Collection is a hand-picked set of recipes, like "Thanksgiving 2026"
+type Collection struct {
+	gorm.Model
+	Name string
+	Slug string `gorm:"uniqueIndex"`
+}
+
+// CollectionRecipe puts a recipe in a collection. Position orders the
+// recipes within the collection.
+type CollectionRecipe struct {
+	gorm.Model
+	CollectionID uint `gorm:"index"`
+	RecipeID     uint `gorm:"index"`
+	Position     int
+}
+
+// CookEvent records a recipe being cooked. Day is stored as YYYY-MM-DD,
+// like MealPlanEntry.Day.
+type CookEvent struct {
+	gorm.Model
+	RecipeID uint   `gorm:"index"`
+	Day      string `gorm:"index"`
+	Servings float64
+	Rating   int8
+	Notes    string `gorm:"type:text"`
+	Tweaks   string `gorm:"type:text"`
+}
+
+// The session log tables are created by GetSessionLogMigrations, which
+// needs a migration for a new model or field the same way.
+
 type SessionHistory struct {
 	gorm.Model
 	Summary string `gorm:"type:text"`
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"gorm.io/gorm"
+)
+
+// PlanMeal puts a recipe on the meal plan for a day and slot, replacing
+// whatever was planned there, and returns the entry's ID.
+func (c *CookBook) PlanMeal(day time.Time, slot utils.MealSlot, recipeID uint) (uint, error) {
+	var name string
+	if err := c.conn.Model(&Recipe{}).Where("id = ?", recipeID).Pluck("recipe_name", &name).Error; err != nil {
+		return 0, err
+	}
+	if name == "" {
+		return 0, fmt.Errorf("recipe %d not found", recipeID)
+	}
+
+	entry := MealPlanEntry{Day: day.Format(utils.PlanDateLayout), Slot: string(slot), RecipeID: recipeID}
+	err := c.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(&MealPlanEntry{}, "day = ? AND slot = ?", entry.Day, entry.Slot).Error; err != nil {
+			return err
+		}
+		return tx.Create(&entry).Error
+	})
+	if err != nil {
+		slog.Error("Error planning meal", "day", entry.Day, "slot", slot, "error", err)
+		return 0, err
+	}
+
+	slog.Debug("Meal planned", "day", entry.Day, "slot", slot, "recipe", name)
+	return entry.ID, nil
+}
+
+// MealPlan returns the entries planned from one day up to (not including)
+// another, ordered by day and slot. Recipe names are read from the recipes,
+// so they follow renames.
+func (c *CookBook) MealPlan(from, to time.Time) ([]utils.MealPlanEntry, error) {
+	var rows []struct {
+		ID         uint
+		Day        string
+		Slot       string
+		RecipeID   uint
+		RecipeName string
+	}
+	err := c.conn.
+		Model(&MealPlanEntry{}).
+		Select("meal_plan_entries.id, meal_plan_entries.day, meal_plan_entries.slot, meal_plan_entries.recipe_id, recipes.recipe_name").
+		Joins("JOIN recipes ON recipes.id = meal_plan_entries.recipe_id AND recipes.deleted_at IS NULL").
+		Where("meal_plan_entries.day >= ? AND meal_plan_entries.day < ?", from.Format(utils.PlanDateLayout), to.Format(utils.PlanDateLayout)).
+		Order("meal_plan_entries.day").
+		Scan(&rows).Error
+	if err != nil {
+		slog.Error("Error getting meal plan", "error", err)
+		return nil, err
+	}
+
+	entries := make([]utils.MealPlanEntry, 0, len(rows))
+	for _, row := range rows {
+		day, err := time.ParseInLocation(utils.PlanDateLayout, row.Day, time.Local)
+		if err != nil {
+			slog.Warn("Skipping meal plan entry with invalid day", "id", row.ID, "day", row.Day)
+			continue
+		}
+		entries = append(entries, utils.MealPlanEntry{
+			ID:         row.ID,
+			Date:       day,
+			Slot:       utils.MealSlot(row.Slot),
+			RecipeID:   row.RecipeID,
+			RecipeName: row.RecipeName,
+		})
+	}
+	utils.SortMealPlan(entries)
+	return entries, nil
+}
+
+// DeleteMealPlanEntry removes one entry from the meal plan
+func (c *CookBook) DeleteMealPlanEntry(entryID uint) error {
+	if err := c.conn.Unscoped().Delete(&MealPlanEntry{}, entryID).Error; err != nil {
+		slog.Error("Error deleting meal plan entry", "id", entryID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ClearMealPlan removes the entries from one day up to (not including)
+// another, only those of slot unless it is empty. It returns how many
+// entries were removed.
+func (c *CookBook) ClearMealPlan(from, to time.Time, slot utils.MealSlot) (int64, error) {
+	query := c.conn.Unscoped().
+		Where("day >= ? AND day < ?", from.Format(utils.PlanDateLayout), to.Format(utils.PlanDateLayout))
+	if slot != "" {
+		query = query.Where("slot = ?", string(slot))
+	}
+
+	res := query.Delete(&MealPlanEntry{})
+	if res.Error != nil {
+		slog.Error("Error clearing meal plan", "error", res.Error)
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
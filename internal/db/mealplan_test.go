@@ -0,0 +1,48 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+)
+
+func TestMealPlanFollowsRecipes(t *testing.T) {
+	cookbook := newTestCookBook(t)
+	curryID := saveTestRecipe(t, cookbook, "Chicken Curry", 0)
+	soupID := saveTestRecipe(t, cookbook, "Tomato Soup", 0)
+
+	monday := utils.WeekStart(time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local))
+	if _, err := cookbook.PlanMeal(monday, utils.SlotDinner, curryID); err != nil {
+		t.Fatalf("PlanMeal: %v", err)
+	}
+	if _, err := cookbook.PlanMeal(monday.AddDate(0, 0, 1), utils.SlotLunch, soupID); err != nil {
+		t.Fatalf("PlanMeal: %v", err)
+	}
+
+	recipe, err := cookbook.GetFullRecipe(curryID)
+	if err != nil {
+		t.Fatalf("GetFullRecipe: %v", err)
+	}
+	recipe.RecipeName = "Thai Curry"
+	if err := cookbook.UpdateRecipe(recipe); err != nil {
+		t.Fatalf("UpdateRecipe: %v", err)
+	}
+	if err := cookbook.DeleteRecipe(soupID); err != nil {
+		t.Fatalf("DeleteRecipe: %v", err)
+	}
+
+	entries, err := cookbook.MealPlan(monday, monday.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("MealPlan: %v", err)
+	}
+	if len(entries) != 1 || entries[0].RecipeID != curryID || entries[0].RecipeName != "Thai Curry" {
+		t.Errorf("MealPlan = %+v, want only the renamed curry", entries)
+	}
+
+	var left int64
+	cookbook.conn.Model(&MealPlanEntry{}).Where("recipe_id = ?", soupID).Count(&left)
+	if left != 0 {
+		t.Errorf("%d meal plan entries left for the deleted recipe, want 0", left)
+	}
+}
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration is a single, ordered schema change. Up moves the schema from
+// Version-1 to Version; Down (optional) reverts it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaVersion records every migration applied to a database.
+type SchemaVersion struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// TableName pins the version table name so it never changes with GORM's pluralisation.
+func (SchemaVersion) TableName() string {
+	return "schema_version"
+}
+
+// MigrationStatus describes whether a known migration has been applied.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies versioned migrations to a single database.
+type Migrator struct {
+	conn       *gorm.DB
+	dbFile     string
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the given connection. dbFile is the path
+// of the SQLite file, used to write a backup before pending migrations run;
+// pass "" to skip backups.
+func NewMigrator(conn *gorm.DB, dbFile string, migrations []Migration) *Migrator {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Migrator{conn: conn, dbFile: dbFile, migrations: sorted}
+}
+
+// LatestVersion returns the highest known migration version.
+func (m *Migrator) LatestVersion() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// CurrentVersion returns the highest applied migration version, or 0 for a
+// database that has never been migrated.
+func (m *Migrator) CurrentVersion() (int, error) {
+	if !m.conn.Migrator().HasTable(&SchemaVersion{}) {
+		return 0, nil
+	}
+
+	var version int
+	if err := m.conn.Model(&SchemaVersion{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		slog.Error("Error reading schema version", "error", err)
+		return 0, err
+	}
+	return version, nil
+}
+
+// Status returns every known migration together with its applied state.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied := map[int]SchemaVersion{}
+	if m.conn.Migrator().HasTable(&SchemaVersion{}) {
+		var rows []SchemaVersion
+		if err := m.conn.Find(&rows).Error; err != nil {
+			slog.Error("Error reading applied migrations", "error", err)
+			return nil, err
+		}
+		for _, row := range rows {
+			applied[row.Version] = row
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		row, ok := applied[mig.Version]
+		statuses = append(statuses, MigrationStatus{
+			Version:   mig.Version,
+			Name:      mig.Name,
+			Applied:   ok,
+			AppliedAt: row.AppliedAt,
+		})
+	}
+	return statuses, nil
+}
+
+// MigrateLatest applies every pending migration.
+func (m *Migrator) MigrateLatest() error {
+	return m.MigrateTo(m.LatestVersion())
+}
+
+// MigrateTo moves the schema up or down to the target version. Each step runs
+// in its own transaction, and a backup of the database file is written before
+// the first step when there is existing data to protect.
+func (m *Migrator) MigrateTo(target int) error {
+	if target < 0 || target > m.LatestVersion() {
+		return fmt.Errorf("unknown schema version %d (latest is %d)", target, m.LatestVersion())
+	}
+
+	if err := m.conn.AutoMigrate(&SchemaVersion{}); err != nil {
+		slog.Error("Error creating schema version table", "error", err)
+		return err
+	}
+
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return err
+	}
+	if current == target {
+		slog.Debug("Schema is up to date", "version", current)
+		return nil
+	}
+
+	if err := m.backup(current); err != nil {
+		return err
+	}
+
+	if target > current {
+		for _, mig := range m.migrations {
+			if mig.Version <= current || mig.Version > target {
+				continue
+			}
+			if err := m.apply(mig); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mig := m.migrations[i]
+		if mig.Version > current || mig.Version <= target {
+			continue
+		}
+		if err := m.revert(mig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply runs a single Up step and records it.
+func (m *Migrator) apply(mig Migration) error {
+	slog.Info("Applying migration", "version", mig.Version, "name", mig.Name)
+	err := m.conn.Transaction(func(tx *gorm.DB) error {
+		if err := mig.Up(tx); err != nil {
+			return err
+		}
+		return tx.Create(&SchemaVersion{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		slog.Error("Migration failed", "version", mig.Version, "name", mig.Name, "error", err)
+		return fmt.Errorf("migration %d (%s) failed: %w", mig.Version, mig.Name, err)
+	}
+	return nil
+}
+
+// revert runs a single Down step and removes its record.
+func (m *Migrator) revert(mig Migration) error {
+	if mig.Down == nil {
+		return fmt.Errorf("migration %d (%s) cannot be reverted", mig.Version, mig.Name)
+	}
+
+	slog.Info("Reverting migration", "version", mig.Version, "name", mig.Name)
+	err := m.conn.Transaction(func(tx *gorm.DB) error {
+		if err := mig.Down(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&SchemaVersion{}, "version = ?", mig.Version).Error
+	})
+	if err != nil {
+		slog.Error("Reverting migration failed", "version", mig.Version, "name", mig.Name, "error", err)
+		return fmt.Errorf("reverting migration %d (%s) failed: %w", mig.Version, mig.Name, err)
+	}
+	return nil
+}
+
+// backup copies the database next to itself as <name>.v<version>-<timestamp>.bak.
+// Fresh databases without any user tables are not backed up.
+func (m *Migrator) backup(fromVersion int) error {
+	if m.dbFile == "" {
+		return nil
+	}
+
+	tables, err := m.conn.Migrator().GetTables()
+	if err != nil {
+		slog.Error("Error listing tables", "error", err)
+		return err
+	}
+	if len(tables) <= 1 {
+		return nil
+	}
+
+	ext := filepath.Ext(m.dbFile)
+	backupPath := fmt.Sprintf("%s.v%d-%s%s.bak",
+		strings.TrimSuffix(m.dbFile, ext), fromVersion, time.Now().Format("20060102-150405"), ext)
+	if _, err := os.Stat(backupPath); err == nil {
+		return nil
+	}
+
+	if err := m.conn.Exec("VACUUM INTO ?", backupPath).Error; err != nil {
+		slog.Error("Error backing up database", "path", backupPath, "error", err)
+		return fmt.Errorf("failed to back up database before migrating: %w", err)
+	}
+
+	slog.Info("Database backed up before migration", "path", backupPath, "version", fromVersion)
+	return nil
+}
@@ -0,0 +1,276 @@
+package db
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type migrateTestNote struct {
+	ID   uint
+	Body string
+}
+
+func openTestDB(t *testing.T) (*gorm.DB, string) {
+	t.Helper()
+	dbFile := filepath.Join(t.TempDir(), "test.db")
+	conn, err := gorm.Open(sqlite.Open(dbFile), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	return conn, dbFile
+}
+
+func testMigrations() []Migration {
+	return []Migration{
+		{
+			Version: 2,
+			Name:    "add note body index",
+			Up: func(tx *gorm.DB) error {
+				return tx.Exec("CREATE INDEX idx_notes_body ON migrate_test_notes(body)").Error
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Exec("DROP INDEX idx_notes_body").Error
+			},
+		},
+		{
+			Version: 1,
+			Name:    "create notes",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&migrateTestNote{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&migrateTestNote{})
+			},
+		},
+	}
+}
+
+func TestMigrateFreshDatabase(t *testing.T) {
+	conn, dbFile := openTestDB(t)
+	m := NewMigrator(conn, dbFile, testMigrations())
+
+	if got := m.LatestVersion(); got != 2 {
+		t.Fatalf("LatestVersion() = %d, want 2", got)
+	}
+	if err := m.MigrateLatest(); err != nil {
+		t.Fatalf("MigrateLatest: %v", err)
+	}
+
+	version, err := m.CurrentVersion()
+	if err != nil || version != 2 {
+		t.Fatalf("CurrentVersion() = %d, %v; want 2", version, err)
+	}
+
+	backups, _ := filepath.Glob(filepath.Join(filepath.Dir(dbFile), "*.bak"))
+	if len(backups) != 0 {
+		t.Errorf("fresh database should not be backed up, got %v", backups)
+	}
+
+	// Running again is a no-op.
+	if err := m.MigrateLatest(); err != nil {
+		t.Fatalf("second MigrateLatest: %v", err)
+	}
+}
+
+func TestMigrateBacksUpAndReverts(t *testing.T) {
+	conn, dbFile := openTestDB(t)
+	m := NewMigrator(conn, dbFile, testMigrations())
+
+	if err := m.MigrateTo(1); err != nil {
+		t.Fatalf("MigrateTo(1): %v", err)
+	}
+	if err := conn.Create(&migrateTestNote{Body: "use less salt"}).Error; err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	if err := m.MigrateTo(2); err != nil {
+		t.Fatalf("MigrateTo(2): %v", err)
+	}
+	backups, _ := filepath.Glob(filepath.Join(filepath.Dir(dbFile), "test.v1-*.db.bak"))
+	if len(backups) != 1 {
+		t.Fatalf("expected one backup before migrating from v1, got %v", backups)
+	}
+
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	for _, s := range statuses {
+		if !s.Applied {
+			t.Errorf("migration %d should be applied", s.Version)
+		}
+	}
+
+	if err := m.MigrateTo(0); err != nil {
+		t.Fatalf("MigrateTo(0): %v", err)
+	}
+	if conn.Migrator().HasTable(&migrateTestNote{}) {
+		t.Error("notes table should be dropped after reverting to version 0")
+	}
+	if version, _ := m.CurrentVersion(); version != 0 {
+		t.Errorf("CurrentVersion() = %d, want 0", version)
+	}
+
+	if err := m.MigrateTo(5); err == nil {
+		t.Error("MigrateTo(5) should fail for an unknown version")
+	}
+}
+
+// schemaOf lists the columns of every table of a database, leaving out the
+// migration bookkeeping and SQLite's internal tables
+func schemaOf(t *testing.T, conn *gorm.DB) map[string][]string {
+	t.Helper()
+	tables, err := conn.Migrator().GetTables()
+	if err != nil {
+		t.Fatalf("GetTables: %v", err)
+	}
+	schema := make(map[string][]string)
+	for _, table := range tables {
+		if table == "schema_version" || strings.HasPrefix(table, "sqlite_") || strings.HasPrefix(table, "recipe_search") {
+			continue
+		}
+		columns, err := conn.Migrator().ColumnTypes(table)
+		if err != nil {
+			t.Fatalf("ColumnTypes(%s): %v", table, err)
+		}
+		names := make([]string, len(columns))
+		for i, column := range columns {
+			names[i] = column.Name()
+		}
+		slices.Sort(names)
+		schema[table] = names
+	}
+	return schema
+}
+
+func TestCookbookBaselineIsFrozen(t *testing.T) {
+	conn, dbFile := openTestDB(t)
+	m := NewMigrator(conn, dbFile, GetCookbookMigrations())
+
+	if err := m.MigrateTo(1); err != nil {
+		t.Fatalf("MigrateTo(1): %v", err)
+	}
+	baseline := schemaOf(t, conn)
+	want := map[string][]string{
+		"recipes":         {"created_at", "deleted_at", "id", "recipe_name", "updated_at"},
+		"categories":      {"category_name", "created_at", "deleted_at", "id", "recipe_id", "updated_at"},
+		"cuisines":        {"created_at", "cuisine_name", "deleted_at", "id", "recipe_id", "updated_at"},
+		"recipe_metadata": {"author", "cook_time", "created_at", "deleted_at", "description", "favourite", "id", "prep_time", "quantity", "rating", "recipe_id", "total_time", "updated_at", "url"},
+		"instructions":    {"created_at", "deleted_at", "description", "id", "recipe_id", "step", "updated_at"},
+		"ingredients":     {"amount", "base_name", "created_at", "deleted_at", "detail", "id", "ingredient_name", "recipe_id", "unit", "updated_at"},
+	}
+	if !reflect.DeepEqual(baseline, want) {
+		t.Errorf("schema at version 1 = %v, want %v", baseline, want)
+	}
+
+	if err := m.MigrateLatest(); err != nil {
+		t.Fatalf("MigrateLatest: %v", err)
+	}
+	latest := schemaOf(t, conn)
+
+	// Down to the baseline and back gives the same schema as going up from it
+	if err := m.MigrateTo(1); err != nil {
+		t.Fatalf("MigrateTo(1) from latest: %v", err)
+	}
+	if got := schemaOf(t, conn); !reflect.DeepEqual(got, baseline) {
+		t.Errorf("schema after reverting to version 1 = %v, want %v", got, baseline)
+	}
+	if err := m.MigrateLatest(); err != nil {
+		t.Fatalf("MigrateLatest again: %v", err)
+	}
+	if got := schemaOf(t, conn); !reflect.DeepEqual(got, latest) {
+		t.Errorf("schema after migrating up again = %v, want %v", got, latest)
+	}
+}
+
+// TestCookbookSchemaAtEveryVersion upgrades a cookbook one step at a time,
+// then reverts it one step at a time: each version must have the same
+// schema both ways, so no migration creates columns of a later one.
+func TestCookbookSchemaAtEveryVersion(t *testing.T) {
+	conn, dbFile := openTestDB(t)
+	migrations := GetCookbookMigrations()
+	m := NewMigrator(conn, dbFile, migrations)
+
+	schemas := make(map[int]map[string][]string)
+	for _, migration := range migrations {
+		if err := m.MigrateTo(migration.Version); err != nil {
+			t.Fatalf("MigrateTo(%d): %v", migration.Version, err)
+		}
+		schemas[migration.Version] = schemaOf(t, conn)
+	}
+
+	for i := len(migrations) - 2; i >= 0; i-- {
+		version := migrations[i].Version
+		if err := m.MigrateTo(version); err != nil {
+			t.Fatalf("MigrateTo(%d) going down: %v", version, err)
+		}
+		if got := schemaOf(t, conn); !reflect.DeepEqual(got, schemas[version]) {
+			t.Errorf("schema at version %d going down = %v, want %v as going up", version, got, schemas[version])
+		}
+	}
+}
+
+func TestCookbookMigrationsCoverModels(t *testing.T) {
+	conn, dbFile := openTestDB(t)
+	if err := NewMigrator(conn, dbFile, GetCookbookMigrations()).MigrateLatest(); err != nil {
+		t.Fatalf("MigrateLatest: %v", err)
+	}
+
+	models := []any{
+		&Recipe{}, &Category{}, &Cuisine{}, &Keyword{}, &Diet{}, &Nutrient{},
+		&RecipeMetadata{}, &Instructions{}, &Ingredients{},
+		&ShoppingList{}, &ShoppingListRecipe{}, &ShoppingListItem{},
+		&PantryItem{}, &MealPlanEntry{}, &SavedSearch{},
+		&Collection{}, &CollectionRecipe{}, &CookEvent{},
+	}
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: conn}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("parse %T: %v", model, err)
+		}
+		if !conn.Migrator().HasTable(model) {
+			t.Errorf("no migration creates the table of %T", model)
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !conn.Migrator().HasColumn(model, field.DBName) {
+				t.Errorf("no migration adds %T.%s", model, field.Name)
+			}
+		}
+	}
+}
+
+func TestSessionLogBaselineIsFrozen(t *testing.T) {
+	conn, dbFile := openTestDB(t)
+	if err := NewMigrator(conn, dbFile, GetSessionLogMigrations()).MigrateTo(1); err != nil {
+		t.Fatalf("MigrateTo(1): %v", err)
+	}
+	want := map[string][]string{
+		"session_histories": {"created_at", "deleted_at", "id", "summary", "updated_at"},
+		"session_messages":  {"created_at", "deleted_at", "id", "input_tokens", "message", "model_name", "output_tokens", "role", "session_id", "total_tokens", "updated_at"},
+	}
+	if got := schemaOf(t, conn); !reflect.DeepEqual(got, want) {
+		t.Errorf("schema at version 1 = %v, want %v", got, want)
+	}
+
+	if err := NewMigrator(conn, dbFile, GetSessionLogMigrations()).MigrateLatest(); err != nil {
+		t.Fatalf("MigrateLatest: %v", err)
+	}
+	for _, model := range []any{&SessionHistory{}, &SessionMessage{}} {
+		stmt := &gorm.Statement{DB: conn}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("parse %T: %v", model, err)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !conn.Migrator().HasColumn(model, field.DBName) {
+				t.Errorf("no migration adds %T.%s", model, field.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package db
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	"github.com/GarroshIcecream/yummy/internal/log"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GetCookbookMigrations returns the ordered schema migrations for cookbook.db.
+// Append new steps at the end; never renumber or edit an applied migration.
+// Each step creates only its own tables and columns, new tables from the
+// frozen models of migrations_baseline.go, so a database upgraded step by
+// step and a fresh one have the same schema at every version.
+func GetCookbookMigrations() []Migration {
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "baseline schema",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(baselineCookbookModels()...)
+			},
+		},
+		{
+			Version: 2,
+			Name:    "recipe image, language, keywords, diets, nutrients and ingredient groups",
+			Up: func(tx *gorm.DB) error {
+				if err := addColumns(tx, &RecipeMetadata{}, "ImageURL", "Language"); err != nil {
+					return err
+				}
+				if err := addColumns(tx, &Ingredients{}, "GroupName"); err != nil {
+					return err
+				}
+				return tx.AutoMigrate(&keywordV2{}, &dietV2{}, &nutrientV2{})
+			},
+			Down: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropTable(&keywordV2{}, &dietV2{}, &nutrientV2{}); err != nil {
+					return err
+				}
+				for _, column := range []string{"ImageURL", "Language"} {
+					if err := tx.Migrator().DropColumn(&RecipeMetadata{}, column); err != nil {
+						return err
+					}
+				}
+				return tx.Migrator().DropColumn(&Ingredients{}, "GroupName")
+			},
+		},
+		{
+			Version: 3,
+			Name:    "instruction sections",
+			Up: func(tx *gorm.DB) error {
+				return addColumns(tx, &Instructions{}, "Section")
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropColumn(&Instructions{}, "Section")
+			},
+		},
+		{
+			Version: 4,
+			Name:    "shopping lists",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&shoppingListV4{}, &shoppingListRecipeV4{}, &shoppingListItemV4{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&shoppingListV4{}, &shoppingListRecipeV4{}, &shoppingListItemV4{})
+			},
+		},
+		{
+			Version: 5,
+			Name:    "pantry",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&pantryItemV5{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&pantryItemV5{})
+			},
+		},
+		{
+			Version: 6,
+			Name:    "meal plan",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&mealPlanEntryV6{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&mealPlanEntryV6{})
+			},
+		},
+		{
+			Version: 7,
+			Name:    "estimated nutrition",
+			Up: func(tx *gorm.DB) error {
+				if err := addColumns(tx, &RecipeMetadata{}, nutritionColumns...); err != nil {
+					return err
+				}
+				return backfillNutrition(tx)
+			},
+			Down: func(tx *gorm.DB) error {
+				for _, column := range nutritionColumns {
+					if err := tx.Migrator().DropColumn(&RecipeMetadata{}, column); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Version: 8,
+			Name:    "full-text search",
+			Up: func(tx *gorm.DB) error {
+				if err := tx.Exec(createRecipeSearchTable).Error; err != nil {
+					return err
+				}
+				return reindexRecipes(tx)
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Exec("DROP TABLE IF EXISTS recipe_search").Error
+			},
+		},
+		{
+			Version: 9,
+			Name:    "saved searches",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&savedSearchV9{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&savedSearchV9{})
+			},
+		},
+		{
+			Version: 10,
+			Name:    "collections",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&collectionV10{}, &collectionRecipeV10{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&collectionRecipeV10{}, &collectionV10{})
+			},
+		},
+		{
+			Version: 11,
+			Name:    "cook log",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&cookEventV11{})
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&cookEventV11{})
+			},
+		},
+		{
+			Version: 12,
+			Name:    "recipe notes",
+			Up: func(tx *gorm.DB) error {
+				if err := addColumns(tx, &RecipeMetadata{}, "Notes"); err != nil {
+					return err
+				}
+				return addColumns(tx, &Instructions{}, "Note")
+			},
+			Down: func(tx *gorm.DB) error {
+				if err := tx.Migrator().DropColumn(&Instructions{}, "Note"); err != nil {
+					return err
+				}
+				return tx.Migrator().DropColumn(&RecipeMetadata{}, "Notes")
+			},
+		},
+		{
+			Version: 13,
+			Name:    "shopping item extras",
+			Up: func(tx *gorm.DB) error {
+				return addColumns(tx, &ShoppingListItem{}, "Extra")
+			},
+			Down: func(tx *gorm.DB) error {
+				return tx.Migrator().DropColumn(&ShoppingListItem{}, "Extra")
+			},
+		},
+		{
+			Version: 14,
+			Name:    "meal plan names from recipes",
+			Up: func(tx *gorm.DB) error {
+				// Entries of recipes deleted before they were removed with them
+				if err := tx.Exec("DELETE FROM meal_plan_entries WHERE recipe_id NOT IN (SELECT id FROM recipes WHERE deleted_at IS NULL)").Error; err != nil {
+					return err
+				}
+				if !tx.Migrator().HasColumn(&mealPlanEntryV6{}, "RecipeName") {
+					return nil
+				}
+				return tx.Migrator().DropColumn(&mealPlanEntryV6{}, "RecipeName")
+			},
+			Down: func(tx *gorm.DB) error {
+				if err := addColumns(tx, &mealPlanEntryV6{}, "RecipeName"); err != nil {
+					return err
+				}
+				return tx.Exec("UPDATE meal_plan_entries SET recipe_name = (SELECT recipe_name FROM recipes WHERE recipes.id = meal_plan_entries.recipe_id)").Error
+			},
+		},
+	}
+}
+
+// GetSessionLogMigrations returns the ordered schema migrations for session_log.db.
+func GetSessionLogMigrations() []Migration {
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "baseline schema",
+			Up: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(baselineSessionLogModels()...)
+			},
+		},
+	}
+}
+
+// NewCookbookMigrator opens cookbook.db without applying any migrations.
+func NewCookbookMigrator(dataDir string, config *config.DatabaseConfig, opts ...gorm.Option) (*Migrator, error) {
+	conn, dbFile, err := openDatabase(dataDir, config.RecipeDBName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewMigrator(conn, dbFile, GetCookbookMigrations()), nil
+}
+
+// NewSessionLogMigrator opens session_log.db without applying any migrations.
+func NewSessionLogMigrator(dataDir string, config *config.DatabaseConfig, opts ...gorm.Option) (*Migrator, error) {
+	conn, dbFile, err := openDatabase(dataDir, config.SessionLogDBName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewMigrator(conn, dbFile, GetSessionLogMigrations()), nil
+}
+
+// openDatabase opens (creating if needed) <dataDir>/db/<dbName> and returns
+// the connection together with the resolved file path.
+func openDatabase(dataDir string, dbName string, opts ...gorm.Option) (*gorm.DB, string, error) {
+	dbDir := filepath.Join(dataDir, "db")
+	if err := os.MkdirAll(dbDir, 0755); err != nil {
+		slog.Error("Failed to create database directory", "dir", dbDir, "error", err)
+		return nil, "", err
+	}
+
+	dbPath := filepath.Join(dbDir, dbName)
+	_, err := os.Stat(dbPath)
+	if err != nil {
+		slog.Info("Database does not exist, creating new database...", "dbPath", dbPath, "error", err)
+	}
+
+	dbCon, err := gorm.Open(sqlite.Open(dbPath), opts...)
+	if err != nil {
+		slog.Error("Error opening database", "dbPath", dbPath, "error", err)
+		return nil, "", err
+	}
+
+	// Configure GORM to use slog logger (logs to file via slog setup, not stdout)
+	dbCon.Logger = log.NewGormLogger(200*time.Millisecond, true, gormlogger.Info)
+
+	return dbCon, dbPath, nil
+}
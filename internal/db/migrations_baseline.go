@@ -0,0 +1,260 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Frozen copies of the models as the migrations created them: the baseline
+// schema of migration 1, as the cookbook was before versioned migrations,
+// and each table a later migration added. A migration creates its tables
+// from these rather than from today's models, so the schema at a version is
+// the same whatever the models look like now. Never edit them: add a
+// migration for each new table or column instead.
+
+type baselineRecipe struct {
+	gorm.Model
+	RecipeName string
+}
+
+func (baselineRecipe) TableName() string { return "recipes" }
+
+type baselineCategory struct {
+	gorm.Model
+	RecipeID     uint
+	CategoryName string
+}
+
+func (baselineCategory) TableName() string { return "categories" }
+
+type baselineCuisine struct {
+	gorm.Model
+	RecipeID    uint
+	CuisineName string
+}
+
+func (baselineCuisine) TableName() string { return "cuisines" }
+
+type baselineRecipeMetadata struct {
+	gorm.Model
+	RecipeID    uint
+	Description string
+	Author      string
+	CookTime    time.Duration
+	PrepTime    time.Duration
+	TotalTime   time.Duration
+	Quantity    string
+	URL         string
+	Favourite   bool
+	Rating      int8
+}
+
+func (baselineRecipeMetadata) TableName() string { return "recipe_metadata" }
+
+type baselineInstructions struct {
+	gorm.Model
+	RecipeID    uint
+	Step        int
+	Description string
+}
+
+func (baselineInstructions) TableName() string { return "instructions" }
+
+type baselineIngredients struct {
+	gorm.Model
+	RecipeID       uint
+	IngredientName string
+	Detail         string
+	Amount         string
+	Unit           string
+	BaseName       string
+}
+
+func (baselineIngredients) TableName() string { return "ingredients" }
+
+// baselineCookbookModels returns the tables of migration 1
+func baselineCookbookModels() []any {
+	return []any{
+		&baselineRecipe{},
+		&baselineCategory{},
+		&baselineCuisine{},
+		&baselineRecipeMetadata{},
+		&baselineInstructions{},
+		&baselineIngredients{},
+	}
+}
+
+// Tables of migration 2
+
+type keywordV2 struct {
+	gorm.Model
+	RecipeID uint
+	Keyword  string
+}
+
+func (keywordV2) TableName() string { return "keywords" }
+
+type dietV2 struct {
+	gorm.Model
+	RecipeID uint
+	DietName string
+}
+
+func (dietV2) TableName() string { return "diets" }
+
+type nutrientV2 struct {
+	gorm.Model
+	RecipeID uint
+	Name     string
+	Value    string
+}
+
+func (nutrientV2) TableName() string { return "nutrients" }
+
+// Tables of migration 4
+
+type shoppingListV4 struct {
+	gorm.Model
+	Name string
+}
+
+func (shoppingListV4) TableName() string { return "shopping_lists" }
+
+type shoppingListRecipeV4 struct {
+	gorm.Model
+	ShoppingListID uint
+	RecipeID       uint
+	RecipeName     string
+	Scale          float64
+}
+
+func (shoppingListRecipeV4) TableName() string { return "shopping_list_recipes" }
+
+type shoppingListItemV4 struct {
+	gorm.Model
+	ShoppingListID uint
+	Name           string
+	BaseName       string
+	Amount         string
+	Unit           string
+	Aisle          string
+	Recipes        string
+	Checked        bool
+}
+
+func (shoppingListItemV4) TableName() string { return "shopping_list_items" }
+
+// Table of migration 5
+
+type pantryItemV5 struct {
+	gorm.Model
+	Name      string
+	Amount    string
+	Unit      string
+	ExpiresAt *time.Time
+}
+
+func (pantryItemV5) TableName() string { return "pantry_items" }
+
+// Table of migration 6, which kept a copy of the recipe's name until
+// migration 14
+
+type mealPlanEntryV6 struct {
+	gorm.Model
+	Day        string `gorm:"index"`
+	Slot       string
+	RecipeID   uint
+	RecipeName string
+}
+
+func (mealPlanEntryV6) TableName() string { return "meal_plan_entries" }
+
+// Table of migration 9
+
+type savedSearchV9 struct {
+	gorm.Model
+	Name  string
+	Slug  string `gorm:"uniqueIndex"`
+	Query string
+}
+
+func (savedSearchV9) TableName() string { return "saved_searches" }
+
+// Tables of migration 10
+
+type collectionV10 struct {
+	gorm.Model
+	Name string
+	Slug string `gorm:"uniqueIndex"`
+}
+
+func (collectionV10) TableName() string { return "collections" }
+
+type collectionRecipeV10 struct {
+	gorm.Model
+	CollectionID uint `gorm:"index"`
+	RecipeID     uint `gorm:"index"`
+	Position     int
+}
+
+func (collectionRecipeV10) TableName() string { return "collection_recipes" }
+
+// Table of migration 11
+
+type cookEventV11 struct {
+	gorm.Model
+	RecipeID uint   `gorm:"index"`
+	Day      string `gorm:"index"`
+	Servings float64
+	Rating   int8
+	Notes    string `gorm:"type:text"`
+	Tweaks   string `gorm:"type:text"`
+}
+
+func (cookEventV11) TableName() string { return "cook_events" }
+
+// The baseline schema of session_log.db
+
+type baselineSessionHistory struct {
+	gorm.Model
+	Summary string `gorm:"type:text"`
+}
+
+func (baselineSessionHistory) TableName() string { return "session_histories" }
+
+type baselineSessionMessage struct {
+	gorm.Model
+	SessionID    uint
+	Message      string
+	Role         string
+	ModelName    string
+	InputTokens  int
+	OutputTokens int
+	TotalTokens  int
+}
+
+func (baselineSessionMessage) TableName() string { return "session_messages" }
+
+// baselineSessionLogModels returns the tables of session log migration 1
+func baselineSessionLogModels() []any {
+	return []any{
+		&baselineSessionHistory{},
+		&baselineSessionMessage{},
+	}
+}
+
+// addColumns adds the columns of the named fields of model that its table
+// does not have yet. Migrations use it rather than AutoMigrate on a model
+// that already has a table, so each adds only its own columns.
+func addColumns(tx *gorm.DB, model any, fields ...string) error {
+	for _, field := range fields {
+		if tx.Migrator().HasColumn(model, field) {
+			continue
+		}
+		if err := tx.Migrator().AddColumn(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/nutrition"
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"gorm.io/gorm"
+)
+
+// nutritionColumns are the RecipeMetadata fields holding the estimated
+// nutrition. They are selected explicitly on update so zeros are written too.
+var nutritionColumns = []string{
+	"Calories", "FatGrams", "SaturatedFatGrams", "CarbohydrateGrams", "SugarGrams",
+	"FiberGrams", "ProteinGrams", "SodiumMilligrams", "NutritionServings",
+	"NutritionCounted", "NutritionMissing",
+}
+
+// setNutrition stores an estimate in the metadata's nutrition columns
+func (m *RecipeMetadata) setNutrition(e utils.NutritionEstimate) {
+	m.Calories = e.Total.Calories
+	m.FatGrams = e.Total.Fat
+	m.SaturatedFatGrams = e.Total.SaturatedFat
+	m.CarbohydrateGrams = e.Total.Carbohydrates
+	m.SugarGrams = e.Total.Sugar
+	m.FiberGrams = e.Total.Fiber
+	m.ProteinGrams = e.Total.Protein
+	m.SodiumMilligrams = e.Total.Sodium
+	m.NutritionServings = e.Servings
+	m.NutritionCounted = e.Counted
+	m.NutritionMissing = strings.Join(e.Missing, "\n")
+}
+
+// nutritionEstimate reads the estimate back from the metadata's nutrition columns
+func (m RecipeMetadata) nutritionEstimate() utils.NutritionEstimate {
+	var missing []string
+	if m.NutritionMissing != "" {
+		missing = strings.Split(m.NutritionMissing, "\n")
+	}
+	return utils.NutritionEstimate{
+		Total: nutrition.Facts{
+			Calories:      m.Calories,
+			Fat:           m.FatGrams,
+			SaturatedFat:  m.SaturatedFatGrams,
+			Carbohydrates: m.CarbohydrateGrams,
+			Sugar:         m.SugarGrams,
+			Fiber:         m.FiberGrams,
+			Protein:       m.ProteinGrams,
+			Sodium:        m.SodiumMilligrams,
+		},
+		Servings: m.NutritionServings,
+		Counted:  m.NutritionCounted,
+		Missing:  missing,
+	}
+}
+
+// updateNutrition recomputes the estimated nutrition of a recipe
+func updateNutrition(tx *gorm.DB, recipeID uint, ingredients []utils.Ingredient, yield string) error {
+	var metadata RecipeMetadata
+	metadata.setNutrition(utils.EstimateNutrition(ingredients, yield))
+	err := tx.Model(&RecipeMetadata{}).
+		Where("recipe_id = ?", recipeID).
+		Select(nutritionColumns).
+		Updates(&metadata).Error
+	if err != nil {
+		slog.Error("Error updating estimated nutrition", "id", recipeID, "error", err)
+	}
+	return err
+}
+
+// backfillNutrition estimates the nutrition of recipes saved before it was
+// computed on save. It only reads tables that exist since the baseline.
+func backfillNutrition(tx *gorm.DB) error {
+	var rows []RecipeMetadata
+	if err := tx.Select("recipe_id", "quantity").Find(&rows).Error; err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		var ingredients []Ingredients
+		if err := tx.Where("recipe_id = ?", row.RecipeID).Order("id").Find(&ingredients).Error; err != nil {
+			return err
+		}
+		if err := updateNutrition(tx, row.RecipeID, toIngredients(ingredients), row.Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toIngredients converts stored ingredient rows into utils ingredients
+func toIngredients(rows []Ingredients) []utils.Ingredient {
+	ingredients := make([]utils.Ingredient, len(rows))
+	for i, ing := range rows {
+		ingredients[i] = utils.Ingredient{
+			Name:     ing.IngredientName,
+			Details:  ing.Detail,
+			Amount:   ing.Amount,
+			Unit:     ing.Unit,
+			BaseName: ing.BaseName,
+			Group:    ing.GroupName,
+		}
+	}
+	return ingredients
+}
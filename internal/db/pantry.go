@@ -0,0 +1,111 @@
+package db
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"gorm.io/gorm"
+)
+
+// PantryItems returns everything in the pantry, ordered by name
+func (c *CookBook) PantryItems() ([]utils.PantryItem, error) {
+	var rows []PantryItem
+	if err := c.conn.Order("LOWER(name)").Find(&rows).Error; err != nil {
+		slog.Error("Error getting pantry items", "error", err)
+		return nil, err
+	}
+
+	items := make([]utils.PantryItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, utils.PantryItem{
+			ID:        row.ID,
+			Name:      row.Name,
+			Amount:    row.Amount,
+			Unit:      row.Unit,
+			ExpiresAt: row.ExpiresAt,
+		})
+	}
+	return items, nil
+}
+
+// AddPantryItem stores an item in the pantry and returns its ID. An item
+// with the same name (ignoring case) is replaced rather than duplicated.
+func (c *CookBook) AddPantryItem(item utils.PantryItem) (uint, error) {
+	var row PantryItem
+	err := c.conn.Where("LOWER(name) = ?", strings.ToLower(strings.TrimSpace(item.Name))).First(&row).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		slog.Error("Error looking up pantry item", "error", err)
+		return 0, err
+	}
+
+	row.Name = strings.TrimSpace(item.Name)
+	row.Amount = item.Amount
+	row.Unit = item.Unit
+	row.ExpiresAt = item.ExpiresAt
+	if err := c.conn.Save(&row).Error; err != nil {
+		slog.Error("Error saving pantry item", "error", err)
+		return 0, err
+	}
+
+	slog.Debug("Pantry item saved", "id", row.ID, "name", row.Name)
+	return row.ID, nil
+}
+
+// UpdatePantryItem overwrites the pantry item with item.ID
+func (c *CookBook) UpdatePantryItem(item utils.PantryItem) error {
+	err := c.conn.Model(&PantryItem{}).Where("id = ?", item.ID).Updates(map[string]any{
+		"name":       strings.TrimSpace(item.Name),
+		"amount":     item.Amount,
+		"unit":       item.Unit,
+		"expires_at": item.ExpiresAt,
+	}).Error
+	if err != nil {
+		slog.Error("Error updating pantry item", "id", item.ID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// DeletePantryItem removes an item from the pantry
+func (c *CookBook) DeletePantryItem(itemID uint) error {
+	if err := c.conn.Unscoped().Delete(&PantryItem{}, itemID).Error; err != nil {
+		slog.Error("Error deleting pantry item", "id", itemID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// RankRecipesByPantry ranks every recipe by how much of its ingredient list
+// the pantry covers (see utils.MatchPantry).
+func (c *CookBook) RankRecipesByPantry() ([]utils.PantryMatch, error) {
+	pantry, err := c.PantryItems()
+	if err != nil {
+		return nil, err
+	}
+
+	recipes, err := c.AllRecipes()
+	if err != nil {
+		return nil, err
+	}
+
+	var ingredients []Ingredients
+	if err := c.conn.Select("recipe_id", "ingredient_name", "base_name").Order("id").Find(&ingredients).Error; err != nil {
+		slog.Error("Error fetching ingredients for pantry matching", "error", err)
+		return nil, err
+	}
+	byRecipe := make(map[uint][]utils.Ingredient)
+	for _, ing := range ingredients {
+		byRecipe[ing.RecipeID] = append(byRecipe[ing.RecipeID], utils.Ingredient{
+			Name:     ing.IngredientName,
+			BaseName: ing.BaseName,
+		})
+	}
+	for i := range recipes {
+		recipes[i].Metadata.Ingredients = byRecipe[recipes[i].RecipeID]
+	}
+
+	return utils.MatchPantry(pantry, recipes, time.Now()), nil
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"gorm.io/gorm"
+)
+
+// SavedSearches returns every saved search, ordered by name
+func (c *CookBook) SavedSearches() ([]utils.SavedSearch, error) {
+	var rows []SavedSearch
+	if err := c.conn.Order("name").Find(&rows).Error; err != nil {
+		slog.Error("Error fetching saved searches", "error", err)
+		return nil, err
+	}
+
+	searches := make([]utils.SavedSearch, len(rows))
+	for i, row := range rows {
+		searches[i] = row.toSavedSearch()
+	}
+	return searches, nil
+}
+
+// SaveSearch saves a filter query under a name and returns its ID. Saving
+// again under the same name replaces the query; the name of a collection
+// cannot be used.
+func (c *CookBook) SaveSearch(name string, query string) (uint, error) {
+	name, query = strings.TrimSpace(name), strings.TrimSpace(query)
+	slug := utils.CollectionSlug(name)
+	if slug == "" {
+		return 0, fmt.Errorf("a saved search needs a name")
+	}
+	if _, err := utils.ParseQuery(query); err != nil {
+		return 0, fmt.Errorf("invalid query: %w", err)
+	}
+
+	var row SavedSearch
+	err := c.conn.Transaction(func(tx *gorm.DB) error {
+		if owner, err := slugOwner(tx, slug); err != nil {
+			return err
+		} else if owner == "collection" {
+			return fmt.Errorf("a collection named %q already exists", name)
+		}
+		if err := tx.Where("slug = ?", slug).Limit(1).Find(&row).Error; err != nil {
+			return err
+		}
+		row.Name, row.Slug, row.Query = name, slug, query
+		return tx.Save(&row).Error
+	})
+	if err != nil {
+		slog.Error("Error saving search", "name", name, "error", err)
+		return 0, err
+	}
+
+	slog.Debug("Search saved", "name", name, "query", query)
+	return row.ID, nil
+}
+
+// SavedSearchBySlug finds a saved search by its slug or name
+func (c *CookBook) SavedSearchBySlug(slugOrName string) (utils.SavedSearch, error) {
+	var row SavedSearch
+	err := c.conn.Where("slug = ?", utils.CollectionSlug(slugOrName)).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.SavedSearch{}, notFoundError{fmt.Sprintf("no saved search named %q", slugOrName)}
+	}
+	if err != nil {
+		slog.Error("Error fetching saved search", "slug", slugOrName, "error", err)
+		return utils.SavedSearch{}, err
+	}
+	return row.toSavedSearch(), nil
+}
+
+// DeleteSavedSearch removes a saved search
+func (c *CookBook) DeleteSavedSearch(searchID uint) error {
+	if err := c.conn.Unscoped().Delete(&SavedSearch{}, searchID).Error; err != nil {
+		slog.Error("Error deleting saved search", "id", searchID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// SavedSearchRecipes returns the recipes a saved search currently matches,
+// best match first
+func (c *CookBook) SavedSearchRecipes(search utils.SavedSearch) ([]utils.RecipeRaw, error) {
+	query, err := utils.ParseQuery(search.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query of %s: %w", search.Name, err)
+	}
+	results, err := c.SearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	recipes := make([]utils.RecipeRaw, len(results))
+	for i, result := range results {
+		recipes[i] = result.Recipe
+	}
+	return recipes, nil
+}
+
+func (s SavedSearch) toSavedSearch() utils.SavedSearch {
+	return utils.SavedSearch{ID: s.ID, Name: s.Name, Slug: s.Slug, Query: s.Query}
+}
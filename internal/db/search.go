@@ -0,0 +1,462 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"gorm.io/gorm"
+)
+
+// The recipe_search FTS5 table indexes the searchable text of every recipe,
+// with the recipe ID as its rowid. It is kept in sync by indexRecipe and
+// unindexRecipe whenever a recipe is saved, updated or deleted.
+const createRecipeSearchTable = `CREATE VIRTUAL TABLE IF NOT EXISTS recipe_search USING fts5(
+	name, description, author, ingredients, instructions, categories, url,
+	tokenize = 'porter unicode61 remove_diacritics 2'
+)`
+
+// SearchFields are the columns of the search index, in table order.
+var SearchFields = []string{"name", "description", "author", "ingredients", "instructions", "categories", "url"}
+
+// searchRank weighs matches per column (in SearchFields order): a hit in the
+// name counts far more than one in the instructions.
+const searchRank = "bm25(recipe_search, 10.0, 4.0, 3.0, 4.0, 1.0, 5.0, 1.0)"
+
+// SnippetStart and SnippetEnd surround the matched terms in search snippets.
+const (
+	SnippetStart = "**"
+	SnippetEnd   = "**"
+)
+
+// SearchFilter narrows a full-text search. Fields limits matching to some of
+// the SearchFields; Limit caps the number of results when positive.
+type SearchFilter struct {
+	RecipeFilter
+	Fields []string
+	Limit  int
+}
+
+// SearchResult is a recipe matching a search, best first. Snippet is the
+// matching text with the terms marked by SnippetStart and SnippetEnd.
+type SearchResult struct {
+	Recipe  utils.RecipeRaw
+	Score   float64
+	Snippet string
+}
+
+// Search finds the recipes whose text matches every word of query, ranked
+// by relevance. Words match by prefix and stem ("fry" finds "frying"). An
+// empty query returns every recipe passing the filter, by name.
+func (c *CookBook) Search(query string, filter SearchFilter) ([]SearchResult, error) {
+	for _, field := range filter.Fields {
+		if !isSearchField(field) {
+			return nil, fmt.Errorf("unknown search field %q (use %s)", field, strings.Join(SearchFields, ", "))
+		}
+	}
+
+	match := ftsQuery(query, filter.Fields)
+	if match == "" {
+		recipes, err := c.FilterRecipes(filter.RecipeFilter)
+		if err != nil {
+			return nil, err
+		}
+		results := make([]SearchResult, 0, len(recipes))
+		for _, recipe := range recipes {
+			if filter.Limit > 0 && len(results) >= filter.Limit {
+				break
+			}
+			results = append(results, SearchResult{Recipe: recipe})
+		}
+		return results, nil
+	}
+
+	hits, err := c.matchIndex(match)
+	if err != nil {
+		slog.Error("Error searching recipes", "query", query, "match", match, "error", err)
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return []SearchResult{}, nil
+	}
+
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	recipes, err := c.recipeSummaries(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]utils.RecipeRaw, len(recipes))
+	for _, recipe := range recipes {
+		byID[recipe.RecipeID] = recipe
+	}
+
+	results := make([]SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		recipe, ok := byID[hit.ID]
+		if !ok || !filter.Matches(recipe) {
+			continue
+		}
+		if filter.Limit > 0 && len(results) >= filter.Limit {
+			break
+		}
+		results = append(results, SearchResult{Recipe: recipe, Score: hit.Score, Snippet: hit.Snippet})
+	}
+
+	slog.Debug("Recipes searched", "query", query, "match", match, "count", len(results))
+	return results, nil
+}
+
+// searchHit is a row of the search index matching an FTS5 query
+type searchHit struct {
+	ID      uint
+	Score   float64
+	Snippet string
+}
+
+// matchIndex runs an FTS5 query against the search index, best match first
+func (c *CookBook) matchIndex(match string) ([]searchHit, error) {
+	var hits []searchHit
+	err := c.conn.Raw(
+		"SELECT rowid AS id, "+searchRank+" AS score, snippet(recipe_search, -1, ?, ?, '…', 12) AS snippet "+
+			"FROM recipe_search WHERE recipe_search MATCH ? ORDER BY score",
+		SnippetStart, SnippetEnd, match,
+	).Scan(&hits).Error
+	return hits, err
+}
+
+func isSearchField(field string) bool {
+	for _, f := range SearchFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ftsQuery turns free text into an FTS5 query matching every word by
+// prefix, within fields when given. Punctuation is dropped, so user input
+// can never form FTS5 operators.
+func ftsQuery(query string, fields []string) string {
+	match := ftsTerm(query, false)
+	if match != "" && len(fields) > 0 {
+		match = "{" + strings.Join(fields, " ") + "} : " + match
+	}
+	return match
+}
+
+// ftsTerm turns text into an FTS5 query matching every word by prefix, or
+// the words in order ending with a prefix when phrase is set
+func ftsTerm(text string, phrase bool) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	if phrase {
+		return `"` + strings.Join(words, " ") + `"*`
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"*`
+	}
+	return "(" + strings.Join(terms, " ") + ")"
+}
+
+// indexRecipe writes the recipe's current text to the search index,
+// replacing what was indexed before.
+func indexRecipe(tx *gorm.DB, recipeID uint) error {
+	var recipe Recipe
+	if err := tx.First(&recipe, recipeID).Error; err != nil {
+		slog.Error("Error loading recipe to index", "id", recipeID, "error", err)
+		return err
+	}
+
+	var metadata RecipeMetadata
+	if err := tx.Where("recipe_id = ?", recipeID).Limit(1).Find(&metadata).Error; err != nil {
+		return err
+	}
+
+	var ingredients []Ingredients
+	if err := tx.Where("recipe_id = ?", recipeID).Order("id").Find(&ingredients).Error; err != nil {
+		return err
+	}
+	var ingredientLines []string
+	for _, ing := range ingredients {
+		ingredientLines = append(ingredientLines, strings.TrimSpace(ing.IngredientName+" "+ing.Detail))
+	}
+
+	// Step notes are searched with the instructions they annotate
+	var steps []Instructions
+	if err := tx.Where("recipe_id = ?", recipeID).Order("step").Find(&steps).Error; err != nil {
+		return err
+	}
+	var instructions []string
+	for _, step := range steps {
+		instructions = append(instructions, step.Description)
+		if step.Note != "" {
+			instructions = append(instructions, step.Note)
+		}
+	}
+
+	// Categories, cuisines, keywords and diets are all searched as tags
+	var tags []string
+	for _, source := range []struct {
+		model  any
+		column string
+	}{
+		{&Category{}, "category_name"},
+		{&Cuisine{}, "cuisine_name"},
+		{&Keyword{}, "keyword"},
+		{&Diet{}, "diet_name"},
+	} {
+		var values []string
+		if err := tx.Model(source.model).Where("recipe_id = ?", recipeID).Pluck(source.column, &values).Error; err != nil {
+			return err
+		}
+		tags = append(tags, values...)
+	}
+
+	if err := unindexRecipe(tx, recipeID); err != nil {
+		return err
+	}
+	err := tx.Exec(
+		"INSERT INTO recipe_search (rowid, name, description, author, ingredients, instructions, categories, url) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		recipeID, recipe.RecipeName, strings.TrimSpace(metadata.Description+"\n"+metadata.Notes), metadata.Author,
+		strings.Join(ingredientLines, "\n"), strings.Join(instructions, "\n"), strings.Join(tags, "\n"), metadata.URL,
+	).Error
+	if err != nil {
+		slog.Error("Error indexing recipe", "id", recipeID, "error", err)
+	}
+	return err
+}
+
+// unindexRecipe removes a recipe from the search index
+func unindexRecipe(tx *gorm.DB, recipeID uint) error {
+	if err := tx.Exec("DELETE FROM recipe_search WHERE rowid = ?", recipeID).Error; err != nil {
+		slog.Error("Error removing recipe from search index", "id", recipeID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// reindexRecipes rebuilds the search index from every recipe
+func reindexRecipes(tx *gorm.DB) error {
+	if err := tx.Exec("DELETE FROM recipe_search").Error; err != nil {
+		return err
+	}
+	var ids []uint
+	if err := tx.Model(&Recipe{}).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := indexRecipe(tx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchQuery finds the recipes matching a parsed filter query. Recipes
+// matching text terms come first, best first; the rest follow by name. A nil
+// query returns every recipe.
+func (c *CookBook) SearchQuery(query utils.Query) ([]SearchResult, error) {
+	recipes, err := c.recipeSummaries(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(recipes))
+	if query == nil {
+		for _, recipe := range recipes {
+			results = append(results, SearchResult{Recipe: recipe})
+		}
+		return results, nil
+	}
+
+	e := queryEvaluator{cookbook: c, recipes: recipes, visiting: make(map[string]bool)}
+	matches, err := e.eval(query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, recipe := range recipes {
+		if m, ok := matches[recipe.RecipeID]; ok {
+			results = append(results, SearchResult{Recipe: recipe, Score: m.Score, Snippet: m.Snippet})
+		}
+	}
+	// bm25 scores are negative, lower is better; unscored matches keep name order
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score < results[j].Score
+	})
+
+	slog.Debug("Recipes queried", "query", query.String(), "count", len(results))
+	return results, nil
+}
+
+// queryEvaluator evaluates a filter query into the set of matching recipes,
+// running one index search per text term. visiting holds the saved searches
+// being evaluated, so one that refers to itself fails instead of looping.
+type queryEvaluator struct {
+	cookbook *CookBook
+	recipes  []utils.RecipeRaw
+	visiting map[string]bool
+}
+
+// queryMatches maps the IDs of matching recipes to their summed text score
+// and the snippet of their first matching text term
+type queryMatches map[uint]searchHit
+
+func (e queryEvaluator) eval(query utils.Query) (queryMatches, error) {
+	switch q := query.(type) {
+	case utils.QueryText:
+		if q.Field != "" && !isSearchField(q.Field) {
+			return nil, fmt.Errorf("unknown search field %q", q.Field)
+		}
+		match := ftsTerm(q.Text, q.Phrase)
+		if match == "" {
+			return e.filter(func(utils.RecipeRaw) bool { return true }), nil
+		}
+		if q.Field != "" {
+			match = q.Field + " : " + match
+		}
+		hits, err := e.cookbook.matchIndex(match)
+		if err != nil {
+			slog.Error("Error searching recipes", "match", match, "error", err)
+			return nil, err
+		}
+		matches := make(queryMatches, len(hits))
+		for _, hit := range hits {
+			matches[hit.ID] = hit
+		}
+		return matches, nil
+
+	case utils.QueryFavourite:
+		return e.filter(func(r utils.RecipeRaw) bool { return r.IsFavourite }), nil
+
+	case utils.QueryCompare:
+		return e.filter(q.Compare), nil
+
+	case utils.QueryCollection:
+		return e.evalCollection(q.Name)
+
+	case utils.QueryNot:
+		excluded, err := e.eval(q.Query)
+		if err != nil {
+			return nil, err
+		}
+		return e.filter(func(r utils.RecipeRaw) bool {
+			_, ok := excluded[r.RecipeID]
+			return !ok
+		}), nil
+
+	case utils.QueryAnd:
+		var matches queryMatches
+		for i, term := range q {
+			termMatches, err := e.eval(term)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 {
+				matches = termMatches
+				continue
+			}
+			for id, m := range matches {
+				if other, ok := termMatches[id]; ok {
+					matches[id] = m.combine(other)
+				} else {
+					delete(matches, id)
+				}
+			}
+		}
+		return matches, nil
+
+	case utils.QueryOr:
+		matches := make(queryMatches)
+		for _, term := range q {
+			termMatches, err := e.eval(term)
+			if err != nil {
+				return nil, err
+			}
+			for id, other := range termMatches {
+				if m, ok := matches[id]; ok {
+					matches[id] = m.combine(other)
+				} else {
+					matches[id] = other
+				}
+			}
+		}
+		return matches, nil
+	}
+	return nil, fmt.Errorf("unsupported query %T", query)
+}
+
+// evalCollection matches the recipes of a collection, or of a saved search
+// by evaluating its query
+func (e queryEvaluator) evalCollection(name string) (queryMatches, error) {
+	collection, err := e.cookbook.CollectionBySlug(name)
+	if err == nil {
+		recipes, err := e.cookbook.CollectionRecipes(collection.ID)
+		if err != nil {
+			return nil, err
+		}
+		matches := make(queryMatches, len(recipes))
+		for _, recipe := range recipes {
+			matches[recipe.RecipeID] = searchHit{ID: recipe.RecipeID}
+		}
+		return matches, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	search, err := e.cookbook.SavedSearchBySlug(name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("no collection or saved search named %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if e.visiting[search.Slug] {
+		return nil, fmt.Errorf("saved search %s refers to itself", search.Name)
+	}
+	query, err := utils.ParseQuery(search.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query of %s: %w", search.Name, err)
+	}
+	if query == nil {
+		return e.filter(func(utils.RecipeRaw) bool { return true }), nil
+	}
+
+	e.visiting[search.Slug] = true
+	defer delete(e.visiting, search.Slug)
+	return e.eval(query)
+}
+
+// filter returns the recipes satisfying keep, without a score
+func (e queryEvaluator) filter(keep func(utils.RecipeRaw) bool) queryMatches {
+	matches := make(queryMatches)
+	for _, recipe := range e.recipes {
+		if keep(recipe) {
+			matches[recipe.RecipeID] = searchHit{ID: recipe.RecipeID}
+		}
+	}
+	return matches
+}
+
+// combine adds the scores of two matches of a recipe, keeping the first snippet
+func (h searchHit) combine(other searchHit) searchHit {
+	h.Score += other.Score
+	if h.Snippet == "" {
+		h.Snippet = other.Snippet
+	}
+	return h
+}
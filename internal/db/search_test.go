@@ -0,0 +1,155 @@
+package db
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	"github.com/GarroshIcecream/yummy/internal/utils"
+)
+
+func newTestCookBook(t *testing.T) *CookBook {
+	t.Helper()
+	dbConfig := config.NewDefaultDatabaseConfig()
+	cookbook, err := NewCookBook(t.TempDir(), &dbConfig)
+	if err != nil {
+		t.Fatalf("NewCookBook: %v", err)
+	}
+	return cookbook
+}
+
+// saveTestRecipe saves a recipe with the given name, ingredients and total time
+func saveTestRecipe(t *testing.T, cookbook *CookBook, name string, totalTime time.Duration, ingredients ...string) uint {
+	t.Helper()
+	recipe := &utils.RecipeRaw{RecipeName: name, Metadata: utils.RecipeMetadata{TotalTime: totalTime}}
+	for _, ingredient := range ingredients {
+		recipe.Metadata.Ingredients = append(recipe.Metadata.Ingredients, utils.Ingredient{Name: ingredient})
+	}
+	id, err := cookbook.SaveScrapedRecipe(recipe)
+	if err != nil {
+		t.Fatalf("SaveScrapedRecipe(%q): %v", name, err)
+	}
+	return id
+}
+
+func searchNames(t *testing.T, cookbook *CookBook, query string, fields ...string) []string {
+	t.Helper()
+	results, err := cookbook.Search(query, SearchFilter{Fields: fields})
+	if err != nil {
+		t.Fatalf("Search(%q): %v", query, err)
+	}
+	names := []string{}
+	for _, result := range results {
+		names = append(names, result.Recipe.RecipeName)
+	}
+	return names
+}
+
+func TestSearchFollowsRecipeChanges(t *testing.T) {
+	cookbook := newTestCookBook(t)
+	id := saveTestRecipe(t, cookbook, "Garlic Bread", 0, "garlic", "baguette")
+	saveTestRecipe(t, cookbook, "Tomato Soup", 0, "tomatoes")
+
+	if got := searchNames(t, cookbook, "garlic"); !reflect.DeepEqual(got, []string{"Garlic Bread"}) {
+		t.Errorf("after save: Search(garlic) = %v, want [Garlic Bread]", got)
+	}
+
+	recipe, err := cookbook.GetFullRecipe(id)
+	if err != nil {
+		t.Fatalf("GetFullRecipe: %v", err)
+	}
+	recipe.RecipeName = "Cheesy Toast"
+	if err := cookbook.UpdateRecipe(recipe); err != nil {
+		t.Fatalf("UpdateRecipe: %v", err)
+	}
+	if got := searchNames(t, cookbook, "toast"); !reflect.DeepEqual(got, []string{"Cheesy Toast"}) {
+		t.Errorf("after rename: Search(toast) = %v, want [Cheesy Toast]", got)
+	}
+	if got := searchNames(t, cookbook, "bread", "name"); len(got) != 0 {
+		t.Errorf("after rename: Search(bread) in names = %v, want none", got)
+	}
+	if got := searchNames(t, cookbook, "garlic"); !reflect.DeepEqual(got, []string{"Cheesy Toast"}) {
+		t.Errorf("after rename: Search(garlic) = %v, want [Cheesy Toast]", got)
+	}
+
+	if err := cookbook.DeleteRecipe(id); err != nil {
+		t.Fatalf("DeleteRecipe: %v", err)
+	}
+	// Search skips hits of missing recipes, so look at the index itself
+	var indexed int64
+	if err := cookbook.conn.Raw("SELECT count(*) FROM recipe_search WHERE rowid = ?", id).Scan(&indexed).Error; err != nil {
+		t.Fatalf("count index rows: %v", err)
+	}
+	if indexed != 0 {
+		t.Errorf("after delete: %d index rows for the recipe, want 0", indexed)
+	}
+	for _, query := range []string{"toast", "garlic"} {
+		if got := searchNames(t, cookbook, query); len(got) != 0 {
+			t.Errorf("after delete: Search(%s) = %v, want none", query, got)
+		}
+	}
+	if got := searchNames(t, cookbook, "soup"); !reflect.DeepEqual(got, []string{"Tomato Soup"}) {
+		t.Errorf("after delete: Search(soup) = %v, want [Tomato Soup]", got)
+	}
+}
+
+func TestSearchIndexBackfill(t *testing.T) {
+	conn, dbFile := openTestDB(t)
+	if err := NewMigrator(conn, dbFile, GetCookbookMigrations()).MigrateTo(7); err != nil {
+		t.Fatalf("MigrateTo(7): %v", err)
+	}
+	if err := conn.Create(&Recipe{RecipeName: "Lentil Stew"}).Error; err != nil {
+		t.Fatalf("create recipe: %v", err)
+	}
+	if err := NewMigrator(conn, dbFile, GetCookbookMigrations()).MigrateLatest(); err != nil {
+		t.Fatalf("MigrateLatest: %v", err)
+	}
+
+	cookbook := &CookBook{conn: conn}
+	if got := searchNames(t, cookbook, "lentil"); !reflect.DeepEqual(got, []string{"Lentil Stew"}) {
+		t.Errorf("Search(lentil) = %v, want [Lentil Stew]", got)
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	cookbook := newTestCookBook(t)
+	saveTestRecipe(t, cookbook, "Chicken Curry", 45*time.Minute, "chicken", "coconut milk")
+	saveTestRecipe(t, cookbook, "Peanut Noodles", 20*time.Minute, "noodles", "peanut butter")
+	saveTestRecipe(t, cookbook, "Chicken Salad", 15*time.Minute, "chicken", "lettuce", "walnuts")
+	saveTestRecipe(t, cookbook, "Sourdough", 0, "flour", "water")
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"@ingredients chicken", []string{"Chicken Curry", "Chicken Salad"}},
+		{"@ingredients chicken -@ingredients walnuts", []string{"Chicken Curry"}},
+		{"-@ingredients chicken", []string{"Peanut Noodles", "Sourdough"}},
+		{"@time<30m", []string{"Chicken Salad", "Peanut Noodles"}},
+		// A recipe without a time never matches a time comparison, either way
+		{"@time>=30m", []string{"Chicken Curry"}},
+		{"curry OR noodles", []string{"Chicken Curry", "Peanut Noodles"}},
+		{"curry | sourdough", []string{"Chicken Curry", "Sourdough"}},
+		{"(curry OR salad) @time<30m", []string{"Chicken Salad"}},
+	}
+	for _, tt := range tests {
+		query, err := utils.ParseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q): %v", tt.query, err)
+		}
+		results, err := cookbook.SearchQuery(query)
+		if err != nil {
+			t.Fatalf("SearchQuery(%q): %v", tt.query, err)
+		}
+		got := []string{}
+		for _, result := range results {
+			got = append(got, result.Recipe.RecipeName)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SearchQuery(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -2,17 +2,11 @@ package db
 
 import (
 	"log/slog"
-	"os"
-	"path/filepath"
-	"time"
 
 	"github.com/GarroshIcecream/yummy/internal/config"
-	"github.com/GarroshIcecream/yummy/internal/log"
 	utils "github.com/GarroshIcecream/yummy/internal/utils"
 	"github.com/tmc/langchaingo/llms"
-	"github.com/glebarez/sqlite"
 	"gorm.io/gorm"
-	gormlogger "gorm.io/gorm/logger"
 )
 
 // SessionStats struct for session statistics
@@ -24,29 +18,15 @@ type SessionStats struct {
 	TotalTokens  int
 }
 
-// Creates new instance of SessionLog struct
+// Creates new instance of SessionLog struct and brings its schema up to date
 func NewSessionLog(dbPath string, config *config.DatabaseConfig, opts ...gorm.Option) (*SessionLog, error) {
-	dbDir := filepath.Join(dbPath, "db")
-	if err := os.MkdirAll(dbDir, 0755); err != nil {
-		slog.Error("Failed to create database directory", "dir", dbDir, "error", err)
-		return nil, err
-	}
-
-	dbPath = filepath.Join(dbDir, config.SessionLogDBName)
-	_, err := os.Stat(dbPath)
-	if err != nil {
-		slog.Info("Database does not exist at %s, creating new database...", "dbPath", dbPath, "error", err)
-	}
-
-	dbCon, err := gorm.Open(sqlite.Open(dbPath), opts...)
+	dbCon, dbFile, err := openDatabase(dbPath, config.SessionLogDBName, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Configure GORM to use slog logger (logs to file via slog setup, not stdout)
-	dbCon.Logger = log.NewGormLogger(200*time.Millisecond, true, gormlogger.Info)
-
-	if err := dbCon.AutoMigrate(GetSessionLogModels()...); err != nil {
+	if err := NewMigrator(dbCon, dbFile, GetSessionLogMigrations()).MigrateLatest(); err != nil {
+		slog.Error("Error migrating session log database", "error", err)
 		return nil, err
 	}
 
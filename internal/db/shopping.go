@@ -0,0 +1,233 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"gorm.io/gorm"
+)
+
+// CreateShoppingList creates a shopping list from the given recipes and
+// returns its ID. A zero Scale means the recipe as saved.
+func (c *CookBook) CreateShoppingList(name string, recipes []utils.ShoppingListRecipe) (uint, error) {
+	if strings.TrimSpace(name) == "" {
+		name = "Shopping list"
+	}
+
+	var listID uint
+	err := c.conn.Transaction(func(tx *gorm.DB) error {
+		list := ShoppingList{Name: name}
+		if err := tx.Create(&list).Error; err != nil {
+			slog.Error("Error creating shopping list", "error", err)
+			return err
+		}
+		listID = list.ID
+		return c.addShoppingListRecipes(tx, list.ID, recipes)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	slog.Debug("Shopping list created", "id", listID, "recipes", len(recipes))
+	return listID, nil
+}
+
+// AddRecipesToShoppingList adds recipes to an existing list and rebuilds its
+// items. Adding a recipe that is already on the list replaces its scale.
+func (c *CookBook) AddRecipesToShoppingList(listID uint, recipes []utils.ShoppingListRecipe) error {
+	return c.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&ShoppingList{}, listID).Error; err != nil {
+			slog.Error("Error getting shopping list", "id", listID, "error", err)
+			return err
+		}
+		return c.addShoppingListRecipes(tx, listID, recipes)
+	})
+}
+
+func (c *CookBook) addShoppingListRecipes(tx *gorm.DB, listID uint, recipes []utils.ShoppingListRecipe) error {
+	for _, recipe := range recipes {
+		scale := recipe.Scale
+		if scale <= 0 {
+			scale = 1
+		}
+
+		var name string
+		if err := tx.Model(&Recipe{}).Where("id = ?", recipe.RecipeID).Pluck("recipe_name", &name).Error; err != nil {
+			return err
+		}
+		if name == "" {
+			return fmt.Errorf("recipe %d not found", recipe.RecipeID)
+		}
+
+		res := tx.Unscoped().Delete(&ShoppingListRecipe{}, "shopping_list_id = ? AND recipe_id = ?", listID, recipe.RecipeID)
+		if res.Error != nil {
+			slog.Error("Error replacing shopping list recipe", "error", res.Error)
+			return res.Error
+		}
+		row := ShoppingListRecipe{ShoppingListID: listID, RecipeID: recipe.RecipeID, RecipeName: name, Scale: scale}
+		if err := tx.Create(&row).Error; err != nil {
+			slog.Error("Error adding recipe to shopping list", "error", err)
+			return err
+		}
+	}
+	return c.rebuildShoppingList(tx, listID)
+}
+
+// rebuildShoppingList recomputes a list's items from its recipes. Items that
+// come out unchanged keep their checked state.
+func (c *CookBook) rebuildShoppingList(tx *gorm.DB, listID uint) error {
+	var rows []ShoppingListRecipe
+	if err := tx.Where("shopping_list_id = ?", listID).Order("id").Find(&rows).Error; err != nil {
+		return err
+	}
+
+	var recipes []*utils.RecipeRaw
+	for _, row := range rows {
+		recipe, err := getFullRecipe(tx, row.RecipeID)
+		if err != nil {
+			slog.Warn("Skipping missing recipe on shopping list", "recipeID", row.RecipeID, "error", err)
+			continue
+		}
+		recipes = append(recipes, recipe.Scaled(row.Scale))
+	}
+
+	var existing []ShoppingListItem
+	if err := tx.Where("shopping_list_id = ?", listID).Find(&existing).Error; err != nil {
+		return err
+	}
+	checked := make(map[string]bool)
+	for _, item := range existing {
+		if item.Checked {
+			checked[shoppingItemKey(item.Name, item.Amount, item.Unit, item.Extra)] = true
+		}
+	}
+
+	if err := tx.Unscoped().Delete(&ShoppingListItem{}, "shopping_list_id = ?", listID).Error; err != nil {
+		slog.Error("Error clearing shopping list items", "error", err)
+		return err
+	}
+	for _, item := range utils.BuildShoppingItems(recipes) {
+		row := ShoppingListItem{
+			ShoppingListID: listID,
+			Name:           item.Name,
+			BaseName:       item.BaseName,
+			Amount:         item.Amount,
+			Unit:           item.Unit,
+			Extra:          item.Extra,
+			Aisle:          item.Aisle,
+			Recipes:        strings.Join(item.Recipes, "\n"),
+			Checked:        checked[shoppingItemKey(item.Name, item.Amount, item.Unit, item.Extra)],
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			slog.Error("Error creating shopping list item", "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func shoppingItemKey(name, amount, unit, extra string) string {
+	return strings.ToLower(name) + "|" + amount + "|" + unit + "|" + extra
+}
+
+// GetShoppingList returns a shopping list with its recipes and items
+func (c *CookBook) GetShoppingList(listID uint) (*utils.ShoppingList, error) {
+	var list ShoppingList
+	if err := c.conn.First(&list, listID).Error; err != nil {
+		slog.Error("Error getting shopping list", "id", listID, "error", err)
+		return nil, err
+	}
+
+	var recipes []ShoppingListRecipe
+	if err := c.conn.Where("shopping_list_id = ?", listID).Order("id").Find(&recipes).Error; err != nil {
+		return nil, err
+	}
+	var items []ShoppingListItem
+	if err := c.conn.Where("shopping_list_id = ?", listID).Order("id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	result := &utils.ShoppingList{ID: list.ID, Name: list.Name, CreatedAt: list.CreatedAt}
+	for _, r := range recipes {
+		result.Recipes = append(result.Recipes, utils.ShoppingListRecipe{
+			RecipeID:   r.RecipeID,
+			RecipeName: r.RecipeName,
+			Scale:      r.Scale,
+		})
+	}
+	for _, item := range items {
+		var recipeNames []string
+		if item.Recipes != "" {
+			recipeNames = strings.Split(item.Recipes, "\n")
+		}
+		result.Items = append(result.Items, utils.ShoppingItem{
+			ID:       item.ID,
+			Name:     item.Name,
+			BaseName: item.BaseName,
+			Amount:   item.Amount,
+			Unit:     item.Unit,
+			Extra:    item.Extra,
+			Aisle:    item.Aisle,
+			Checked:  item.Checked,
+			Recipes:  recipeNames,
+		})
+	}
+	utils.SortShoppingItems(result.Items)
+	return result, nil
+}
+
+// LatestShoppingList returns the most recently created shopping list, or nil
+// when there are none.
+func (c *CookBook) LatestShoppingList() (*utils.ShoppingList, error) {
+	var list ShoppingList
+	err := c.conn.Order("id DESC").First(&list).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		slog.Error("Error getting latest shopping list", "error", err)
+		return nil, err
+	}
+	return c.GetShoppingList(list.ID)
+}
+
+// ShoppingLists returns every shopping list (without items), newest first
+func (c *CookBook) ShoppingLists() ([]utils.ShoppingList, error) {
+	var lists []ShoppingList
+	if err := c.conn.Order("id DESC").Find(&lists).Error; err != nil {
+		slog.Error("Error getting shopping lists", "error", err)
+		return nil, err
+	}
+
+	result := make([]utils.ShoppingList, 0, len(lists))
+	for _, list := range lists {
+		result = append(result, utils.ShoppingList{ID: list.ID, Name: list.Name, CreatedAt: list.CreatedAt})
+	}
+	return result, nil
+}
+
+// SetShoppingItemChecked ticks a shopping list item off (or back on)
+func (c *CookBook) SetShoppingItemChecked(itemID uint, checked bool) error {
+	err := c.conn.Model(&ShoppingListItem{}).Where("id = ?", itemID).Update("checked", checked).Error
+	if err != nil {
+		slog.Error("Error setting shopping item checked", "error", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteShoppingList deletes a shopping list with its recipes and items
+func (c *CookBook) DeleteShoppingList(listID uint) error {
+	return c.conn.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{&ShoppingListItem{}, &ShoppingListRecipe{}} {
+			if err := tx.Unscoped().Delete(model, "shopping_list_id = ?", listID).Error; err != nil {
+				slog.Error("Error deleting shopping list contents", "error", err)
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&ShoppingList{}, "id = ?", listID).Error
+	})
+}
@@ -0,0 +1,239 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	"github.com/tmc/langchaingo/llms/ollama"
+)
+
+// DefaultOllamaHost is where Ollama listens unless configured otherwise
+const DefaultOllamaHost = "http://localhost:11434"
+
+const defaultOllamaPort = "11434"
+
+// OllamaHost returns the URL of the Ollama server: ollama_host from the chat
+// config, else base_url when the provider is Ollama, else $OLLAMA_HOST, else
+// DefaultOllamaHost
+func OllamaHost() string {
+	chatConfig := config.GetChatConfig()
+	host := chatConfig.OllamaHost
+	if strings.TrimSpace(host) == "" {
+		if provider, _ := ParseProvider(chatConfig.Provider); provider == ProviderOllama {
+			host = chatConfig.BaseURL
+		}
+	}
+	if strings.TrimSpace(host) == "" {
+		host = os.Getenv("OLLAMA_HOST")
+	}
+	return NormalizeOllamaHost(host)
+}
+
+// NormalizeOllamaHost turns a host as Ollama accepts it in $OLLAMA_HOST
+// ("remote", "remote:8080", "https://remote") into a base URL
+func NormalizeOllamaHost(host string) string {
+	host = strings.TrimRight(strings.TrimSpace(host), "/")
+	if host == "" {
+		return DefaultOllamaHost
+	}
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil || u.Host == "" {
+		return host
+	}
+	if u.Port() == "" && u.Scheme == "http" {
+		u.Host = net.JoinHostPort(u.Hostname(), defaultOllamaPort)
+	}
+	return strings.TrimRight(u.String(), "/")
+}
+
+// IsLocalHost reports whether an Ollama base URL points at this machine,
+// where the service can be started
+func IsLocalHost(baseURL string) bool {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1", "0.0.0.0", "":
+		return true
+	}
+	return false
+}
+
+// NewOllama creates a langchaingo Ollama model on the configured host
+func NewOllama(modelName string) (*ollama.LLM, error) {
+	return ollama.New(
+		ollama.WithModel(modelName),
+		ollama.WithServerURL(OllamaHost()),
+	)
+}
+
+// OllamaClient talks to the REST API of an Ollama server
+type OllamaClient struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewOllamaClient creates a client for the Ollama server at baseURL
+func NewOllamaClient(baseURL string) *OllamaClient {
+	return &OllamaClient{
+		baseURL: NormalizeOllamaHost(baseURL),
+		http:    &http.Client{},
+	}
+}
+
+// BaseURL returns the URL of the server
+func (c *OllamaClient) BaseURL() string {
+	return c.baseURL
+}
+
+// ollamaModel is a model as listed by /api/tags and /api/ps
+type ollamaModel struct {
+	Name  string `json:"name"`
+	Model string `json:"model"`
+	Size  int64  `json:"size"`
+}
+
+type ollamaModelList struct {
+	Models []ollamaModel `json:"models"`
+}
+
+// InstalledModels returns the names of the models on the server (/api/tags)
+func (c *OllamaClient) InstalledModels(ctx context.Context) ([]string, error) {
+	return c.listModels(ctx, "/api/tags")
+}
+
+// RunningModels returns the names of the models loaded in memory (/api/ps).
+// It doubles as a check that the server is up.
+func (c *OllamaClient) RunningModels(ctx context.Context) ([]string, error) {
+	return c.listModels(ctx, "/api/ps")
+}
+
+func (c *OllamaClient) listModels(ctx context.Context, path string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ollama is not reachable at %s: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	var list ollamaModelList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	names := make([]string, 0, len(list.Models))
+	for _, model := range list.Models {
+		name := model.Name
+		if name == "" {
+			name = model.Model
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// PullProgress is one update of a model pull
+type PullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Percent returns how much of the current layer is downloaded, or -1 when
+// the update is not a download
+func (p PullProgress) Percent() int {
+	if p.Total <= 0 {
+		return -1
+	}
+	return int(p.Completed * 100 / p.Total)
+}
+
+// Pull downloads a model to the server (/api/pull), calling progress with
+// each update as the server streams them. It returns once the pull is done.
+func (c *OllamaClient) Pull(ctx context.Context, modelName string, progress func(PullProgress)) error {
+	// Older servers read the model from "name"
+	body, err := json.Marshal(map[string]any{"model": modelName, "name": modelName, "stream": true})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/pull", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("ollama is not reachable at %s: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var update PullProgress
+		if err := json.Unmarshal(line, &update); err != nil {
+			return fmt.Errorf("failed to decode pull progress: %w", err)
+		}
+		if update.Error != "" {
+			return fmt.Errorf("failed to pull %s: %s", modelName, update.Error)
+		}
+		if progress != nil {
+			progress(update)
+		}
+		if update.Status == "success" {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to pull %s: %w", modelName, err)
+	}
+	return fmt.Errorf("failed to pull %s: the server stopped before it finished", modelName)
+}
+
+// responseError reads the {"error": "..."} body Ollama sends with a failed
+// request
+func responseError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(data, &body) == nil && body.Error != "" {
+		return fmt.Errorf("ollama: %s", body.Error)
+	}
+	if text := strings.TrimSpace(string(data)); text != "" {
+		return fmt.Errorf("ollama: %s (%s)", text, resp.Status)
+	}
+	return fmt.Errorf("ollama: %s", resp.Status)
+}
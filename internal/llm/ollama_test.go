@@ -0,0 +1,174 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newOllamaServer stands in for an Ollama server with the given models
+// installed and loaded
+func newOllamaServer(t *testing.T, installed, running []string, pull func(w http.ResponseWriter, model string)) *httptest.Server {
+	t.Helper()
+	list := func(names []string) map[string]any {
+		models := make([]map[string]any, len(names))
+		for i, name := range names {
+			models[i] = map[string]any{"name": name, "model": name, "size": 1024}
+		}
+		return map[string]any{"models": models}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/tags", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(list(installed))
+	})
+	mux.HandleFunc("GET /api/ps", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(list(running))
+	})
+	mux.HandleFunc("POST /api/pull", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model  string `json:"model"`
+			Stream bool   `json:"stream"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !body.Stream {
+			t.Errorf("bad pull request: %+v, %v", body, err)
+		}
+		pull(w, body.Model)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNormalizeOllamaHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"", DefaultOllamaHost},
+		{"  ", DefaultOllamaHost},
+		{"localhost", "http://localhost:11434"},
+		{"gpu-box:8080", "http://gpu-box:8080"},
+		{"http://gpu-box", "http://gpu-box:11434"},
+		{"http://10.0.0.5:11434/", "http://10.0.0.5:11434"},
+		{"https://ollama.example.com", "https://ollama.example.com"},
+		{"https://example.com/ollama/", "https://example.com/ollama"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeOllamaHost(tt.host); got != tt.want {
+			t.Errorf("NormalizeOllamaHost(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestIsLocalHost(t *testing.T) {
+	for host, want := range map[string]bool{
+		"http://localhost:11434":     true,
+		"http://127.0.0.1:11434":     true,
+		"http://[::1]:11434":         true,
+		"http://gpu-box:11434":       false,
+		"https://ollama.example.com": false,
+	} {
+		if got := IsLocalHost(host); got != want {
+			t.Errorf("IsLocalHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestOllamaClientModels(t *testing.T) {
+	server := newOllamaServer(t, []string{"gemma3:4b", "llama3.2:latest"}, []string{"gemma3:4b"}, nil)
+	client := NewOllamaClient(server.URL)
+
+	installed, err := client.InstalledModels(t.Context())
+	if err != nil {
+		t.Fatalf("InstalledModels: %v", err)
+	}
+	if want := []string{"gemma3:4b", "llama3.2:latest"}; !reflect.DeepEqual(installed, want) {
+		t.Errorf("InstalledModels = %v, want %v", installed, want)
+	}
+
+	running, err := client.RunningModels(t.Context())
+	if err != nil {
+		t.Fatalf("RunningModels: %v", err)
+	}
+	if want := []string{"gemma3:4b"}; !reflect.DeepEqual(running, want) {
+		t.Errorf("RunningModels = %v, want %v", running, want)
+	}
+}
+
+func TestOllamaClientNotReachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	_, err := NewOllamaClient(url).RunningModels(t.Context())
+	if err == nil || !strings.Contains(err.Error(), "not reachable") {
+		t.Errorf("RunningModels on a stopped server: err = %v, want not reachable", err)
+	}
+}
+
+func TestOllamaClientPull(t *testing.T) {
+	server := newOllamaServer(t, nil, nil, func(w http.ResponseWriter, model string) {
+		if model == "missing" {
+			fmt.Fprintln(w, `{"status":"pulling manifest"}`)
+			fmt.Fprintln(w, `{"error":"pull model manifest: file does not exist"}`)
+			return
+		}
+		fmt.Fprintln(w, `{"status":"pulling manifest"}`)
+		fmt.Fprintln(w, `{"status":"pulling 8eeb52dfb3bb","digest":"sha256:8eeb","total":200,"completed":50}`)
+		fmt.Fprintln(w, `{"status":"pulling 8eeb52dfb3bb","digest":"sha256:8eeb","total":200,"completed":200}`)
+		fmt.Fprintln(w, `{"status":"verifying sha256 digest"}`)
+		fmt.Fprintln(w, `{"status":"success"}`)
+	})
+	client := NewOllamaClient(server.URL)
+
+	var percents []int
+	var statuses []string
+	err := client.Pull(t.Context(), "gemma3:4b", func(p PullProgress) {
+		statuses = append(statuses, p.Status)
+		percents = append(percents, p.Percent())
+	})
+	if err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	if want := []int{-1, 25, 100, -1, -1}; !reflect.DeepEqual(percents, want) {
+		t.Errorf("pull percents = %v, want %v", percents, want)
+	}
+	if statuses[len(statuses)-1] != "success" {
+		t.Errorf("last pull status = %q, want success", statuses[len(statuses)-1])
+	}
+
+	err = client.Pull(t.Context(), "missing", nil)
+	if err == nil || !strings.Contains(err.Error(), "file does not exist") {
+		t.Errorf("Pull(missing): err = %v, want the server's error", err)
+	}
+}
+
+func TestOllamaClientPullInterrupted(t *testing.T) {
+	server := newOllamaServer(t, nil, nil, func(w http.ResponseWriter, model string) {
+		fmt.Fprintln(w, `{"status":"pulling manifest"}`)
+	})
+
+	err := NewOllamaClient(server.URL).Pull(t.Context(), "gemma3:4b", nil)
+	if err == nil || !strings.Contains(err.Error(), "stopped before it finished") {
+		t.Errorf("Pull cut short: err = %v, want stopped before it finished", err)
+	}
+}
+
+func TestOllamaClientErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"unauthorized"}`)
+	}))
+	defer server.Close()
+
+	_, err := NewOllamaClient(server.URL).InstalledModels(t.Context())
+	if err == nil || err.Error() != "ollama: unauthorized" {
+		t.Errorf("InstalledModels: err = %v, want ollama: unauthorized", err)
+	}
+}
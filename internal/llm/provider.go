@@ -0,0 +1,201 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+// Provider is the kind of server the LLM runs on
+type Provider string
+
+const (
+	// ProviderOllama is an Ollama server, local or remote
+	ProviderOllama Provider = "ollama"
+	// ProviderOpenAI is any server with the OpenAI chat completions API:
+	// OpenAI itself, llama.cpp server, vLLM, LM Studio and the like
+	ProviderOpenAI Provider = "openai"
+)
+
+// DefaultOpenAIBaseURL is the API of OpenAI, used when base_url is not set
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// defaultAPIKeyEnv is read for the API key when api_key_env is not set
+const defaultAPIKeyEnv = "OPENAI_API_KEY"
+
+// ParseProvider reads the provider of the chat config. Empty means Ollama.
+func ParseProvider(name string) (Provider, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "ollama":
+		return ProviderOllama, nil
+	case "openai", "openai-compatible":
+		return ProviderOpenAI, nil
+	}
+	return "", fmt.Errorf("unknown LLM provider: %s. Supported providers: ollama, openai", name)
+}
+
+// CurrentProvider returns the configured provider for labels and hints, or
+// Ollama when the config names one that is not supported. Whatever connects
+// to the provider uses ParseProvider, so that case is reported as an error.
+func CurrentProvider() Provider {
+	provider, err := ParseProvider(config.GetChatConfig().Provider)
+	if err != nil {
+		return ProviderOllama
+	}
+	return provider
+}
+
+// Name returns the provider as shown to the user
+func (p Provider) Name() string {
+	if p == ProviderOpenAI {
+		return "the LLM server"
+	}
+	return "Ollama"
+}
+
+// CanPull reports whether models can be downloaded through the provider
+func (p Provider) CanPull() bool {
+	return p == ProviderOllama
+}
+
+// BaseURL returns the URL of the provider's API from the chat config
+func (p Provider) BaseURL() string {
+	if p == ProviderOllama {
+		return OllamaHost()
+	}
+	return NormalizeBaseURL(config.GetChatConfig().BaseURL)
+}
+
+// NormalizeBaseURL trims an OpenAI-compatible base URL, defaulting to OpenAI
+func NormalizeBaseURL(baseURL string) string {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		return DefaultOpenAIBaseURL
+	}
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "http://" + baseURL
+	}
+	return baseURL
+}
+
+// APIKey reads the API key from the environment variable named by
+// api_key_env, or $OPENAI_API_KEY
+func APIKey() string {
+	env := strings.TrimSpace(config.GetChatConfig().APIKeyEnv)
+	if env == "" {
+		env = defaultAPIKeyEnv
+	}
+	return os.Getenv(env)
+}
+
+// New creates the model for chat, cooking help and ingredient parsing on the
+// configured provider
+func New(modelName string) (llms.Model, error) {
+	provider, err := ParseProvider(config.GetChatConfig().Provider)
+	if err != nil {
+		return nil, err
+	}
+	if provider == ProviderOllama {
+		return NewOllama(modelName)
+	}
+	return NewOpenAI(modelName, provider.BaseURL(), APIKey())
+}
+
+// NewOpenAI creates a model on an OpenAI-compatible server
+func NewOpenAI(modelName, baseURL, apiKey string) (llms.Model, error) {
+	if apiKey == "" {
+		// Local servers take no key, but the client insists on one
+		apiKey = "none"
+	}
+	return openai.New(
+		openai.WithModel(modelName),
+		openai.WithBaseURL(NormalizeBaseURL(baseURL)),
+		openai.WithToken(apiKey),
+	)
+}
+
+// OpenAIClient talks to the REST API of an OpenAI-compatible server
+type OpenAIClient struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+// NewOpenAIClient creates a client for the server at baseURL
+func NewOpenAIClient(baseURL, apiKey string) *OpenAIClient {
+	return &OpenAIClient{
+		baseURL: NormalizeBaseURL(baseURL),
+		apiKey:  apiKey,
+		http:    &http.Client{},
+	}
+}
+
+// BaseURL returns the URL of the server
+func (c *OpenAIClient) BaseURL() string {
+	return c.baseURL
+}
+
+// Models returns the IDs of the models the server offers (/models). It
+// doubles as a check that the server is up.
+func (c *OpenAIClient) Models(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("the LLM server is not reachable at %s: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, openAIResponseError(resp)
+	}
+
+	var list struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode /models: %w", err)
+	}
+	names := make([]string, 0, len(list.Data))
+	for _, model := range list.Data {
+		names = append(names, model.ID)
+	}
+	return names, nil
+}
+
+// openAIResponseError reads the {"error": {"message": "..."}} body of a
+// failed request. Some servers send the error as a plain string.
+func openAIResponseError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var body struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(data, &body) == nil && len(body.Error) > 0 {
+		var detail struct {
+			Message string `json:"message"`
+		}
+		var message string
+		if json.Unmarshal(body.Error, &detail) == nil && detail.Message != "" {
+			return fmt.Errorf("LLM server: %s", detail.Message)
+		}
+		if json.Unmarshal(body.Error, &message) == nil && message != "" {
+			return fmt.Errorf("LLM server: %s", message)
+		}
+	}
+	return fmt.Errorf("LLM server: %s", resp.Status)
+}
@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/ollama"
+	"github.com/tmc/langchaingo/llms/openai"
+)
+
+// withChatConfig sets the global config for the test, with edit applied to
+// the default chat config
+func withChatConfig(t *testing.T, edit func(*config.ChatConfig)) {
+	t.Helper()
+	previous := config.GetGlobalConfig()
+	cfg := config.NewDefaultConfig()
+	edit(&cfg.Chat)
+	config.SetGlobalConfig(cfg)
+	t.Cleanup(func() { config.SetGlobalConfig(previous) })
+}
+
+func TestParseProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Provider
+		wantErr bool
+	}{
+		{"", ProviderOllama, false},
+		{"ollama", ProviderOllama, false},
+		{" Ollama ", ProviderOllama, false},
+		{"openai", ProviderOpenAI, false},
+		{"OpenAI-compatible", ProviderOpenAI, false},
+		{"anthropic", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseProvider(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseProvider(%q) = %q, %v; want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNormalizeBaseURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		want    string
+	}{
+		{"", DefaultOpenAIBaseURL},
+		{"http://localhost:8080/v1/", "http://localhost:8080/v1"},
+		{"localhost:1234/v1", "http://localhost:1234/v1"},
+		{"https://vllm.example.com/v1", "https://vllm.example.com/v1"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeBaseURL(tt.baseURL); got != tt.want {
+			t.Errorf("NormalizeBaseURL(%q) = %q, want %q", tt.baseURL, got, tt.want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	withChatConfig(t, func(c *config.ChatConfig) { c.Provider = "" })
+	model, err := New("gemma3:4b")
+	if err != nil {
+		t.Fatalf("New with ollama: %v", err)
+	}
+	if _, ok := model.(*ollama.LLM); !ok {
+		t.Errorf("New with ollama = %T, want *ollama.LLM", model)
+	}
+
+	withChatConfig(t, func(c *config.ChatConfig) {
+		c.Provider = "openai"
+		c.BaseURL = "http://localhost:8080/v1"
+		c.APIKeyEnv = "YUMMY_TEST_NO_SUCH_KEY"
+	})
+	model, err = New("qwen2.5")
+	if err != nil {
+		t.Fatalf("New with openai and no key: %v", err)
+	}
+	if _, ok := model.(*openai.LLM); !ok {
+		t.Errorf("New with openai = %T, want *openai.LLM", model)
+	}
+
+	withChatConfig(t, func(c *config.ChatConfig) { c.Provider = "anthropic" })
+	if _, err := New("claude"); err == nil {
+		t.Error("New with an unknown provider: want an error")
+	}
+}
+
+func TestNewOpenAIGenerates(t *testing.T) {
+	var gotModel, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			Model string `json:"model"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		gotModel, gotAuth = body.Model, r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"1","object":"chat.completion","model":"qwen2.5","choices":[{"index":0,"message":{"role":"assistant","content":"Add salt."},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	model, err := NewOpenAI("qwen2.5", server.URL+"/v1", "secret")
+	if err != nil {
+		t.Fatalf("NewOpenAI: %v", err)
+	}
+	answer, err := llms.GenerateFromSinglePrompt(t.Context(), model, "How do I season soup?")
+	if err != nil {
+		t.Fatalf("GenerateFromSinglePrompt: %v", err)
+	}
+	if answer != "Add salt." {
+		t.Errorf("answer = %q, want Add salt.", answer)
+	}
+	if gotModel != "qwen2.5" || gotAuth != "Bearer secret" {
+		t.Errorf("request model %q, auth %q; want qwen2.5, Bearer secret", gotModel, gotAuth)
+	}
+}
+
+func TestOpenAIClientModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":{"message":"invalid api key","type":"invalid_request_error"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"qwen2.5","object":"model"},{"id":"llama-3.1-8b","object":"model"}]}`)
+	}))
+	defer server.Close()
+
+	models, err := NewOpenAIClient(server.URL, "secret").Models(t.Context())
+	if err != nil {
+		t.Fatalf("Models: %v", err)
+	}
+	if want := []string{"qwen2.5", "llama-3.1-8b"}; !reflect.DeepEqual(models, want) {
+		t.Errorf("Models = %v, want %v", models, want)
+	}
+
+	_, err = NewOpenAIClient(server.URL, "wrong").Models(t.Context())
+	if err == nil || err.Error() != "LLM server: invalid api key" {
+		t.Errorf("Models with a wrong key: err = %v, want LLM server: invalid api key", err)
+	}
+}
+
+func TestOpenAIClientNotReachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	_, err := NewOpenAIClient(url, "").Models(t.Context())
+	if err == nil || !strings.Contains(err.Error(), "not reachable") {
+		t.Errorf("Models on a stopped server: err = %v, want not reachable", err)
+	}
+}
+
+func TestOllamaHostFromBaseURL(t *testing.T) {
+	t.Setenv("OLLAMA_HOST", "")
+	withChatConfig(t, func(c *config.ChatConfig) { c.BaseURL = "gpu-box" })
+	if got := OllamaHost(); got != "http://gpu-box:11434" {
+		t.Errorf("OllamaHost with base_url = %q, want http://gpu-box:11434", got)
+	}
+
+	withChatConfig(t, func(c *config.ChatConfig) {
+		c.BaseURL = "gpu-box"
+		c.OllamaHost = "other-box:8080"
+	})
+	if got := OllamaHost(); got != "http://other-box:8080" {
+		t.Errorf("OllamaHost with ollama_host = %q, want http://other-box:8080", got)
+	}
+
+	withChatConfig(t, func(c *config.ChatConfig) {
+		c.Provider = "openai"
+		c.BaseURL = "http://localhost:8080/v1"
+	})
+	if got := OllamaHost(); got != DefaultOllamaHost {
+		t.Errorf("OllamaHost with the openai provider = %q, want %q", got, DefaultOllamaHost)
+	}
+}
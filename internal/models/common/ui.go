@@ -26,6 +26,9 @@ const (
 	SessionStateEdit
 	SessionStateChat
 	SessionStateCooking
+	SessionStateShoppingList
+	SessionStatePantry
+	SessionStateMealPlan
 )
 
 func (s SessionState) GetStateEmoji() string {
@@ -42,6 +45,12 @@ func (s SessionState) GetStateEmoji() string {
 		return "💬"
 	case SessionStateCooking:
 		return "🍳"
+	case SessionStateShoppingList:
+		return "🛒"
+	case SessionStatePantry:
+		return "🥫"
+	case SessionStateMealPlan:
+		return "📅"
 	default:
 		return "❌"
 	}
@@ -61,23 +70,17 @@ func (s SessionState) GetStateName() string {
 		return "Chat Assistant"
 	case SessionStateCooking:
 		return "Cooking"
+	case SessionStateShoppingList:
+		return "Shopping List"
+	case SessionStatePantry:
+		return "Pantry"
+	case SessionStateMealPlan:
+		return "Meal Plan"
 	default:
 		return "Unknown State"
 	}
 }
 
-type FilterField string
-
-const (
-	AuthorField      FilterField = "author"
-	CategoryField    FilterField = "categories"
-	IngredientsField FilterField = "ingredients"
-	FavouriteField   FilterField = "favourite"
-	TitleField       FilterField = "title"
-	DescriptionField FilterField = "description"
-	URLField         FilterField = "url"
-)
-
 type ModelState int
 
 const (
@@ -97,6 +100,9 @@ const (
 	StatusModeStateSelector   StatusMode = "STATE"
 	StatusModeSessionSelector StatusMode = "SESSION"
 	StatusModeCooking         StatusMode = "COOKING"
+	StatusModeShopping        StatusMode = "SHOPPING"
+	StatusModePantry          StatusMode = "PANTRY"
+	StatusModeMealPlan        StatusMode = "PLAN"
 )
 
 type ModalType string
@@ -110,4 +116,8 @@ const (
 	ModalTypeRecipeSelector   ModalType = "RECIPE_SELECTOR"
 	ModalTypeCommandPalette   ModalType = "COMMAND_PALETTE"
 	ModalTypeRating           ModalType = "RATING"
+	ModalTypeSavedSearches    ModalType = "SAVED_SEARCHES"
+	ModalTypeSaveSearch       ModalType = "SAVE_SEARCH"
+	ModalTypeCollections      ModalType = "COLLECTIONS"
+	ModalTypeLogCook          ModalType = "LOG_COOK"
 )
@@ -73,6 +73,11 @@ type ModelSelectedMsg struct {
 	ModelName string
 }
 
+// ModelPulledMsg is sent when a model has been pulled to the Ollama server.
+type ModelPulledMsg struct {
+	ModelName string
+}
+
 type ThemeSelectedMsg struct {
 	ThemeName string
 }
@@ -189,6 +194,10 @@ func SendModelSelectedMsg(modelName string) tea.Cmd {
 	return CmdHandler(ModelSelectedMsg{ModelName: modelName})
 }
 
+func SendModelPulledMsg(modelName string) tea.Cmd {
+	return CmdHandler(ModelPulledMsg{ModelName: modelName})
+}
+
 func SendThemeSelectedMsg(themeName string) tea.Cmd {
 	return CmdHandler(ThemeSelectedMsg{ThemeName: themeName})
 }
@@ -228,3 +237,32 @@ type RatingSelectedMsg struct {
 func SendRatingSelectedMsg(recipeID uint, rating int8) tea.Cmd {
 	return CmdHandler(RatingSelectedMsg{RecipeID: recipeID, Rating: rating})
 }
+
+// ApplyFilterMsg is sent to show the recipe list filtered by a query, such as
+// a saved search.
+type ApplyFilterMsg struct {
+	Name  string
+	Query string
+}
+
+func SendApplyFilterMsg(name string, query string) tea.Cmd {
+	return CmdHandler(ApplyFilterMsg{Name: name, Query: query})
+}
+
+// SearchSavedMsg is sent when the list filter has been saved as a search.
+type SearchSavedMsg struct {
+	Name string
+}
+
+func SendSearchSavedMsg(name string) tea.Cmd {
+	return CmdHandler(SearchSavedMsg{Name: name})
+}
+
+// CookLoggedMsg is sent when a recipe's cook log has changed.
+type CookLoggedMsg struct {
+	RecipeID uint
+}
+
+func SendCookLoggedMsg(recipeID uint) tea.Cmd {
+	return CmdHandler(CookLoggedMsg{RecipeID: recipeID})
+}
@@ -0,0 +1,183 @@
+// Package nutrition holds a small offline table of typical nutrients per
+// 100 g of common ingredients, used to estimate the nutrition of recipes.
+package nutrition
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Facts are the calories and macronutrients of an amount of food. Masses
+// are in grams, except sodium which is in milligrams.
+type Facts struct {
+	Calories      float64
+	Fat           float64
+	SaturatedFat  float64
+	Carbohydrates float64
+	Sugar         float64
+	Fiber         float64
+	Protein       float64
+	Sodium        float64
+}
+
+// Add returns the sum of f and o
+func (f Facts) Add(o Facts) Facts {
+	return Facts{
+		Calories:      f.Calories + o.Calories,
+		Fat:           f.Fat + o.Fat,
+		SaturatedFat:  f.SaturatedFat + o.SaturatedFat,
+		Carbohydrates: f.Carbohydrates + o.Carbohydrates,
+		Sugar:         f.Sugar + o.Sugar,
+		Fiber:         f.Fiber + o.Fiber,
+		Protein:       f.Protein + o.Protein,
+		Sodium:        f.Sodium + o.Sodium,
+	}
+}
+
+// Scale returns f multiplied by factor
+func (f Facts) Scale(factor float64) Facts {
+	return Facts{
+		Calories:      f.Calories * factor,
+		Fat:           f.Fat * factor,
+		SaturatedFat:  f.SaturatedFat * factor,
+		Carbohydrates: f.Carbohydrates * factor,
+		Sugar:         f.Sugar * factor,
+		Fiber:         f.Fiber * factor,
+		Protein:       f.Protein * factor,
+		Sodium:        f.Sodium * factor,
+	}
+}
+
+// Food is an entry of the nutrient table.
+type Food struct {
+	Name       string
+	Per100g    Facts
+	Density    float64 // grams per millilitre, 0 when the units package knows it
+	PieceGrams float64 // weight of one piece, 0 when not counted in pieces
+}
+
+// Grams returns the facts for the given weight of the food
+func (f Food) Grams(grams float64) Facts {
+	return f.Per100g.Scale(grams / 100)
+}
+
+//go:embed foods.csv
+var foodsCSV string
+
+// foods maps every normalised name and alias to its food, and foodKeys
+// lists those names longest first so "brown sugar" wins over "sugar".
+var (
+	foods    map[string]Food
+	foodKeys []string
+)
+
+func init() {
+	var err error
+	if foods, err = parseFoods(foodsCSV); err != nil {
+		panic(fmt.Sprintf("nutrition: invalid foods.csv: %v", err))
+	}
+	for key := range foods {
+		foodKeys = append(foodKeys, key)
+	}
+	sort.Slice(foodKeys, func(i, j int) bool {
+		if len(foodKeys[i]) != len(foodKeys[j]) {
+			return len(foodKeys[i]) > len(foodKeys[j])
+		}
+		return foodKeys[i] < foodKeys[j]
+	})
+}
+
+// parseFoods reads the nutrient table: the food's names separated by "|",
+// then kcal, fat, saturated fat, carbohydrates, sugar, fiber, protein and
+// sodium per 100 g, the density and the weight of a piece.
+func parseFoods(data string) (map[string]Food, error) {
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	table := make(map[string]Food)
+	for i, record := range records {
+		if i == 0 {
+			continue // header
+		}
+		if len(record) != 11 {
+			return nil, fmt.Errorf("line %d: expected 11 fields, got %d", i+1, len(record))
+		}
+
+		values := make([]float64, 10)
+		for j, field := range record[1:] {
+			if field == "" {
+				continue
+			}
+			if values[j], err = strconv.ParseFloat(field, 64); err != nil {
+				return nil, fmt.Errorf("line %d: %v", i+1, err)
+			}
+		}
+
+		names := strings.Split(record[0], "|")
+		food := Food{
+			Name: names[0],
+			Per100g: Facts{
+				Calories:      values[0],
+				Fat:           values[1],
+				SaturatedFat:  values[2],
+				Carbohydrates: values[3],
+				Sugar:         values[4],
+				Fiber:         values[5],
+				Protein:       values[6],
+				Sodium:        values[7],
+			},
+			Density:    values[8],
+			PieceGrams: values[9],
+		}
+		for _, name := range names {
+			table[Normalize(name)] = food
+		}
+	}
+	return table, nil
+}
+
+// Normalize turns an ingredient name into the form the table is keyed by:
+// lower case words without punctuation, each made singular
+// ("Red Onions, chopped" → "red onion chopped").
+func Normalize(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-'
+	})
+	for i, word := range words {
+		words[i] = singular(word)
+	}
+	return strings.Join(words, " ")
+}
+
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && len(word) > 3:
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// Lookup finds the food an ingredient name refers to, matching the most
+// specific name in the table it mentions ("2 large free-range eggs" → egg).
+func Lookup(ingredient string) (Food, bool) {
+	name := " " + Normalize(ingredient) + " "
+	for _, key := range foodKeys {
+		if strings.Contains(name, " "+key+" ") {
+			return foods[key], true
+		}
+	}
+	return Food{}, false
+}
@@ -0,0 +1,36 @@
+package nutrition
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		ingredient string
+		want       string
+	}{
+		{"2 large free-range Eggs", "egg"},
+		{"Light brown sugar", "brown sugar"},
+		{"peanut butter", "peanut butter"},
+		{"buttermilk", "buttermilk"},
+		{"chopped tomatoes", "canned tomato"},
+		{"Red Onions, finely chopped", "onion"},
+		{"low-sodium chicken stock", "stock"},
+	}
+	for _, tt := range tests {
+		food, ok := Lookup(tt.ingredient)
+		if !ok || food.Name != tt.want {
+			t.Errorf("Lookup(%q) = %q, %v; want %q", tt.ingredient, food.Name, ok, tt.want)
+		}
+	}
+
+	if _, ok := Lookup("saffron threads"); ok {
+		t.Error("expected saffron to be unknown")
+	}
+}
+
+func TestFacts(t *testing.T) {
+	egg, _ := Lookup("egg")
+	got := egg.Grams(50).Add(egg.Grams(50))
+	if got.Calories != 143 || got.Protein != 12.6 {
+		t.Errorf("two 50 g eggs = %+v", got)
+	}
+}
@@ -1,6 +1,7 @@
 package scrape
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -16,8 +17,10 @@ type Scraper interface {
 	Cuisine() ([]string, bool)
 	Description() (string, bool)
 	ImageURL() (string, bool)
+	IngredientGroups() ([]IngredientGroup, bool)
 	Ingredients() ([]string, bool)
 	Instructions() ([]string, bool)
+	Keywords() ([]string, bool)
 	Language() (string, bool)
 	Name() (string, bool)
 	Nutrition() (recipe.Nutrition, bool)
@@ -69,6 +72,14 @@ func (a *adapter) ImageURL() (string, bool) {
 	return strings.TrimSpace(a.j.Image), a.j.Image != ""
 }
 
+func (a *adapter) IngredientGroups() ([]IngredientGroup, bool) {
+	// A single group without a purpose is just the flat ingredient list
+	if len(a.j.IngredientGroups) == 0 || (len(a.j.IngredientGroups) == 1 && a.j.IngredientGroups[0].Purpose == nil) {
+		return nil, false
+	}
+	return a.j.IngredientGroups, true
+}
+
 func (a *adapter) Ingredients() ([]string, bool) {
 	if len(a.j.Ingredients) == 0 {
 		return nil, false
@@ -93,6 +104,16 @@ func (a *adapter) Instructions() ([]string, bool) {
 	return out, len(out) > 0
 }
 
+func (a *adapter) Keywords() ([]string, bool) {
+	var keywords []string
+	for _, k := range strings.Split(string(a.j.Keywords), ",") {
+		if t := strings.TrimSpace(k); t != "" {
+			keywords = append(keywords, t)
+		}
+	}
+	return keywords, len(keywords) > 0
+}
+
 func (a *adapter) Language() (string, bool) {
 	return strings.TrimSpace(a.j.Language), a.j.Language != ""
 }
@@ -102,7 +123,13 @@ func (a *adapter) Name() (string, bool) {
 }
 
 func (a *adapter) Nutrition() (recipe.Nutrition, bool) {
-	return recipe.Nutrition{}, false
+	nutrients := make(map[string]string, len(a.j.Nutrients))
+	for key, value := range a.j.Nutrients {
+		if value != nil {
+			nutrients[key] = strings.TrimSpace(fmt.Sprint(value))
+		}
+	}
+	return nutritionFromMap(nutrients)
 }
 
 func (a *adapter) PrepTime() (time.Duration, bool) {
@@ -113,7 +140,13 @@ func (a *adapter) PrepTime() (time.Duration, bool) {
 }
 
 func (a *adapter) SuitableDiets() ([]recipe.Diet, bool) {
-	return nil, false
+	var diets []recipe.Diet
+	for _, text := range strings.Split(string(a.j.DietaryRestrictions), ",") {
+		if diet, ok := dietFromText(strings.TrimSpace(text)); ok {
+			diets = append(diets, diet)
+		}
+	}
+	return diets, len(diets) > 0
 }
 
 func (a *adapter) TotalTime() (time.Duration, bool) {
@@ -0,0 +1,52 @@
+package scrape
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kkyr/go-recipe"
+)
+
+const recipeScrapersOutput = `{
+  "title": "Pizza",
+  "image": "https://example.com/pizza.jpg",
+  "language": "en-US",
+  "keywords": ["pizza", "dinner"],
+  "dietary_restrictions": "Vegetarian Diet, Unknown Diet",
+  "nutrients": {"calories": "300 kcal", "proteinContent": "12 g", "servingSize": "1 slice"},
+  "ingredients": ["500 g flour", "1 cup tomato sauce"],
+  "ingredient_groups": [
+    {"ingredients": ["500 g flour"], "purpose": "For the dough"},
+    {"ingredients": ["1 cup tomato sauce"], "purpose": "For the topping"}
+  ]
+}`
+
+func TestAdapterExtendedFields(t *testing.T) {
+	var j RecipeScrapersJSON
+	if err := json.Unmarshal([]byte(recipeScrapersOutput), &j); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	a := &adapter{j: j}
+
+	if keywords, _ := a.Keywords(); !reflect.DeepEqual(keywords, []string{"pizza", "dinner"}) {
+		t.Errorf("Keywords() = %q", keywords)
+	}
+	if diets, _ := a.SuitableDiets(); !reflect.DeepEqual(diets, []recipe.Diet{recipe.VegetarianDiet}) {
+		t.Errorf("SuitableDiets() = %v", diets)
+	}
+
+	nutrition, ok := a.Nutrition()
+	if !ok || nutrition.Calories != 300 || nutrition.ProteinGrams != 12 || nutrition.ServingSize != "1 slice" {
+		t.Errorf("Nutrition() = %+v, %v", nutrition, ok)
+	}
+	want := map[string]string{"calories": "300 kcal", "proteinContent": "12 g", "servingSize": "1 slice"}
+	if got := NutrientMap(nutrition); !reflect.DeepEqual(got, want) {
+		t.Errorf("NutrientMap() = %v, want %v", got, want)
+	}
+
+	groups, ok := a.IngredientGroups()
+	if !ok || len(groups) != 2 || *groups[1].Purpose != "For the topping" {
+		t.Errorf("IngredientGroups() = %+v, %v", groups, ok)
+	}
+}
@@ -0,0 +1,168 @@
+package scrape
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Built-in backend names
+const (
+	BackendJSONLD         = "jsonld"
+	BackendRecipeScrapers = "recipe-scrapers"
+)
+
+// DefaultBackends is the backend order used when none is configured.
+var DefaultBackends = []string{BackendJSONLD, BackendRecipeScrapers}
+
+// Options are passed to every backend.
+type Options struct {
+	PythonPath string // optional path to Python for recipe-scrapers
+}
+
+// Backend turns a recipe URL into a Scraper.
+type Backend interface {
+	Name() string
+	Scrape(url string, opts Options) (Scraper, error)
+}
+
+var (
+	backends   = map[string]Backend{}
+	backendsMu sync.RWMutex
+)
+
+func init() {
+	RegisterBackend(jsonLDBackend{})
+	RegisterBackend(recipeScrapersBackend{})
+}
+
+// RegisterBackend adds a backend to the registry, replacing any backend with the same name.
+func RegisterBackend(b Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[b.Name()] = b
+}
+
+// GetBackend returns the registered backend with the given name.
+func GetBackend(name string) (Backend, bool) {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	b, ok := backends[name]
+	return b, ok
+}
+
+// BackendNames returns the names of all registered backends, sorted.
+func BackendNames() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BackendsForURL returns the backend order for a URL. hostBackends maps a host
+// ("www.example.com", "example.com" or ".example.com" for all sub-domains) to
+// its own order; otherwise order is used, or DefaultBackends when empty.
+func BackendsForURL(rawURL string, order []string, hostBackends map[string][]string) []string {
+	if u, err := url.Parse(rawURL); err == nil && len(hostBackends) > 0 {
+		host := strings.ToLower(u.Hostname())
+		candidates := []string{host, strings.TrimPrefix(host, "www.")}
+		labels := strings.Split(host, ".")
+		for i := 0; i < len(labels)-1; i++ {
+			candidates = append(candidates, "."+strings.Join(labels[i:], "."))
+		}
+		for _, candidate := range candidates {
+			if hostOrder, ok := hostBackends[candidate]; ok && len(hostOrder) > 0 {
+				return hostOrder
+			}
+		}
+	}
+	if len(order) > 0 {
+		return order
+	}
+	return DefaultBackends
+}
+
+// BackendAttempt records the outcome of one backend.
+type BackendAttempt struct {
+	Backend string
+	Err     error
+}
+
+// Result is a successful scrape together with the backends that were tried.
+type Result struct {
+	Scraper  Scraper
+	Backend  string
+	Attempts []BackendAttempt
+}
+
+// Error is returned when every backend failed.
+type Error struct {
+	Attempts []BackendAttempt
+}
+
+func (e *Error) Error() string {
+	if len(e.Attempts) == 0 {
+		return "no scraper backends configured"
+	}
+	parts := make([]string, 0, len(e.Attempts))
+	for _, a := range e.Attempts {
+		parts = append(parts, fmt.Sprintf("%s: %v", a.Backend, a.Err))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Scrape tries each backend in order and returns the first success. Failed
+// attempts are kept in the result (or the returned *Error) for reporting.
+func Scrape(url string, order []string, opts Options) (*Result, error) {
+	var attempts []BackendAttempt
+	for _, name := range order {
+		backend, ok := GetBackend(name)
+		if !ok {
+			attempts = append(attempts, BackendAttempt{Backend: name, Err: fmt.Errorf("unknown scraper backend (available: %s)", strings.Join(BackendNames(), ", "))})
+			continue
+		}
+
+		scraper, err := backend.Scrape(url, opts)
+		if err == nil {
+			slog.Info("Recipe scraped", "url", url, "backend", name)
+			return &Result{Scraper: scraper, Backend: name, Attempts: attempts}, nil
+		}
+		slog.Warn("Scraper backend failed", "url", url, "backend", name, "error", err)
+		attempts = append(attempts, BackendAttempt{Backend: name, Err: err})
+	}
+	return nil, &Error{Attempts: attempts}
+}
+
+// jsonLDBackend downloads the page and reads its schema.org JSON-LD or microdata.
+type jsonLDBackend struct{}
+
+func (jsonLDBackend) Name() string { return BackendJSONLD }
+
+func (jsonLDBackend) Scrape(url string, _ Options) (Scraper, error) {
+	return ScrapeHTMLURL(url)
+}
+
+// recipeScrapersBackend runs the Python recipe-scrapers package.
+type recipeScrapersBackend struct{}
+
+func (recipeScrapersBackend) Name() string { return BackendRecipeScrapers }
+
+func (recipeScrapersBackend) Scrape(url string, opts Options) (Scraper, error) {
+	raw, err := ScrapeURLRaw(url, opts.PythonPath)
+	if err != nil {
+		return nil, err
+	}
+	var j RecipeScrapersJSON
+	if err := json.Unmarshal([]byte(raw), &j); err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	return &adapter{j: j}, nil
+}
@@ -0,0 +1,73 @@
+package scrape
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fakeBackend struct {
+	name string
+	err  error
+}
+
+func (b fakeBackend) Name() string { return b.name }
+
+func (b fakeBackend) Scrape(url string, _ Options) (Scraper, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return ScrapeHTML(strings.NewReader(ldJSONPage), url)
+}
+
+func TestBackendsForURL(t *testing.T) {
+	order := []string{"a", "b"}
+	hosts := map[string][]string{
+		"example.com":   {"host"},
+		".example.org":  {"suffix"},
+		"www.other.net": {"exact"},
+	}
+
+	tests := []struct {
+		url  string
+		want []string
+	}{
+		{"https://www.example.com/recipe", []string{"host"}},
+		{"https://blog.example.org/recipe", []string{"suffix"}},
+		{"https://www.other.net/recipe", []string{"exact"}},
+		{"https://other.net/recipe", order},
+		{"not a url", order},
+	}
+	for _, tt := range tests {
+		if got := BackendsForURL(tt.url, order, hosts); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("BackendsForURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+
+	if got := BackendsForURL("https://example.com", nil, nil); !reflect.DeepEqual(got, DefaultBackends) {
+		t.Errorf("BackendsForURL with no config = %v, want %v", got, DefaultBackends)
+	}
+}
+
+func TestScrapeFallsBackToNextBackend(t *testing.T) {
+	RegisterBackend(fakeBackend{name: "test-broken", err: errors.New("boom")})
+	RegisterBackend(fakeBackend{name: "test-ok"})
+
+	result, err := Scrape("https://example.com/recipes/pancakes", []string{"test-missing", "test-broken", "test-ok"}, Options{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if result.Backend != "test-ok" {
+		t.Errorf("Backend = %q, want test-ok", result.Backend)
+	}
+	if len(result.Attempts) != 2 || result.Attempts[0].Backend != "test-missing" || result.Attempts[1].Backend != "test-broken" {
+		t.Errorf("Attempts = %+v", result.Attempts)
+	}
+
+	_, err = Scrape("https://example.com", []string{"test-broken"}, Options{})
+	var scrapeErr *Error
+	if !errors.As(err, &scrapeErr) || len(scrapeErr.Attempts) != 1 {
+		t.Fatalf("expected *Error with one attempt, got %v", err)
+	}
+}
@@ -0,0 +1,608 @@
+package scrape
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/kkyr/go-recipe"
+	"golang.org/x/net/html"
+)
+
+const (
+	htmlFetchTimeout = 30 * time.Second
+	htmlMaxBytes     = 10 << 20
+	htmlUserAgent    = "Mozilla/5.0 (compatible; yummy recipe importer)"
+)
+
+// htmlScraper implements Scraper over a schema.org Recipe embedded in an HTML
+// page, either as an application/ld+json block or as microdata.
+type htmlScraper struct {
+	node      utils.SchemaOrgNode
+	groups    []IngredientGroup
+	pageURL   string
+	canonical string
+	lang      string
+}
+
+// ScrapeHTML reads an HTML page and returns a Scraper for the first schema.org
+// Recipe it contains. pageURL is optional and only used for CanonicalURL/Host.
+func ScrapeHTML(r io.Reader, pageURL string) (Scraper, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("html: %w", err)
+	}
+
+	s := &htmlScraper{pageURL: pageURL}
+	var microdata utils.SchemaOrgNode
+	walkHTML(doc, func(n *html.Node) bool {
+		switch {
+		case n.Data == "html":
+			s.lang = htmlAttr(n, "lang")
+		case n.Data == "link" && strings.EqualFold(htmlAttr(n, "rel"), "canonical"):
+			s.canonical = htmlAttr(n, "href")
+		case n.Data == "meta" && htmlAttr(n, "property") == "og:url" && s.canonical == "":
+			s.canonical = htmlAttr(n, "content")
+		case n.Data == "script" && strings.EqualFold(htmlAttr(n, "type"), "application/ld+json"):
+			if s.node == nil {
+				s.node = parseLDJSON(textContent(n, false))
+			}
+			return false
+		case hasHTMLAttr(n, "itemscope") && isRecipeItemType(htmlAttr(n, "itemtype")):
+			if microdata == nil {
+				microdata = microdataItem(n)
+			}
+			return false
+		}
+		return true
+	})
+
+	if s.node == nil {
+		s.node = microdata
+	}
+	if s.node == nil {
+		return nil, fmt.Errorf("no recipe found in page (no schema.org Recipe JSON-LD or microdata)")
+	}
+	s.groups = matchIngredientGroups(htmlIngredientGroups(doc), s.node.Ingredients())
+	return s, nil
+}
+
+// ScrapeHTMLFile returns a Scraper for a saved HTML page
+func ScrapeHTMLFile(path string) (Scraper, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return ScrapeHTML(f, "")
+}
+
+// ScrapeHTMLURL downloads a page and scrapes it without Python
+func ScrapeHTMLURL(pageURL string) (Scraper, error) {
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", htmlUserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	client := &http.Client{Timeout: htmlFetchTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: %s", pageURL, resp.Status)
+	}
+	return ScrapeHTML(io.LimitReader(resp.Body, htmlMaxBytes), pageURL)
+}
+
+// ParseHTMLRecipeFile converts the schema.org Recipe in a saved HTML page into
+// a RecipeRaw, parsing ingredients with the regex parser.
+func ParseHTMLRecipeFile(path string, customName string) (*utils.RecipeRaw, error) {
+	s, err := ScrapeHTMLFile(path)
+	if err != nil {
+		return nil, err
+	}
+	hs := s.(*htmlScraper)
+
+	recipeData := utils.RecipeFromSchemaOrg(hs.node)
+	if recipeData.Metadata.URL == "" {
+		recipeData.Metadata.URL = hs.canonical
+	}
+	if recipeData.Metadata.Language == "" {
+		recipeData.Metadata.Language = hs.lang
+	}
+	if customName != "" {
+		recipeData.RecipeName = customName
+	}
+	applyIngredientGroups(recipeData.Metadata.Ingredients, hs.groups)
+	return recipeData, nil
+}
+
+// parseLDJSON decodes a JSON-LD block and returns its Recipe node, if any.
+func parseLDJSON(text string) utils.SchemaOrgNode {
+	text = strings.TrimSpace(text)
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "<!--"), "-->")
+
+	var doc any
+	if err := json.Unmarshal([]byte(text), &doc); err != nil {
+		return nil
+	}
+	node, _ := utils.FindSchemaOrgRecipe(doc)
+	return node
+}
+
+func isRecipeItemType(itemType string) bool {
+	for _, t := range strings.Fields(itemType) {
+		if strings.HasSuffix(strings.TrimSuffix(t, "/"), "schema.org/Recipe") {
+			return true
+		}
+	}
+	return false
+}
+
+// microdataItem collects the itemprop values below an itemscope element into
+// a schema.org node. Nested itemscopes become nested nodes.
+func microdataItem(item *html.Node) map[string]any {
+	node := map[string]any{}
+	if itemType := strings.Fields(htmlAttr(item, "itemtype")); len(itemType) > 0 {
+		node["@type"] = itemType[0][strings.LastIndex(itemType[0], "/")+1:]
+	}
+
+	for c := item.FirstChild; c != nil; c = c.NextSibling {
+		walkHTML(c, func(n *html.Node) bool {
+			props := strings.Fields(htmlAttr(n, "itemprop"))
+			nested := hasHTMLAttr(n, "itemscope")
+			if len(props) > 0 {
+				var value any
+				if nested {
+					value = microdataItem(n)
+				} else {
+					value = microdataValue(n)
+				}
+				for _, prop := range props {
+					appendMicrodata(node, prop, value)
+				}
+			}
+			return !nested
+		})
+	}
+	return node
+}
+
+func appendMicrodata(node map[string]any, prop string, value any) {
+	switch existing := node[prop].(type) {
+	case nil:
+		node[prop] = value
+	case []any:
+		node[prop] = append(existing, value)
+	default:
+		node[prop] = []any{existing, value}
+	}
+}
+
+// microdataValue returns the value of an itemprop element per the microdata spec.
+func microdataValue(n *html.Node) string {
+	switch n.Data {
+	case "meta":
+		return htmlAttr(n, "content")
+	case "a", "link", "area":
+		return htmlAttr(n, "href")
+	case "img", "audio", "video", "source", "iframe", "embed":
+		return htmlAttr(n, "src")
+	case "time":
+		if dt := htmlAttr(n, "datetime"); dt != "" {
+			return dt
+		}
+	case "data", "meter":
+		return htmlAttr(n, "value")
+	}
+	if content := htmlAttr(n, "content"); content != "" {
+		return content
+	}
+	return textContent(n, true)
+}
+
+var htmlBlockElements = map[string]bool{
+	"p": true, "li": true, "br": true, "div": true, "ol": true, "ul": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// textContent concatenates the text below n. With blocks set, block-level
+// elements are separated by newlines so steps can be split later.
+func textContent(n *html.Node, blocks bool) string {
+	var b bytes.Buffer
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+			return
+		}
+		if n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style") && blocks {
+			return
+		}
+		isBlock := blocks && n.Type == html.ElementNode && htmlBlockElements[n.Data]
+		if isBlock {
+			b.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if isBlock {
+			b.WriteString("\n")
+		}
+	}
+	walk(n)
+
+	if !blocks {
+		return b.String()
+	}
+	lines := strings.Split(b.String(), "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// ingredientGroupContainers are the classes of the ingredient lists of common
+// recipe plugins, whose headings split the ingredients into groups
+var ingredientGroupContainers = []string{
+	"wprm-recipe-ingredients-container", // WP Recipe Maker
+	"tasty-recipes-ingredients",         // Tasty Recipes
+	"mv-create-ingredients",             // Mediavine Create
+}
+
+var htmlHeadingElements = map[string]bool{
+	"h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// htmlIngredientGroups reads the ingredient list of a recipe plugin: each
+// heading starts a group of the list items after it. Items before the first
+// heading form a group without a purpose.
+func htmlIngredientGroups(doc *html.Node) []IngredientGroup {
+	var container *html.Node
+	walkHTML(doc, func(n *html.Node) bool {
+		if container != nil {
+			return false
+		}
+		for _, class := range ingredientGroupContainers {
+			if hasHTMLClass(n, class) {
+				container = n
+				return false
+			}
+		}
+		return true
+	})
+	if container == nil {
+		return nil
+	}
+
+	var groups []IngredientGroup
+	walkHTML(container, func(n *html.Node) bool {
+		switch {
+		case htmlHeadingElements[n.Data] || hasHTMLClass(n, "wprm-recipe-group-name"):
+			purpose := strings.Join(strings.Fields(textContent(n, false)), " ")
+			// The title of the whole list is not a group
+			if !strings.EqualFold(strings.TrimSuffix(purpose, ":"), "ingredients") {
+				groups = append(groups, IngredientGroup{Purpose: &purpose})
+			}
+			return false
+		case n.Data == "li":
+			if len(groups) == 0 {
+				groups = append(groups, IngredientGroup{})
+			}
+			last := &groups[len(groups)-1]
+			last.Ingredients = append(last.Ingredients, strings.Join(strings.Fields(textContent(n, false)), " "))
+			return false
+		}
+		return true
+	})
+	return groups
+}
+
+// matchIngredientGroups returns groups only when they name at least one
+// group. When they hold as many ingredients as the recipe, the recipe's
+// ingredient lines are used, in order, since the plugin markup may carry
+// extra text such as checkboxes or notes.
+func matchIngredientGroups(groups []IngredientGroup, ingredients []string) []IngredientGroup {
+	named, count := false, 0
+	for _, group := range groups {
+		if len(group.Ingredients) > 0 && group.Purpose != nil && *group.Purpose != "" {
+			named = true
+		}
+		count += len(group.Ingredients)
+	}
+	if !named || count == 0 {
+		return nil
+	}
+
+	matched := make([]IngredientGroup, 0, len(groups))
+	i := 0
+	for _, group := range groups {
+		if len(group.Ingredients) == 0 {
+			continue
+		}
+		if count == len(ingredients) {
+			group.Ingredients = ingredients[i : i+len(group.Ingredients)]
+			i += len(group.Ingredients)
+		}
+		matched = append(matched, group)
+	}
+	return matched
+}
+
+// applyIngredientGroups tags parsed ingredients with the purpose of their
+// group, when there is one ingredient per group line
+func applyIngredientGroups(ingredients []utils.Ingredient, groups []IngredientGroup) {
+	var purposes []string
+	for _, group := range groups {
+		purpose := ""
+		if group.Purpose != nil {
+			purpose = *group.Purpose
+		}
+		for range group.Ingredients {
+			purposes = append(purposes, purpose)
+		}
+	}
+	if len(purposes) != len(ingredients) {
+		return
+	}
+	for i := range ingredients {
+		ingredients[i].Group = purposes[i]
+	}
+}
+
+// walkHTML visits element nodes depth-first; returning false skips children.
+func walkHTML(n *html.Node, visit func(*html.Node) bool) {
+	if n.Type == html.ElementNode && !visit(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkHTML(c, visit)
+	}
+}
+
+func htmlAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+func hasHTMLClass(n *html.Node, class string) bool {
+	return slices.Contains(strings.Fields(htmlAttr(n, "class")), class)
+}
+
+func hasHTMLAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *htmlScraper) Author() (string, bool) {
+	author := strings.Join(s.node.Texts("author", false), ", ")
+	return author, author != ""
+}
+
+func (s *htmlScraper) CanonicalURL() (string, bool) {
+	for _, u := range []string{s.node.Text("url"), s.canonical, s.pageURL} {
+		if u != "" {
+			return u, true
+		}
+	}
+	return "", false
+}
+
+func (s *htmlScraper) Host() (string, bool) {
+	canonical, ok := s.CanonicalURL()
+	if !ok {
+		return "", false
+	}
+	u, err := url.Parse(canonical)
+	if err != nil || u.Hostname() == "" {
+		return "", false
+	}
+	return u.Hostname(), true
+}
+
+func (s *htmlScraper) Categories() ([]string, bool) {
+	categories := s.node.Texts("recipeCategory", true)
+	return categories, len(categories) > 0
+}
+
+func (s *htmlScraper) CookTime() (time.Duration, bool) {
+	d := s.node.Duration("cookTime")
+	return d, d > 0
+}
+
+func (s *htmlScraper) Cuisine() ([]string, bool) {
+	cuisines := s.node.Texts("recipeCuisine", true)
+	return cuisines, len(cuisines) > 0
+}
+
+func (s *htmlScraper) Description() (string, bool) {
+	desc := s.node.Text("description")
+	return desc, desc != ""
+}
+
+func (s *htmlScraper) ImageURL() (string, bool) {
+	image := s.node.Image()
+	return image, image != ""
+}
+
+// IngredientGroups returns the groups of the page's ingredient list.
+// schema.org has no ingredient groups, so they are read from the markup of
+// the recipe plugins in ingredientGroupContainers.
+func (s *htmlScraper) IngredientGroups() ([]IngredientGroup, bool) {
+	return s.groups, len(s.groups) > 0
+}
+
+func (s *htmlScraper) Ingredients() ([]string, bool) {
+	ingredients := s.node.Ingredients()
+	return ingredients, len(ingredients) > 0
+}
+
+func (s *htmlScraper) Instructions() ([]string, bool) {
+	steps := s.node.Instructions()
+	return steps, len(steps) > 0
+}
+
+func (s *htmlScraper) Keywords() ([]string, bool) {
+	keywords := s.node.Texts("keywords", true)
+	return keywords, len(keywords) > 0
+}
+
+func (s *htmlScraper) Language() (string, bool) {
+	if lang := s.node.Text("inLanguage"); lang != "" {
+		return lang, true
+	}
+	return s.lang, s.lang != ""
+}
+
+func (s *htmlScraper) Name() (string, bool) {
+	name := s.node.Text("name")
+	return name, name != ""
+}
+
+var leadingNumberRe = regexp.MustCompile(`[\d]+(?:[.,]\d+)?`)
+
+// nutrientValue extracts the number from values such as "420 kcal" or "12 g".
+func nutrientValue(text string) float32 {
+	match := leadingNumberRe.FindString(text)
+	if match == "" {
+		return 0
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(match, ",", "."), 32)
+	if err != nil {
+		return 0
+	}
+	return float32(value)
+}
+
+func (s *htmlScraper) Nutrition() (recipe.Nutrition, bool) {
+	return nutritionFromMap(s.node.Nutrition())
+}
+
+// nutritionFromMap converts schema.org NutritionInformation properties
+// ("proteinContent" → "7 g") into a recipe.Nutrition.
+func nutritionFromMap(nutrients map[string]string) (recipe.Nutrition, bool) {
+	if len(nutrients) == 0 {
+		return recipe.Nutrition{}, false
+	}
+	return recipe.Nutrition{
+		Calories:              nutrientValue(nutrients["calories"]),
+		CarbohydrateGrams:     nutrientValue(nutrients["carbohydrateContent"]),
+		CholesterolMilligrams: nutrientValue(nutrients["cholesterolContent"]),
+		FatGrams:              nutrientValue(nutrients["fatContent"]),
+		FiberGrams:            nutrientValue(nutrients["fiberContent"]),
+		ProteinGrams:          nutrientValue(nutrients["proteinContent"]),
+		SaturatedFatGrams:     nutrientValue(nutrients["saturatedFatContent"]),
+		ServingSize:           nutrients["servingSize"],
+		SodiumMilligrams:      nutrientValue(nutrients["sodiumContent"]),
+		SugarGrams:            nutrientValue(nutrients["sugarContent"]),
+		TransFatGrams:         nutrientValue(nutrients["transFatContent"]),
+		UnsaturatedFatGrams:   nutrientValue(nutrients["unsaturatedFatContent"]),
+	}, true
+}
+
+// NutrientMap is the inverse of nutritionFromMap: it renders the non-zero
+// values of n as schema.org properties with units (e.g. "proteinContent" → "7 g").
+func NutrientMap(n recipe.Nutrition) map[string]string {
+	nutrients := map[string]string{}
+	add := func(key string, value float32, unit string) {
+		if value > 0 {
+			nutrients[key] = strconv.FormatFloat(float64(value), 'f', -1, 32) + " " + unit
+		}
+	}
+	add("calories", n.Calories, "kcal")
+	add("carbohydrateContent", n.CarbohydrateGrams, "g")
+	add("cholesterolContent", n.CholesterolMilligrams, "mg")
+	add("fatContent", n.FatGrams, "g")
+	add("fiberContent", n.FiberGrams, "g")
+	add("proteinContent", n.ProteinGrams, "g")
+	add("saturatedFatContent", n.SaturatedFatGrams, "g")
+	add("sodiumContent", n.SodiumMilligrams, "mg")
+	add("sugarContent", n.SugarGrams, "g")
+	add("transFatContent", n.TransFatGrams, "g")
+	add("unsaturatedFatContent", n.UnsaturatedFatGrams, "g")
+	if n.ServingSize != "" {
+		nutrients["servingSize"] = n.ServingSize
+	}
+	if len(nutrients) == 0 {
+		return nil
+	}
+	return nutrients
+}
+
+func (s *htmlScraper) PrepTime() (time.Duration, bool) {
+	d := s.node.Duration("prepTime")
+	return d, d > 0
+}
+
+var schemaOrgDiets = map[string]recipe.Diet{
+	"DiabeticDiet":   recipe.DiabeticDiet,
+	"GlutenFreeDiet": recipe.GlutenFreeDiet,
+	"HalalDiet":      recipe.HalalDiet,
+	"HinduDiet":      recipe.HinduDiet,
+	"KosherDiet":     recipe.KosherDiet,
+	"LowCalorieDiet": recipe.LowCalorieDiet,
+	"LowFatDiet":     recipe.LowFatDiet,
+	"LowLactoseDiet": recipe.LowLactoseDiet,
+	"LowSaltDiet":    recipe.LowSaltDiet,
+	"VeganDiet":      recipe.VeganDiet,
+	"VegetarianDiet": recipe.VegetarianDiet,
+}
+
+// dietFromText maps "https://schema.org/VeganDiet", "VeganDiet" or "Vegan Diet" to a recipe.Diet.
+func dietFromText(text string) (recipe.Diet, bool) {
+	name := strings.Join(strings.Fields(text[strings.LastIndex(text, "/")+1:]), "")
+	if !strings.HasSuffix(name, "Diet") {
+		name += "Diet"
+	}
+	diet, ok := schemaOrgDiets[name]
+	return diet, ok
+}
+
+func (s *htmlScraper) SuitableDiets() ([]recipe.Diet, bool) {
+	var diets []recipe.Diet
+	for _, text := range s.node.Texts("suitableForDiet", false) {
+		if diet, ok := dietFromText(text); ok {
+			diets = append(diets, diet)
+		}
+	}
+	return diets, len(diets) > 0
+}
+
+func (s *htmlScraper) TotalTime() (time.Duration, bool) {
+	if d := s.node.Duration("totalTime"); d > 0 {
+		return d, true
+	}
+	d := s.node.Duration("prepTime") + s.node.Duration("cookTime")
+	return d, d > 0
+}
+
+func (s *htmlScraper) Yields() (string, bool) {
+	yields := s.node.Yield()
+	return yields, yields != ""
+}
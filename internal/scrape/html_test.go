@@ -0,0 +1,204 @@
+package scrape
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const ldJSONPage = `<!doctype html>
+<html lang="en">
+<head>
+  <link rel="canonical" href="https://example.com/recipes/pancakes">
+  <script type="application/ld+json">{"@context": "https://schema.org", "@type": "WebSite", "name": "Example"}</script>
+  <script type="application/ld+json">
+  {
+    "@context": "https://schema.org",
+    "@type": "Recipe",
+    "name": "Fluffy Pancakes",
+    "image": {"@type": "ImageObject", "url": "https://example.com/pancakes.jpg"},
+    "recipeYield": "4",
+    "cookTime": "PT15M",
+    "recipeIngredient": ["2 cups flour", "2 eggs"],
+    "recipeInstructions": [{"@type": "HowToStep", "text": "Mix."}, {"@type": "HowToStep", "text": "Fry."}],
+    "nutrition": {"@type": "NutritionInformation", "calories": "250 kcal", "proteinContent": "7 g"},
+    "suitableForDiet": "https://schema.org/VegetarianDiet"
+  }
+  </script>
+</head>
+<body><h1>Fluffy Pancakes</h1></body>
+</html>`
+
+const microdataPage = `<html lang="de">
+<body>
+<div itemscope itemtype="https://schema.org/Recipe">
+  <h1 itemprop="name">Kartoffelsalat</h1>
+  <span itemprop="author" itemscope itemtype="https://schema.org/Person"><span itemprop="name">Oma</span></span>
+  <meta itemprop="prepTime" content="PT20M">
+  <time itemprop="cookTime" datetime="PT25M">25 minutes</time>
+  <span itemprop="recipeCategory">Salad</span>
+  <ul>
+    <li itemprop="recipeIngredient">1 kg potatoes</li>
+    <li itemprop="recipeIngredient">2 tbsp vinegar</li>
+  </ul>
+  <div itemprop="recipeInstructions">
+    <p>Boil the potatoes.</p>
+    <p>Slice and dress while warm.</p>
+  </div>
+</div>
+</body>
+</html>`
+
+func TestScrapeHTMLJSONLD(t *testing.T) {
+	s, err := ScrapeHTML(strings.NewReader(ldJSONPage), "")
+	if err != nil {
+		t.Fatalf("ScrapeHTML: %v", err)
+	}
+
+	if name, _ := s.Name(); name != "Fluffy Pancakes" {
+		t.Errorf("Name() = %q", name)
+	}
+	if host, _ := s.Host(); host != "example.com" {
+		t.Errorf("Host() = %q", host)
+	}
+	if image, _ := s.ImageURL(); image != "https://example.com/pancakes.jpg" {
+		t.Errorf("ImageURL() = %q", image)
+	}
+	if cook, _ := s.CookTime(); cook != 15*time.Minute {
+		t.Errorf("CookTime() = %v", cook)
+	}
+	if steps, _ := s.Instructions(); !reflect.DeepEqual(steps, []string{"Mix.", "Fry."}) {
+		t.Errorf("Instructions() = %q", steps)
+	}
+	if nutrition, ok := s.Nutrition(); !ok || nutrition.Calories != 250 || nutrition.ProteinGrams != 7 {
+		t.Errorf("Nutrition() = %+v, %v", nutrition, ok)
+	}
+	if diets, ok := s.SuitableDiets(); !ok || len(diets) != 1 {
+		t.Errorf("SuitableDiets() = %v, %v", diets, ok)
+	}
+	if lang, _ := s.Language(); lang != "en" {
+		t.Errorf("Language() = %q", lang)
+	}
+}
+
+func TestScrapeHTMLMicrodata(t *testing.T) {
+	s, err := ScrapeHTML(strings.NewReader(microdataPage), "https://example.de/salat")
+	if err != nil {
+		t.Fatalf("ScrapeHTML: %v", err)
+	}
+
+	if name, _ := s.Name(); name != "Kartoffelsalat" {
+		t.Errorf("Name() = %q", name)
+	}
+	if author, _ := s.Author(); author != "Oma" {
+		t.Errorf("Author() = %q", author)
+	}
+	if total, _ := s.TotalTime(); total != 45*time.Minute {
+		t.Errorf("TotalTime() = %v", total)
+	}
+	if ingredients, _ := s.Ingredients(); !reflect.DeepEqual(ingredients, []string{"1 kg potatoes", "2 tbsp vinegar"}) {
+		t.Errorf("Ingredients() = %q", ingredients)
+	}
+	want := []string{"Boil the potatoes.", "Slice and dress while warm."}
+	if steps, _ := s.Instructions(); !reflect.DeepEqual(steps, want) {
+		t.Errorf("Instructions() = %q, want %q", steps, want)
+	}
+	if categories, _ := s.Categories(); !reflect.DeepEqual(categories, []string{"Salad"}) {
+		t.Errorf("Categories() = %q", categories)
+	}
+	if host, _ := s.Host(); host != "example.de" {
+		t.Errorf("Host() = %q", host)
+	}
+}
+
+func TestScrapeHTMLNoRecipe(t *testing.T) {
+	_, err := ScrapeHTML(strings.NewReader("<html><body>hello</body></html>"), "")
+	if err == nil || !strings.Contains(err.Error(), "no recipe found") {
+		t.Errorf("expected no recipe found error, got %v", err)
+	}
+}
+
+// wprmPage is a WP Recipe Maker page: JSON-LD without groups, and the groups
+// in the plugin's markup
+const wprmPage = `<html><head>
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "Recipe", "name": "Chicken Tikka",
+  "recipeIngredient": ["500 g chicken thighs", "150 g yogurt", "2 tbsp butter", "400 g tomatoes"]}</script>
+</head><body>
+<div class="wprm-recipe-ingredients-container wprm-block-text-normal">
+  <h3 class="wprm-recipe-header">Ingredients</h3>
+  <div class="wprm-recipe-ingredient-group">
+    <h4 class="wprm-recipe-group-name">For the marinade</h4>
+    <ul>
+      <li class="wprm-recipe-ingredient"><span>500</span> <span>g</span> <span>chicken thighs</span> <span class="wprm-recipe-ingredient-notes">boneless</span></li>
+      <li class="wprm-recipe-ingredient"><span>150</span> <span>g</span> <span>yogurt</span></li>
+    </ul>
+  </div>
+  <div class="wprm-recipe-ingredient-group">
+    <h4 class="wprm-recipe-group-name">For the sauce</h4>
+    <ul>
+      <li class="wprm-recipe-ingredient">2 tbsp butter</li>
+      <li class="wprm-recipe-ingredient">400 g tomatoes</li>
+    </ul>
+  </div>
+</div>
+</body></html>`
+
+func TestScrapeHTMLIngredientGroups(t *testing.T) {
+	s, err := ScrapeHTML(strings.NewReader(wprmPage), "")
+	if err != nil {
+		t.Fatalf("ScrapeHTML: %v", err)
+	}
+	groups, ok := s.IngredientGroups()
+	if !ok || len(groups) != 2 {
+		t.Fatalf("IngredientGroups() = %+v, %v; want 2 groups", groups, ok)
+	}
+	// The lines come from the JSON-LD, without the notes of the markup
+	wantLines := [][]string{{"500 g chicken thighs", "150 g yogurt"}, {"2 tbsp butter", "400 g tomatoes"}}
+	for i, purpose := range []string{"For the marinade", "For the sauce"} {
+		if groups[i].Purpose == nil || *groups[i].Purpose != purpose {
+			t.Errorf("group %d purpose = %v, want %q", i, groups[i].Purpose, purpose)
+		}
+		if !reflect.DeepEqual(groups[i].Ingredients, wantLines[i]) {
+			t.Errorf("group %d ingredients = %q, want %q", i, groups[i].Ingredients, wantLines[i])
+		}
+	}
+
+	recipe := RecipeRawFromScraper(s, "https://example.com/tikka", "")
+	var got []string
+	for _, ing := range recipe.Metadata.Ingredients {
+		got = append(got, ing.Group)
+	}
+	want := []string{"For the marinade", "For the marinade", "For the sauce", "For the sauce"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ingredient groups of the recipe = %q, want %q", got, want)
+	}
+}
+
+func TestScrapeHTMLIngredientGroupsUngrouped(t *testing.T) {
+	// A plugin list whose only heading is the title of the list has no groups
+	page := `<html><head>
+<script type="application/ld+json">{"@type": "Recipe", "name": "Toast", "recipeIngredient": ["1 slice bread", "butter"]}</script>
+</head><body>
+<div class="tasty-recipes-ingredients"><h3>Ingredients</h3>
+  <div class="tasty-recipes-ingredients-body"><ul><li>1 slice bread</li><li>butter</li></ul></div>
+</div></body></html>`
+	s, err := ScrapeHTML(strings.NewReader(page), "")
+	if err != nil {
+		t.Fatalf("ScrapeHTML: %v", err)
+	}
+	if groups, ok := s.IngredientGroups(); ok {
+		t.Errorf("IngredientGroups() = %+v, want none", groups)
+	}
+
+	// Tasty Recipes groups are plain headings within the list
+	page = strings.Replace(page, `<ul><li>1 slice bread</li>`, `<h4>Toast</h4><ul><li>1 slice bread</li></ul><h4>Topping</h4><ul>`, 1)
+	s, err = ScrapeHTML(strings.NewReader(page), "")
+	if err != nil {
+		t.Fatalf("ScrapeHTML: %v", err)
+	}
+	groups, ok := s.IngredientGroups()
+	if !ok || len(groups) != 2 || *groups[1].Purpose != "Topping" || groups[1].Ingredients[0] != "butter" {
+		t.Errorf("IngredientGroups() = %+v, %v; want Toast and Topping", groups, ok)
+	}
+}
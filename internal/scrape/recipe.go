@@ -0,0 +1,194 @@
+package scrape
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/utils"
+)
+
+// FriendlyError turns a raw scrape error into a short, user-friendly message.
+func FriendlyError(err error) string {
+	msg := err.Error()
+	low := strings.ToLower(msg)
+
+	switch {
+	case strings.Contains(low, "isn't currently supported") ||
+		strings.Contains(low, "not supported"):
+		return "Can't scrape this website yet"
+	case strings.Contains(low, "no python found"):
+		return "Python 3 is needed for scraping"
+	case strings.Contains(low, "no such host") ||
+		strings.Contains(low, "dial tcp") ||
+		strings.Contains(low, "connection refused"):
+		return "Can't reach that website"
+	case strings.Contains(low, "404") || strings.Contains(low, "not found"):
+		return "Page not found"
+	case strings.Contains(low, "timeout") || strings.Contains(low, "deadline exceeded"):
+		return "Request timed out"
+	case strings.Contains(low, "no recipe found") || strings.Contains(low, "no schema found"):
+		return "No recipe found on this page"
+	default:
+		if len(msg) > 80 {
+			msg = msg[:77] + "..."
+		}
+		return msg
+	}
+}
+
+// RecipeRawFromScraper converts a Scraper into utils.RecipeRaw for saving.
+// When llmModel is set it attempts to parse ingredients via Ollama (experimental),
+// falling back to the regex parser on any error.
+func RecipeRawFromScraper(s Scraper, sourceURL string, llmModel string) *utils.RecipeRaw {
+	r := &utils.RecipeRaw{
+		Metadata: utils.RecipeMetadata{
+			URL:          sourceURL,
+			Ingredients:  []utils.Ingredient{},
+			Instructions: []string{},
+			Categories:   []string{},
+		},
+	}
+
+	if name, ok := s.Name(); ok && name != "" {
+		r.RecipeName = strings.TrimSpace(name)
+	} else {
+		r.RecipeName = "Imported Recipe #" + strconv.Itoa(rand.Intn(1000))
+	}
+	if desc, ok := s.Description(); ok {
+		r.RecipeDescription = strings.TrimSpace(desc)
+	}
+	if author, ok := s.Author(); ok {
+		r.Metadata.Author = strings.TrimSpace(author)
+	}
+	if ct, ok := s.CookTime(); ok {
+		r.Metadata.CookTime = ct
+	}
+	if pt, ok := s.PrepTime(); ok {
+		r.Metadata.PrepTime = pt
+	}
+	if tt, ok := s.TotalTime(); ok {
+		r.Metadata.TotalTime = tt
+	}
+	if y, ok := s.Yields(); ok && y != "" {
+		r.Metadata.Quantity = strings.TrimSpace(y)
+	}
+	if groups, ok := s.IngredientGroups(); ok {
+		r.Metadata.Ingredients = parseIngredientGroups(groups, llmModel)
+	} else if ingList, ok := s.Ingredients(); ok {
+		r.Metadata.Ingredients = ParseIngredientList(ingList, llmModel)
+	}
+	if instr, ok := s.Instructions(); ok {
+		for _, step := range instr {
+			if t := strings.TrimSpace(step); t != "" {
+				r.Metadata.Instructions = append(r.Metadata.Instructions, t)
+			}
+		}
+	}
+	if cat, ok := s.Categories(); ok {
+		for _, c := range cat {
+			if t := strings.TrimSpace(c); t != "" {
+				r.Metadata.Categories = append(r.Metadata.Categories, t)
+			}
+		}
+	}
+	if image, ok := s.ImageURL(); ok {
+		r.Metadata.ImageURL = image
+	}
+	if lang, ok := s.Language(); ok {
+		r.Metadata.Language = lang
+	}
+	if keywords, ok := s.Keywords(); ok {
+		r.Metadata.Keywords = keywords
+	}
+	if diets, ok := s.SuitableDiets(); ok {
+		for _, diet := range diets {
+			r.Metadata.Diets = append(r.Metadata.Diets, utils.DietName(diet.String()))
+		}
+	}
+	if nutrition, ok := s.Nutrition(); ok {
+		r.Metadata.Nutrients = NutrientMap(nutrition)
+	}
+	return r
+}
+
+// parseIngredientGroups parses grouped ingredients in one pass and tags each
+// ingredient with its group's purpose. If the parser does not return one
+// ingredient per line the groups cannot be matched up and are dropped.
+func parseIngredientGroups(groups []IngredientGroup, llmModel string) []utils.Ingredient {
+	var lines, purposes []string
+	for _, group := range groups {
+		purpose := ""
+		if group.Purpose != nil {
+			purpose = strings.TrimSpace(*group.Purpose)
+		}
+		for _, line := range group.Ingredients {
+			if t := strings.TrimSpace(line); t != "" {
+				lines = append(lines, t)
+				purposes = append(purposes, purpose)
+			}
+		}
+	}
+
+	ingredients := ParseIngredientList(lines, llmModel)
+	if len(ingredients) != len(purposes) {
+		slog.Warn("Ingredient count changed while parsing, dropping groups", "lines", len(lines), "parsed", len(ingredients))
+		return ingredients
+	}
+	for i := range ingredients {
+		ingredients[i].Group = purposes[i]
+	}
+	return ingredients
+}
+
+// ParseIngredientList parses a list of raw ingredient strings. When llmModel
+// is set it sends the entire list to an Ollama model for structured extraction
+// and falls back to the regex parser if the LLM call fails. Even on the regex
+// fallback path, LLM is still used to extract base names for highlighting.
+func ParseIngredientList(rawList []string, llmModel string) []utils.Ingredient {
+	// Clean up the raw list first.
+	cleaned := make([]string, 0, len(rawList))
+	for _, s := range rawList {
+		if t := strings.TrimSpace(s); t != "" {
+			cleaned = append(cleaned, t)
+		}
+	}
+	if len(cleaned) == 0 {
+		return []utils.Ingredient{}
+	}
+
+	// Experimental: try full LLM-based parsing (amount + unit + name + base_name).
+	if llmModel != "" {
+		slog.Info("Attempting LLM ingredient parsing", "model", llmModel, "count", len(cleaned))
+		parsed, err := utils.ParseIngredientsWithLLM(context.Background(), cleaned, llmModel)
+		if err != nil {
+			slog.Error("LLM ingredient parsing failed, falling back to regex", "error", err)
+		} else if len(parsed) > 0 {
+			slog.Info("LLM ingredient parsing succeeded", "count", len(parsed))
+			return parsed
+		}
+	}
+
+	// Regex fallback for amount/unit/name parsing.
+	ingredients := make([]utils.Ingredient, 0, len(cleaned))
+	for _, ingStr := range cleaned {
+		if parsed, err := utils.ParseIngredient(ingStr); err == nil {
+			ingredients = append(ingredients, parsed)
+		} else {
+			ingredients = append(ingredients, utils.Ingredient{Name: ingStr})
+		}
+	}
+
+	// Even on the regex path, try the lightweight LLM base-name extraction
+	// so we get good highlighting tokens.
+	if llmModel != "" && len(ingredients) > 0 {
+		slog.Info("Attempting LLM base name extraction", "model", llmModel, "count", len(ingredients))
+		if err := utils.ExtractBaseNamesWithLLM(context.Background(), ingredients, llmModel); err != nil {
+			slog.Error("LLM base name extraction failed, using full names for highlighting", "error", err)
+		}
+	}
+
+	return ingredients
+}
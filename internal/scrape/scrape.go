@@ -3,7 +3,6 @@ package scrape
 import (
 	"bytes"
 	_ "embed"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -131,21 +130,8 @@ func runScript(bin, url string) ([]byte, error) {
 	return out, nil
 }
 
-// ScrapeURL returns a recipe.Scraper for the given URL. pythonPath is optional (e.g. "python3" or "" for default).
+// ScrapeURLRaw returns the recipe-scrapers JSON string. Used by the gopy bindings.
 // Auto-installs recipe-scrapers or creates ~/.yummy/recipe-scrapers-venv on PEP 668 systems.
-func ScrapeURL(url string, pythonPath string) (Scraper, error) {
-	raw, err := ScrapeURLRaw(url, pythonPath)
-	if err != nil {
-		return nil, err
-	}
-	var j RecipeScrapersJSON
-	if err := json.Unmarshal([]byte(raw), &j); err != nil {
-		return nil, fmt.Errorf("json: %w", err)
-	}
-	return &adapter{j: j}, nil
-}
-
-// ScrapeURLRaw returns the recipe JSON string. Used by the gopy bindings.
 func ScrapeURLRaw(url string, pythonPath string) (string, error) {
 	bin, err := resolvePython(pythonPath)
 	if err != nil {
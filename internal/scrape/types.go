@@ -30,24 +30,25 @@ func (f *FlexibleString) UnmarshalJSON(data []byte) error {
 
 // RecipeScrapersJSON is the JSON shape from recipe_scrapers.scrape_me(url).to_json().
 type RecipeScrapersJSON struct {
-	Author           string            `json:"author"`
-	CanonicalURL     string            `json:"canonical_url"`
-	Category         string            `json:"category"`
-	CookTime         *int              `json:"cook_time"`
-	Host             string            `json:"host"`
-	Image            string            `json:"image"`
-	IngredientGroups []IngredientGroup `json:"ingredient_groups"`
-	Ingredients      []string          `json:"ingredients"`
-	Instructions     string            `json:"instructions"`
-	InstructionsList []string          `json:"instructions_list"`
-	Keywords         FlexibleString    `json:"keywords"`
-	Language         string            `json:"language"`
-	Nutrients        map[string]any    `json:"nutrients"`
-	PrepTime         *int              `json:"prep_time"`
-	Title            string            `json:"title"`
-	TotalTime        *int              `json:"total_time"`
-	Description      string            `json:"description"`
-	Yields           string            `json:"yields"`
+	Author              string            `json:"author"`
+	CanonicalURL        string            `json:"canonical_url"`
+	Category            string            `json:"category"`
+	CookTime            *int              `json:"cook_time"`
+	DietaryRestrictions FlexibleString    `json:"dietary_restrictions"`
+	Host                string            `json:"host"`
+	Image               string            `json:"image"`
+	IngredientGroups    []IngredientGroup `json:"ingredient_groups"`
+	Ingredients         []string          `json:"ingredients"`
+	Instructions        string            `json:"instructions"`
+	InstructionsList    []string          `json:"instructions_list"`
+	Keywords            FlexibleString    `json:"keywords"`
+	Language            string            `json:"language"`
+	Nutrients           map[string]any    `json:"nutrients"`
+	PrepTime            *int              `json:"prep_time"`
+	Title               string            `json:"title"`
+	TotalTime           *int              `json:"total_time"`
+	Description         string            `json:"description"`
+	Yields              string            `json:"yields"`
 }
 
 type IngredientGroup struct {
@@ -580,6 +580,12 @@ func NewDefaultTheme() Theme {
 	t.CookingSidebarTitle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#4a9eff")).
 		Bold(true)
+	t.CookingSection = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4a9eff")).
+		Italic(true)
+	t.CookingStepNote = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFD700")).
+		Italic(true)
 	t.CookingIngredient = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#999999"))
 	t.CookingIngredientAmount = lipgloss.NewStyle().
@@ -707,5 +713,76 @@ func NewDefaultTheme() Theme {
 	t.CookingHelpKey = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#e0e0e0"))
 
+	// Shopping list styles
+	t.ShoppingTitle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4a9eff")).
+		Bold(true)
+	t.ShoppingAisle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4a9eff")).
+		Italic(true)
+	t.ShoppingItem = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#e0e0e0"))
+	t.ShoppingItemAmount = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFD700")).
+		Bold(true)
+	t.ShoppingItemChecked = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555")).
+		Strikethrough(true)
+	t.ShoppingCursor = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4a9eff")).
+		Bold(true)
+	t.ShoppingHelp = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555"))
+
+	// Pantry styles
+	t.PantryTitle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4a9eff")).
+		Bold(true)
+	t.PantryItem = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#e0e0e0"))
+	t.PantryItemAmount = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFD700")).
+		Bold(true)
+	t.PantryExpiring = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#F0E68C"))
+	t.PantryExpired = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF6B6B")).
+		Strikethrough(true)
+	t.PantryCursor = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4a9eff")).
+		Bold(true)
+	t.PantryRecipe = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#e0e0e0")).
+		Bold(true)
+	t.PantryCoverage = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4ECDC4")).
+		Bold(true)
+	t.PantryHelp = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555"))
+
+	// Meal plan styles
+	t.PlanTitle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4a9eff")).
+		Bold(true)
+	t.PlanDayHeader = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#e0e0e0")).
+		Bold(true)
+	t.PlanToday = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFD700")).
+		Bold(true)
+	t.PlanSlotLabel = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4a9eff")).
+		Italic(true)
+	t.PlanCell = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#e0e0e0"))
+	t.PlanCellEmpty = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555"))
+	t.PlanCursor = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#4a9eff")).
+		Bold(true).
+		Underline(true)
+	t.PlanHelp = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#555555"))
+
 	return t
 }
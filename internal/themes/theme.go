@@ -158,6 +158,8 @@ type Theme struct {
 	CookingNavHint          lipgloss.Style
 	CookingSidebar          lipgloss.Style
 	CookingSidebarTitle     lipgloss.Style
+	CookingSection          lipgloss.Style
+	CookingStepNote         lipgloss.Style
 	CookingIngredient       lipgloss.Style
 	CookingIngredientAmount lipgloss.Style
 	CookingIngredientDetail lipgloss.Style
@@ -219,6 +221,36 @@ type Theme struct {
 	CookingIngredientHighlight lipgloss.Style
 	CookingNavArrow            lipgloss.Style
 	CookingHelpKey             lipgloss.Style
+
+	// Shopping list styles
+	ShoppingTitle       lipgloss.Style
+	ShoppingAisle       lipgloss.Style
+	ShoppingItem        lipgloss.Style
+	ShoppingItemAmount  lipgloss.Style
+	ShoppingItemChecked lipgloss.Style
+	ShoppingCursor      lipgloss.Style
+	ShoppingHelp        lipgloss.Style
+
+	// Pantry styles
+	PantryTitle      lipgloss.Style
+	PantryItem       lipgloss.Style
+	PantryItemAmount lipgloss.Style
+	PantryExpiring   lipgloss.Style
+	PantryExpired    lipgloss.Style
+	PantryCursor     lipgloss.Style
+	PantryRecipe     lipgloss.Style
+	PantryCoverage   lipgloss.Style
+	PantryHelp       lipgloss.Style
+
+	// Meal plan styles
+	PlanTitle     lipgloss.Style
+	PlanDayHeader lipgloss.Style
+	PlanToday     lipgloss.Style
+	PlanSlotLabel lipgloss.Style
+	PlanCell      lipgloss.Style
+	PlanCellEmpty lipgloss.Style
+	PlanCursor    lipgloss.Style
+	PlanHelp      lipgloss.Style
 }
 
 // Helper methods for main menu rendering
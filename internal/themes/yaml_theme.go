@@ -422,6 +422,10 @@ func (yt *YAMLTheme) ToTheme() (Theme, error) {
 			theme.CookingSidebar = style
 		case "cooking_sidebar_title":
 			theme.CookingSidebarTitle = style
+		case "cooking_section":
+			theme.CookingSection = style
+		case "cooking_step_note":
+			theme.CookingStepNote = style
 		case "cooking_ingredient":
 			theme.CookingIngredient = style
 		case "cooking_ingredient_amount":
@@ -504,6 +508,54 @@ func (yt *YAMLTheme) ToTheme() (Theme, error) {
 			theme.CookingNavArrow = style
 		case "cooking_help_key":
 			theme.CookingHelpKey = style
+		case "shopping_title":
+			theme.ShoppingTitle = style
+		case "shopping_aisle":
+			theme.ShoppingAisle = style
+		case "shopping_item":
+			theme.ShoppingItem = style
+		case "shopping_item_amount":
+			theme.ShoppingItemAmount = style
+		case "shopping_item_checked":
+			theme.ShoppingItemChecked = style
+		case "shopping_cursor":
+			theme.ShoppingCursor = style
+		case "shopping_help":
+			theme.ShoppingHelp = style
+		case "pantry_title":
+			theme.PantryTitle = style
+		case "pantry_item":
+			theme.PantryItem = style
+		case "pantry_item_amount":
+			theme.PantryItemAmount = style
+		case "pantry_expiring":
+			theme.PantryExpiring = style
+		case "pantry_expired":
+			theme.PantryExpired = style
+		case "pantry_cursor":
+			theme.PantryCursor = style
+		case "pantry_recipe":
+			theme.PantryRecipe = style
+		case "pantry_coverage":
+			theme.PantryCoverage = style
+		case "pantry_help":
+			theme.PantryHelp = style
+		case "plan_title":
+			theme.PlanTitle = style
+		case "plan_day_header":
+			theme.PlanDayHeader = style
+		case "plan_today":
+			theme.PlanToday = style
+		case "plan_slot_label":
+			theme.PlanSlotLabel = style
+		case "plan_cell":
+			theme.PlanCell = style
+		case "plan_cell_empty":
+			theme.PlanCellEmpty = style
+		case "plan_cursor":
+			theme.PlanCursor = style
+		case "plan_help":
+			theme.PlanHelp = style
 		}
 	}
 
@@ -18,6 +18,7 @@ import (
 	"github.com/tmc/langchaingo/llms"
 
 	"github.com/GarroshIcecream/yummy/internal/config"
+	yummy_llm "github.com/GarroshIcecream/yummy/internal/llm"
 	common "github.com/GarroshIcecream/yummy/internal/models/common"
 	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
 	themes "github.com/GarroshIcecream/yummy/internal/themes"
@@ -272,7 +273,7 @@ func (m *ChatModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 
 		case key.Matches(msg, m.keyMap.Enter):
 			userInput := strings.TrimSpace(m.textarea.Value())
-			if userInput == "" {
+			if userInput == "" || !m.ExecutorService.IsAvailable() {
 				return m, nil
 			}
 
@@ -506,6 +507,11 @@ func (m *ChatModel) RenderConversationAsMarkdown() error {
 	if msgCount == 0 && !m.waitingForResponse && !m.isStreaming {
 		emptyText := m.theme.ChatEmptyState.
 			Render("Start a conversation...")
+		if reason := m.ExecutorService.UnavailableReason(); reason != "" {
+			emptyText = m.theme.ChatEmptyState.
+				Render(utils.WrapTextToWidth("Chat is offline: "+reason, max(m.viewport.Width-8, 20)) +
+					"\n\nIt comes back once " + yummy_llm.CurrentProvider().Name() + " is ready.")
+		}
 		verticalPad := max(m.viewport.Height/2-1, 0)
 		centered := lipgloss.NewStyle().
 			Width(m.viewport.Width).
@@ -524,6 +530,20 @@ func (m *ChatModel) RenderConversationAsMarkdown() error {
 	return nil
 }
 
+// SetOllamaStatus applies a background Ollama check: sending is disabled and
+// the input says why while the model is unavailable
+func (m *ChatModel) SetOllamaStatus(msg OllamaStatusMsg) {
+	m.ExecutorService.SetOllamaStatus(msg.Model, msg.Status, msg.Err)
+	if reason := m.ExecutorService.UnavailableReason(); reason != "" {
+		m.textarea.Placeholder = "Chat is offline: " + reason
+	} else {
+		m.textarea.Placeholder = m.chatConfig.TextAreaPlaceholder
+	}
+	if err := m.RenderConversationAsMarkdown(); err != nil {
+		slog.Error("Error rendering conversation", "error", err)
+	}
+}
+
 func (m *ChatModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
@@ -9,23 +9,26 @@ import (
 
 	"github.com/GarroshIcecream/yummy/internal/config"
 	db "github.com/GarroshIcecream/yummy/internal/db"
+	yummy_llm "github.com/GarroshIcecream/yummy/internal/llm"
 	"github.com/GarroshIcecream/yummy/internal/tui/chat/callbacks"
 	tools "github.com/GarroshIcecream/yummy/internal/tui/chat/tools"
 	"github.com/tmc/langchaingo/agents"
 	"github.com/tmc/langchaingo/chains"
 	"github.com/tmc/langchaingo/llms"
-	"github.com/tmc/langchaingo/llms/ollama"
 	"github.com/tmc/langchaingo/memory"
 )
 
 // ExecutorService provides agent-based LLM interactions using langchaingo executor
 type ExecutorService struct {
 	executor        *agents.Executor
-	llm             *ollama.LLM
+	llm             llms.Model
 	toolManager     *tools.ToolManager
 	cookbook        *db.CookBook
 	sessionLog      *db.SessionLog
 	ollamaStatus    *OllamaServiceStatus
+	ollamaErr       error // why chat is unavailable, nil when it is
+	providerErr     error // the chat config names no supported provider
+	statusChecked   bool
 	modelName       string
 	ctx             context.Context
 	cancelCtx       context.CancelFunc
@@ -36,30 +39,23 @@ type ExecutorService struct {
 	streamCh        chan string
 }
 
-// NewExecutorService creates a new executor service instance
+// NewExecutorService creates a new executor service instance. It does not
+// need Ollama to be running: chat is unavailable until SetOllamaStatus
+// reports the model is ready. A chat config naming no supported provider
+// leaves chat offline with the reason, rather than keeping the TUI from
+// starting.
 func NewExecutorService(cookbook *db.CookBook, sessionLog *db.SessionLog) (*ExecutorService, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	chatConfig := config.GetChatConfig()
 
-	// Get Ollama service status
-	ollamaStatus, err := GetOllamaServiceStatus(chatConfig.DefaultModel)
-	if err != nil {
-		slog.Error("Failed to get ollama service status", "error", err)
-		cancel()
-		return nil, err
-	}
-
 	// Create tool manager with cookbook access
 	toolManager := tools.NewToolManager(cookbook)
 
 	// Initialize the LLM
-	llm, err := ollama.New(
-		ollama.WithModel(chatConfig.DefaultModel),
-	)
-	if err != nil {
-		slog.Error("Failed to create LLM", "error", err)
-		cancel()
-		return nil, err
+	llm, providerErr := yummy_llm.New(chatConfig.DefaultModel)
+	if providerErr != nil {
+		slog.Error("Chat is offline: failed to create LLM", "error", providerErr)
+		llm = unavailableModel{err: providerErr}
 	}
 
 	mem := memory.NewConversationBuffer(
@@ -98,17 +94,33 @@ func NewExecutorService(cookbook *db.CookBook, sessionLog *db.SessionLog) (*Exec
 		ctx:             ctx,
 		cancelCtx:       cancel,
 		toolManager:     toolManager,
-		ollamaStatus:    ollamaStatus,
+		ollamaStatus:    &OllamaServiceStatus{InstalledModels: []string{}},
 		sessionStats:    emptySessionStats,
 		systemPrompt:    chatConfig.SystemPrompt,
 		maxIterations:   chatConfig.MaxIterations,
 		callbackHandler: callbackHandler,
 		streamCh:        streamCh,
+		providerErr:     providerErr,
+		statusChecked:   providerErr != nil,
 	}
 
 	return service, nil
 }
 
+// unavailableModel stands in for the LLM when it cannot be created, so the
+// executor and its memory work while chat is offline
+type unavailableModel struct {
+	err error
+}
+
+func (m unavailableModel) GenerateContent(context.Context, []llms.MessageContent, ...llms.CallOption) (*llms.ContentResponse, error) {
+	return nil, m.err
+}
+
+func (m unavailableModel) Call(context.Context, string, ...llms.CallOption) (string, error) {
+	return "", m.err
+}
+
 func (e *ExecutorService) GetSystemPrompt() string {
 	return e.systemPrompt
 }
@@ -369,11 +381,13 @@ func (e *ExecutorService) LoadSession(sessionID uint) error {
 		return err
 	}
 
-	// Set the model for the session
-	err = e.SetModelByName(sessionMessages[0].ModelName, e.ollamaStatus)
-	if err != nil {
-		slog.Error("Failed to set model", "error", err)
-		return err
+	// Set the model for the session. Offline, the conversation can still be read.
+	if e.ollamaStatus.Functional {
+		err = e.SetModelByName(sessionMessages[0].ModelName, e.ollamaStatus)
+		if err != nil {
+			slog.Error("Failed to set model", "error", err)
+			return err
+		}
 	}
 
 	// Get session stats (excludes system messages)
@@ -392,11 +406,74 @@ func (e *ExecutorService) GetCurrentModelName() string {
 	return e.modelName
 }
 
-// GetInstalledModels returns the list of Ollama models available locally.
+// GetInstalledModels returns the list of models the LLM provider offers.
 func (e *ExecutorService) GetInstalledModels() []string {
 	return e.ollamaStatus.InstalledModels
 }
 
+// SetOllamaStatus records the result of an Ollama check for modelName. A
+// check started before the model was changed is applied to the current model.
+func (e *ExecutorService) SetOllamaStatus(modelName string, status *OllamaServiceStatus, err error) {
+	if modelName != e.modelName && status.Functional {
+		status.ModelAvailable = slices.Contains(status.InstalledModels, e.modelName)
+		err = nil
+		if !status.ModelAvailable {
+			err = modelMissingError(e.modelName)
+		}
+	}
+
+	if status.Ready() != e.ollamaStatus.Ready() || !e.statusChecked {
+		slog.Info("LLM status changed", "model", e.modelName, "ready", status.Ready(), "reason", err)
+	}
+	e.ollamaStatus = status
+	e.ollamaErr = err
+	e.statusChecked = true
+}
+
+// AddInstalledModel records a model just pulled to the Ollama server, so it
+// can be selected before the next Ollama check
+func (e *ExecutorService) AddInstalledModel(modelName string) {
+	if !slices.Contains(e.ollamaStatus.InstalledModels, modelName) {
+		e.ollamaStatus.InstalledModels = append(e.ollamaStatus.InstalledModels, modelName)
+	}
+	if modelName == e.modelName && e.ollamaStatus.Functional {
+		e.ollamaStatus.ModelAvailable = true
+		e.ollamaErr = nil
+	}
+}
+
+// IsAvailable reports whether chat can be used: Ollama is running and has
+// the current model
+func (e *ExecutorService) IsAvailable() bool {
+	return e.providerErr == nil && e.ollamaStatus.Ready()
+}
+
+// StatusIndicator describes the LLM for the status line
+func (e *ExecutorService) StatusIndicator() string {
+	switch {
+	case !e.statusChecked:
+		return "🤖 checking…"
+	case !e.IsAvailable():
+		return "🤖 offline"
+	}
+	return "🤖 " + e.modelName
+}
+
+// UnavailableReason says why chat cannot be used, or is empty when it can
+func (e *ExecutorService) UnavailableReason() string {
+	switch {
+	case e.providerErr != nil:
+		return e.providerErr.Error()
+	case !e.statusChecked:
+		return "checking for " + yummy_llm.CurrentProvider().Name() + "…"
+	case e.ollamaErr != nil:
+		return e.ollamaErr.Error()
+	case !e.IsAvailable():
+		return yummy_llm.CurrentProvider().Name() + " is not available"
+	}
+	return ""
+}
+
 func (e *ExecutorService) GetSessionLog() *db.SessionLog {
 	return e.sessionLog
 }
@@ -433,9 +510,11 @@ func (e *ExecutorService) SetModelByName(modelName string, ollamaStatus *OllamaS
 	}
 
 	e.modelName = modelName
-	llm, err := ollama.New(
-		ollama.WithModel(modelName),
-	)
+	ollamaStatus.ModelAvailable = true
+	if ollamaStatus == e.ollamaStatus {
+		e.ollamaErr = nil
+	}
+	llm, err := yummy_llm.New(modelName)
 	if err != nil {
 		slog.Error("Failed to create LLM", "error", err)
 		return err
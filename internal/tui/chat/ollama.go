@@ -1,15 +1,27 @@
 package chat
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os/exec"
 	"slices"
-	"strings"
 	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	yummy_llm "github.com/GarroshIcecream/yummy/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// ollamaRequestTimeout bounds the status requests to the Ollama API
+	ollamaRequestTimeout = 5 * time.Second
+	// ollamaStartTimeout is how long a started service gets to answer
+	ollamaStartTimeout = 20 * time.Second
 )
 
 type OllamaServiceStatus struct {
+	Host            string
 	Installed       bool
 	Running         bool
 	Functional      bool
@@ -17,27 +29,25 @@ type OllamaServiceStatus struct {
 	ModelAvailable  bool
 }
 
+func newOllamaClient() *yummy_llm.OllamaClient {
+	return yummy_llm.NewOllamaClient(yummy_llm.OllamaHost())
+}
+
 // CheckOllamaServiceRunning checks if the Ollama service is running and responsive
 func CheckOllamaServiceRunning() error {
-	// Check if ollama command exists first
-	_, err := exec.LookPath("ollama")
-	if err != nil {
-		slog.Error("Ollama command not found in PATH", "error", err)
-		return err
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), ollamaRequestTimeout)
+	defer cancel()
 
-	// Try to ping the service
-	cmd := exec.Command("ollama", "ps")
-	_, err = cmd.Output()
-	if err != nil {
-		slog.Error("Ollama service is not running or not responding", "error", err)
+	client := newOllamaClient()
+	if _, err := client.RunningModels(ctx); err != nil {
+		slog.Debug("Ollama service is not running or not responding", "host", client.BaseURL(), "error", err)
 		return err
 	}
-
 	return nil
 }
 
-// StartOllamaService attempts to start the Ollama service
+// StartOllamaService starts a local Ollama service and waits until its API
+// answers
 func StartOllamaService() error {
 	// Check if ollama command exists first
 	_, err := exec.LookPath("ollama")
@@ -54,68 +64,78 @@ func StartOllamaService() error {
 		return err
 	}
 
-	// Give the service a moment to start up
-	time.Sleep(5 * time.Second)
-
-	// Check if the service is now running
-	err = CheckOllamaServiceRunning()
-	if err != nil {
-		slog.Error("Ollama service failed to start properly", "error", err)
-		return err
+	// Poll the API rather than guessing how long the service takes to start
+	deadline := time.Now().Add(ollamaStartTimeout)
+	for {
+		err = CheckOllamaServiceRunning()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			slog.Error("Ollama service failed to start properly", "error", err)
+			return err
+		}
+		time.Sleep(250 * time.Millisecond)
 	}
-
-	return nil
 }
 
-// CheckOllamaAvailable checks if Ollama is installed and the required model is available
+// CheckOllamaAvailable checks that the Ollama service is running, starting it
+// when it is installed on this machine and the configured host is local
 func CheckOllamaAvailable() error {
-	_, err := exec.LookPath("ollama")
-	if err != nil {
-		slog.Error("Ollama not installed", "error", err)
+	err := CheckOllamaServiceRunning()
+	if err == nil {
+		return nil
+	}
+	if !yummy_llm.IsLocalHost(yummy_llm.OllamaHost()) {
 		return err
 	}
 
-	err = CheckOllamaServiceRunning()
-	if err != nil {
-		slog.Error("Ollama service not running, attempting to start it...", "error", err)
-		startErr := StartOllamaService()
-		if startErr != nil {
-			slog.Error("Failed to start ollama service", "error", startErr)
-			return startErr
-		}
-		slog.Info("Successfully started Ollama service")
+	slog.Info("Ollama service not running, attempting to start it...", "error", err)
+	if startErr := StartOllamaService(); startErr != nil {
+		slog.Error("Failed to start ollama service", "error", startErr)
+		return startErr
 	}
-
+	slog.Info("Successfully started Ollama service")
 	return nil
 }
 
+// GetOllamaInstalledModels lists the models on the Ollama server
 func GetOllamaInstalledModels() ([]string, error) {
-	cmd := exec.Command("ollama", "list")
-	output, err := cmd.Output()
+	ctx, cancel := context.WithTimeout(context.Background(), ollamaRequestTimeout)
+	defer cancel()
+
+	modelList, err := newOllamaClient().InstalledModels(ctx)
 	if err != nil {
 		slog.Error("Failed to get ollama installed models", "error", err)
 		return nil, err
 	}
-
-	modelList := make([]string, 0)
-	lines := strings.Split(string(output), "\n")
-	for idx, line := range lines {
-		if idx != 0 {
-			fields := strings.Split(line, " ")
-			if len(fields) > 1 {
-				cleanModel := strings.TrimSpace(fields[0])
-				modelList = append(modelList, cleanModel)
-			}
-		}
-	}
 	slog.Debug("Ollama installed models", "models", modelList)
 	return modelList, nil
 }
 
-// GetOllamaServiceStatus returns a detailed status of the Ollama service
-func GetOllamaServiceStatus(modelName string) (*OllamaServiceStatus, error) {
-	slog.Debug("Getting ollama service status", "model", modelName)
+// Ready reports whether chat can use the model the status was taken for
+func (s *OllamaServiceStatus) Ready() bool {
+	return s != nil && s.Functional && s.ModelAvailable
+}
+
+// GetOllamaServiceStatus returns a detailed status of the LLM provider,
+// Ollama unless the config names another. When start is set, a local Ollama
+// service that is installed but not running is started. The status is
+// returned even when the LLM cannot be used, along with the error saying
+// why, so the TUI can run without it.
+func GetOllamaServiceStatus(modelName string, start bool) (*OllamaServiceStatus, error) {
+	provider, err := yummy_llm.ParseProvider(config.GetChatConfig().Provider)
+	if err != nil {
+		return &OllamaServiceStatus{InstalledModels: []string{}}, err
+	}
+	if provider != yummy_llm.ProviderOllama {
+		return getServerStatus(provider, modelName)
+	}
+
+	host := yummy_llm.OllamaHost()
+	slog.Debug("Getting ollama service status", "model", modelName, "host", host)
 	status := &OllamaServiceStatus{
+		Host:            host,
 		Installed:       false,
 		Running:         false,
 		Functional:      false,
@@ -124,10 +144,19 @@ func GetOllamaServiceStatus(modelName string) (*OllamaServiceStatus, error) {
 	}
 
 	// Check if service is running
-	err := CheckOllamaAvailable()
+	if start {
+		err = CheckOllamaAvailable()
+	} else {
+		err = CheckOllamaServiceRunning()
+	}
 	if err != nil {
-		slog.Error("Failed to check ollama available", "error", err)
-		return nil, err
+		slog.Debug("Ollama service not running", "error", err)
+		if yummy_llm.IsLocalHost(host) {
+			if _, lookErr := exec.LookPath("ollama"); lookErr != nil {
+				return status, fmt.Errorf("ollama is not installed")
+			}
+		}
+		return status, fmt.Errorf("ollama is not running at %s", host)
 	}
 	status.Installed = true
 	status.Running = true
@@ -135,16 +164,83 @@ func GetOllamaServiceStatus(modelName string) (*OllamaServiceStatus, error) {
 
 	status.InstalledModels, err = GetOllamaInstalledModels()
 	if err != nil {
-		slog.Error("Failed to get ollama installed models", "error", err)
-		return nil, err
+		status.Functional = false
+		return status, fmt.Errorf("failed to list ollama models: %v", err)
 	}
 
 	if slices.Contains(status.InstalledModels, modelName) {
 		status.ModelAvailable = true
 	} else {
-		slog.Error("Required model not found", "model", modelName)
-		return nil, fmt.Errorf("required model %s not found", modelName)
+		slog.Debug("Required model not found", "model", modelName)
+		return status, modelMissingError(modelName)
 	}
 
 	return status, nil
 }
+
+// getServerStatus returns the status of an OpenAI-compatible server, which
+// is up when it lists its models
+func getServerStatus(provider yummy_llm.Provider, modelName string) (*OllamaServiceStatus, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ollamaRequestTimeout)
+	defer cancel()
+
+	client := yummy_llm.NewOpenAIClient(provider.BaseURL(), yummy_llm.APIKey())
+	status := &OllamaServiceStatus{Host: client.BaseURL(), InstalledModels: []string{}}
+
+	models, err := client.Models(ctx)
+	if err != nil {
+		slog.Debug("LLM server not available", "host", client.BaseURL(), "error", err)
+		return status, err
+	}
+	status.Installed = true
+	status.Running = true
+	status.Functional = true
+	status.InstalledModels = models
+
+	if !slices.Contains(models, modelName) {
+		slog.Debug("Required model not found", "model", modelName, "models", models)
+		return status, modelMissingError(modelName)
+	}
+	status.ModelAvailable = true
+	return status, nil
+}
+
+// modelMissingError says that the provider does not have modelName, and how
+// to get it
+func modelMissingError(modelName string) error {
+	provider := yummy_llm.CurrentProvider()
+	if provider.CanPull() {
+		return fmt.Errorf("model %s is not installed (pull it from the model selector)", modelName)
+	}
+	return fmt.Errorf("model %s is not served at %s", modelName, provider.BaseURL())
+}
+
+// OllamaStatusMsg carries the result of a background Ollama check
+type OllamaStatusMsg struct {
+	Model  string
+	Status *OllamaServiceStatus
+	Err    error
+}
+
+// CheckOllamaStatus checks Ollama off the UI goroutine. The check at startup
+// sets start to launch an installed service that is not running.
+func CheckOllamaStatus(modelName string, start bool) tea.Cmd {
+	return func() tea.Msg {
+		status, err := GetOllamaServiceStatus(modelName, start)
+		return OllamaStatusMsg{Model: modelName, Status: status, Err: err}
+	}
+}
+
+// ScheduleOllamaCheck checks Ollama again after the configured interval, so
+// chat comes back once Ollama or the model is installed. It returns nil when
+// re-checks are turned off.
+func ScheduleOllamaCheck(modelName string) tea.Cmd {
+	interval := time.Duration(config.GetChatConfig().LLMCheckInterval) * time.Second
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		status, err := GetOllamaServiceStatus(modelName, false)
+		return OllamaStatusMsg{Model: modelName, Status: status, Err: err}
+	})
+}
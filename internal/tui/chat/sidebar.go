@@ -12,8 +12,10 @@ func RenderSidebar(sessionStats db.SessionStats, ollamaStatus OllamaServiceStatu
 	var sidebar strings.Builder
 
 	// Status indicator
-	if ollamaStatus.Functional && ollamaStatus.ModelAvailable {
+	if ollamaStatus.Ready() {
 		sidebar.WriteString(theme.SidebarSuccess.Render("● ") + theme.SidebarValue.Render("connected"))
+	} else if executorService != nil && !executorService.statusChecked {
+		sidebar.WriteString(theme.SidebarContent.Render("● checking"))
 	} else {
 		sidebar.WriteString(theme.SidebarError.Render("● ") + theme.SidebarContent.Render("offline"))
 	}
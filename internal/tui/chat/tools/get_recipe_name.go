@@ -24,7 +24,7 @@ var _ tools.Tool = &GetRecipeNameTool{}
 func NewGetRecipeNameTool(cookbook *db.CookBook) *GetRecipeNameTool {
 	return &GetRecipeNameTool{
 		FunctionName:        "searchRecipeByName",
-		FunctionDescription: "Search for recipes by name (case-insensitive partial match)",
+		FunctionDescription: "Search for recipes by name (case-insensitive, words match by prefix)",
 		Cookbook:            cookbook,
 	}
 }
@@ -39,19 +39,14 @@ func (t *GetRecipeNameTool) Description() string {
 
 func (t *GetRecipeNameTool) Call(ctx context.Context, input string) (string, error) {
 	slog.Debug("Executing tool", "tool", t.FunctionName, "input", input)
-	allRecipes, err := t.Cookbook.AllRecipes()
+	results, err := t.Cookbook.Search(input, db.SearchFilter{Fields: []string{"name"}})
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch recipes: %w", err)
+		return "", fmt.Errorf("failed to search recipes: %w", err)
 	}
 
-	// Filter recipes by name (case-insensitive partial match)
-	var matches []utils.RecipeRaw
-	searchName := strings.ToLower(input)
-
-	for _, r := range allRecipes {
-		if strings.Contains(strings.ToLower(r.RecipeName), searchName) {
-			matches = append(matches, r)
-		}
+	matches := make([]utils.RecipeRaw, len(results))
+	for i, r := range results {
+		matches[i] = r.Recipe
 	}
 
 	if len(matches) == 0 {
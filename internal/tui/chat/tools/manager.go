@@ -27,6 +27,8 @@ func NewToolManager(cookbook *db.CookBook) *ToolManager {
 
 	tm.RegisterTool(NewGetRecipeNameTool(cookbook))
 	tm.RegisterTool(NewGetRecipeIdTool(cookbook))
+	tm.RegisterTool(NewSearchRecipesTool(cookbook))
+	tm.RegisterTool(NewPantryRecipesTool(cookbook))
 	// tm.RegisterTool(ddg)
 	return tm
 }
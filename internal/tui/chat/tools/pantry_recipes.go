@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// pantryRecipesLimit is how many recipes the tool returns by default
+const pantryRecipesLimit = 5
+
+type PantryRecipesTool struct {
+	FunctionName        string            `json:"name"`
+	FunctionDescription string            `json:"description"`
+	CallbackHandler     callbacks.Handler `json:"callback_handler"`
+	Cookbook            *db.CookBook      `json:"cookbook"`
+}
+
+var _ tools.Tool = &PantryRecipesTool{}
+
+func NewPantryRecipesTool(cookbook *db.CookBook) *PantryRecipesTool {
+	return &PantryRecipesTool{
+		FunctionName:        "whatCanICook",
+		FunctionDescription: "List the ingredients in the user's pantry and the recipes that can be cooked with them, best match first. Input: optional number of recipes to return",
+		Cookbook:            cookbook,
+	}
+}
+
+func (t *PantryRecipesTool) Name() string {
+	return t.FunctionName
+}
+
+func (t *PantryRecipesTool) Description() string {
+	return t.FunctionDescription
+}
+
+func (t *PantryRecipesTool) Call(ctx context.Context, input string) (string, error) {
+	slog.Debug("Executing tool", "tool", t.FunctionName, "input", input)
+	limit := pantryRecipesLimit
+	if n, err := strconv.Atoi(strings.TrimSpace(input)); err == nil && n > 0 {
+		limit = n
+	}
+
+	pantry, err := t.Cookbook.PantryItems()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch pantry: %w", err)
+	}
+	if len(pantry) == 0 {
+		return "The pantry is empty, so there is nothing to match recipes against.", nil
+	}
+
+	matches, err := t.Cookbook.RankRecipesByPantry()
+	if err != nil {
+		return "", fmt.Errorf("failed to match recipes: %w", err)
+	}
+
+	var result strings.Builder
+	now := time.Now()
+	var onHand []string
+	for _, item := range pantry {
+		if item.Expired(now) {
+			continue
+		}
+		onHand = append(onHand, strings.TrimSpace(strings.Join([]string{item.Amount, item.Unit, item.Name}, " ")))
+	}
+	result.WriteString(fmt.Sprintf("Pantry: %s\n\n", strings.Join(onHand, ", ")))
+
+	shown := 0
+	for _, match := range matches {
+		if match.Coverage == 0 || shown >= limit {
+			break
+		}
+		shown++
+		result.WriteString(fmt.Sprintf("%d. **%s** (ID: %d) — %.0f%% of ingredients on hand\n",
+			shown, match.RecipeName, match.RecipeID, match.Coverage*100))
+		if len(match.Missing) > 0 {
+			result.WriteString(fmt.Sprintf("   Missing: %s\n", strings.Join(match.Missing, ", ")))
+		}
+	}
+	if shown == 0 {
+		result.WriteString("No recipe in the cookbook uses any of these ingredients.\n")
+	}
+
+	return result.String(), nil
+}
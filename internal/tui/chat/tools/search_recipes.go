@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// searchRecipesLimit is how many recipes the tool returns
+const searchRecipesLimit = 10
+
+type SearchRecipesTool struct {
+	FunctionName        string            `json:"name"`
+	FunctionDescription string            `json:"description"`
+	CallbackHandler     callbacks.Handler `json:"callback_handler"`
+	Cookbook            *db.CookBook      `json:"cookbook"`
+}
+
+var _ tools.Tool = &SearchRecipesTool{}
+
+func NewSearchRecipesTool(cookbook *db.CookBook) *SearchRecipesTool {
+	return &SearchRecipesTool{
+		FunctionName:        "searchRecipes",
+		FunctionDescription: "Full-text search over every recipe's name, description, author, ingredients, instructions and categories, best match first. Input: the words to search for",
+		Cookbook:            cookbook,
+	}
+}
+
+func (t *SearchRecipesTool) Name() string {
+	return t.FunctionName
+}
+
+func (t *SearchRecipesTool) Description() string {
+	return t.FunctionDescription
+}
+
+func (t *SearchRecipesTool) Call(ctx context.Context, input string) (string, error) {
+	slog.Debug("Executing tool", "tool", t.FunctionName, "input", input)
+	if strings.TrimSpace(input) == "" {
+		return "Please give some words to search for.", nil
+	}
+
+	results, err := t.Cookbook.Search(input, db.SearchFilter{Limit: searchRecipesLimit})
+	if err != nil {
+		return "", fmt.Errorf("failed to search recipes: %w", err)
+	}
+	if len(results) == 0 {
+		return fmt.Sprintf("No recipes found matching '%s'", input), nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Recipes matching '%s', best first:\n\n", input))
+	for i, r := range results {
+		result.WriteString(fmt.Sprintf("%d. **%s** (ID: %d)\n", i+1, r.Recipe.RecipeName, r.Recipe.RecipeID))
+		if snippet := strings.Join(strings.Fields(r.Snippet), " "); snippet != "" {
+			result.WriteString(fmt.Sprintf("   Match: %s\n", snippet))
+		}
+		result.WriteString("\n")
+	}
+
+	return result.String(), nil
+}
@@ -8,9 +8,12 @@ import (
 	"time"
 
 	"github.com/GarroshIcecream/yummy/internal/config"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	yummy_llm "github.com/GarroshIcecream/yummy/internal/llm"
 	common "github.com/GarroshIcecream/yummy/internal/models/common"
 	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
 	themes "github.com/GarroshIcecream/yummy/internal/themes"
+	dialog "github.com/GarroshIcecream/yummy/internal/tui/dialog"
 	utils "github.com/GarroshIcecream/yummy/internal/utils"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -20,7 +23,6 @@ import (
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/tmc/langchaingo/llms"
-	"github.com/tmc/langchaingo/llms/ollama"
 )
 
 // chatResponseMsg is a local message returned when the LLM responds.
@@ -39,9 +41,11 @@ type chatEntry struct {
 }
 
 type CookingModel struct {
+	cookbook        *db.CookBook
 	Recipe          *utils.RecipeRaw
 	CurrentStep     int
 	TotalSteps      int
+	cooked          *utils.CookEvent // logged when the last step is done
 	showIngredients bool
 	theme           *themes.Theme
 	keyMap          config.CookingKeyMap
@@ -56,6 +60,7 @@ type CookingModel struct {
 	chatSpinner      spinner.Model
 	chatHistory      []chatEntry
 	chatWaiting      bool
+	llmOffline       string // why the LLM is unavailable, empty when it is
 	llm              llms.Model
 	ctx              context.Context
 	markdownRenderer *glamour.TermRenderer
@@ -68,7 +73,7 @@ type CookingModel struct {
 	timerDoneAt    time.Time // used to animate the "done" celebration
 }
 
-func NewCookingModel(theme *themes.Theme) (*CookingModel, error) {
+func NewCookingModel(cookbook *db.CookBook, theme *themes.Theme) (*CookingModel, error) {
 	cfg := config.GetGlobalConfig()
 	if cfg == nil {
 		return nil, fmt.Errorf("global config not set")
@@ -111,6 +116,7 @@ func NewCookingModel(theme *themes.Theme) (*CookingModel, error) {
 	}
 
 	return &CookingModel{
+		cookbook:         cookbook,
 		theme:            theme,
 		keyMap:           keymaps,
 		modelState:       common.ModelStateLoaded,
@@ -118,6 +124,7 @@ func NewCookingModel(theme *themes.Theme) (*CookingModel, error) {
 		chatViewport:     vp,
 		chatSpinner:      s,
 		chatHistory:      []chatEntry{},
+		llmOffline:       "checking for " + yummy_llm.CurrentProvider().Name() + "…",
 		ctx:              context.Background(),
 		markdownRenderer: mdRenderer,
 	}, nil
@@ -135,6 +142,7 @@ func (m *CookingModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 		m.Recipe = msg.Recipe
 		m.CurrentStep = 0
 		m.TotalSteps = len(msg.Recipe.Metadata.Instructions)
+		m.cooked = nil
 		m.modelState = common.ModelStateLoaded
 		// Reset chat state for new recipe
 		m.chatHistory = []chatEntry{}
@@ -154,7 +162,7 @@ func (m *CookingModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 			slog.Error("Cooking chat LLM error", "error", msg.err)
 			m.chatHistory = append(m.chatHistory, chatEntry{
 				role:    "assistant",
-				content: "Sorry, I couldn't generate a response. Make sure Ollama is running.",
+				content: "Sorry, I couldn't generate a response. Make sure " + yummy_llm.CurrentProvider().Name() + " is running.",
 			})
 		} else {
 			m.chatHistory = append(m.chatHistory, chatEntry{
@@ -205,7 +213,7 @@ func (m *CookingModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 				return m, nil
 
 			case key.Matches(msg, m.keyMap.Enter):
-				if m.chatWaiting {
+				if m.chatWaiting || m.llmOffline != "" {
 					return m, nil
 				}
 				userInput := strings.TrimSpace(m.chatTextarea.Value())
@@ -235,6 +243,8 @@ func (m *CookingModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 			case key.Matches(msg, m.keyMap.NextStep):
 				if m.CurrentStep < m.TotalSteps-1 {
 					m.CurrentStep++
+				} else if m.cooked == nil {
+					cmds = append(cmds, m.logCook())
 				}
 			case key.Matches(msg, m.keyMap.PrevStep):
 				if m.CurrentStep > 0 {
@@ -273,6 +283,32 @@ func (m *CookingModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// logCook records the recipe as cooked today, at the servings it was cooked
+// for, then asks for a rating and notes
+func (m *CookingModel) logCook() tea.Cmd {
+	event := utils.CookEvent{RecipeID: m.Recipe.RecipeID, Date: utils.StartOfDay(time.Now())}
+	if servings, ok := utils.ParseServings(m.Recipe.Metadata.Quantity); ok {
+		event.Servings = servings.Min
+	}
+
+	id, err := m.cookbook.LogCook(event)
+	if err != nil {
+		slog.Error("Failed to log cook", "error", err)
+		return nil
+	}
+	event.ID = id
+	m.cooked = &event
+	cmds := []tea.Cmd{messages.SendCookLoggedMsg(event.RecipeID)}
+
+	logCookDialog, err := dialog.NewLogCookDialog(m.cookbook, event, "🎉 Cooked! How did it go?", m.theme)
+	if err != nil {
+		slog.Error("Failed to create log cook dialog", "error", err)
+		return tea.Batch(cmds...)
+	}
+	cmds = append(cmds, messages.SendOpenModalViewMsg(logCookDialog, common.ModalTypeLogCook))
+	return tea.Sequence(cmds...)
+}
+
 // timerFunnyMessage returns a fun quip based on how much time remains.
 func timerFunnyMessage(remaining, total time.Duration, done bool) string {
 	if done {
@@ -406,18 +442,22 @@ func timerTick() tea.Cmd {
 	})
 }
 
-// initLLM lazily initializes the Ollama LLM for cooking chat.
+// SetLLMStatus records whether the LLM can be used, from the background
+// LLM check. reason is empty when it can.
+func (m *CookingModel) SetLLMStatus(reason string) {
+	m.llmOffline = reason
+}
+
+// initLLM lazily initializes the LLM of the configured provider for cooking chat.
 func (m *CookingModel) initLLM() error {
 	if m.llm != nil {
 		return nil
 	}
 
 	chatConfig := config.GetChatConfig()
-	llm, err := ollama.New(
-		ollama.WithModel(chatConfig.DefaultModel),
-	)
+	llm, err := yummy_llm.New(chatConfig.DefaultModel)
 	if err != nil {
-		slog.Error("Failed to create Ollama LLM for cooking chat", "error", err)
+		slog.Error("Failed to create LLM for cooking chat", "error", err)
 		return fmt.Errorf("failed to create LLM: %w", err)
 	}
 	m.llm = llm
@@ -468,7 +508,14 @@ func (m *CookingModel) buildRecipeContext() string {
 		m.Recipe.Metadata.Instructions[m.CurrentStep]))
 
 	ctx.WriteString("\nIngredients:\n")
+	group := ""
 	for _, ing := range m.Recipe.Metadata.Ingredients {
+		if ing.Group != group {
+			group = ing.Group
+			if group != "" {
+				ctx.WriteString(fmt.Sprintf("%s:\n", group))
+			}
+		}
 		if ing.Amount != "" {
 			line := ing.Amount
 			if ing.Unit != "" {
@@ -483,16 +530,25 @@ func (m *CookingModel) buildRecipeContext() string {
 
 	ctx.WriteString("\nAll Steps:\n")
 	for i, step := range m.Recipe.Metadata.Instructions {
+		if title, starts := meta.InstructionSectionAt(i); starts {
+			ctx.WriteString(fmt.Sprintf("%s:\n", title))
+		}
 		marker := "  "
 		if i == m.CurrentStep {
 			marker = "→ "
 		}
 		ctx.WriteString(fmt.Sprintf("%s%d. %s\n", marker, i+1, step))
+		if note := meta.StepNoteAt(i); note != "" {
+			ctx.WriteString(fmt.Sprintf("     (cook's note: %s)\n", note))
+		}
+	}
+	if meta.Notes != "" {
+		ctx.WriteString(fmt.Sprintf("\nCook's Notes:\n%s\n", meta.Notes))
 	}
 	return ctx.String()
 }
 
-// sendChatMessage creates a tea.Cmd that calls the Ollama LLM with recipe context.
+// sendChatMessage creates a tea.Cmd that calls the LLM with recipe context.
 func (m *CookingModel) sendChatMessage(userMessage string) tea.Cmd {
 	llm := m.llm
 	ctx := m.ctx
@@ -554,10 +610,14 @@ func (m *CookingModel) updateChatViewport() {
 	var conv strings.Builder
 
 	if len(m.chatHistory) == 0 && !m.chatWaiting {
+		hintText := "Ask anything about the recipe or current step..."
+		if m.llmOffline != "" {
+			hintText = "Chat is offline: " + m.llmOffline
+		}
 		hint := m.theme.CookingChatEmpty.
 			Width(innerWidth).
 			Align(lipgloss.Center).
-			Render("Ask anything about the recipe or current step...")
+			Render(hintText)
 		conv.WriteString("\n\n" + hint)
 	}
 
@@ -635,9 +695,12 @@ func (m *CookingModel) renderMainPanel() string {
 	// Recipe name
 	name := m.theme.CookingRecipeName.Render(m.Recipe.RecipeName)
 
-	// Step counter
+	// Step counter, with the section the step belongs to
 	counter := m.theme.CookingStepCounter.Render(
 		fmt.Sprintf("Step %d of %d", m.CurrentStep+1, m.TotalSteps))
+	if section, _ := m.Recipe.Metadata.InstructionSectionAt(m.CurrentStep); section != "" {
+		counter += m.theme.CookingNavHint.Render("  ·  ") + m.theme.CookingSection.Render(section)
+	}
 
 	// Progress bar
 	progressWidth := min(contentWidth-8, 40)
@@ -663,6 +726,18 @@ func (m *CookingModel) renderMainPanel() string {
 		Align(lipgloss.Center).
 		Render(highlightedInstruction)
 
+	// Personal annotation on this step
+	if note := m.Recipe.Metadata.StepNoteAt(m.CurrentStep); note != "" {
+		instructionText = lipgloss.JoinVertical(lipgloss.Center,
+			instructionText,
+			"",
+			m.theme.CookingStepNote.
+				Width(wrappedWidth).
+				Align(lipgloss.Center).
+				Render("💡 "+note),
+		)
+	}
+
 	// Navigation hints
 	var navParts []string
 	if m.CurrentStep > 0 {
@@ -679,8 +754,12 @@ func (m *CookingModel) renderMainPanel() string {
 		navParts = append(navParts,
 			m.theme.CookingNavHint.Render("next ")+
 				m.theme.CookingNavArrow.Render("→"))
+	} else if m.cooked == nil {
+		navParts = append(navParts,
+			m.theme.CookingNavHint.Render("done ")+
+				m.theme.CookingNavArrow.Render("→"))
 	} else {
-		navParts = append(navParts, m.theme.CookingNavHint.Render("     "))
+		navParts = append(navParts, m.theme.CookingNavHint.Render("✅ logged"))
 	}
 	nav := strings.Join(navParts, "")
 
@@ -823,7 +902,18 @@ func (m *CookingModel) renderIngredientsSidebar(width, height int) string {
 	sidebar.WriteString(sep)
 	sidebar.WriteString("\n\n")
 
+	group := ""
 	for _, ing := range m.Recipe.Metadata.Ingredients {
+		// Group heading when a new ingredient group starts
+		if ing.Group != group {
+			group = ing.Group
+			if group != "" {
+				sidebar.WriteString("\n")
+				sidebar.WriteString(m.theme.CookingSection.Render(group))
+				sidebar.WriteString("\n")
+			}
+		}
+
 		// Bullet prefix
 		bullet := m.theme.CookingNavHint.Render("  • ")
 
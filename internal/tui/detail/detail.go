@@ -3,6 +3,7 @@ package detail
 import (
 	"fmt"
 	"log/slog"
+	"math"
 	"strings"
 
 	"github.com/GarroshIcecream/yummy/internal/config"
@@ -11,6 +12,7 @@ import (
 	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
 	themes "github.com/GarroshIcecream/yummy/internal/themes"
 	dialog "github.com/GarroshIcecream/yummy/internal/tui/dialog"
+	units "github.com/GarroshIcecream/yummy/internal/units"
 	utils "github.com/GarroshIcecream/yummy/internal/utils"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -29,6 +31,9 @@ type DetailModel struct {
 	Recipe          *utils.RecipeRaw
 	renderedContent string
 	content         string
+	scale           float64      // ingredient scale factor, 1 for the recipe as saved
+	units           units.System // unit system ingredient amounts are shown in
+	history         []utils.CookEvent
 
 	// UI
 	width          int
@@ -44,6 +49,11 @@ func NewDetailModel(cookbook *db.CookBook, theme *themes.Theme) (*DetailModel, e
 	}
 
 	keymaps := cfg.Keymap.ToKeyMap().GetDetailKeyMap()
+	unitSystem, err := units.ParseSystem(cfg.General.UnitSystem)
+	if err != nil {
+		slog.Warn("Invalid unit system in config, showing units as written", "error", err)
+		unitSystem = units.Original
+	}
 	detailConfig := cfg.Detail
 	renderer, err := glamour.NewTermRenderer(
 		glamour.WithAutoStyle(),
@@ -59,6 +69,8 @@ func NewDetailModel(cookbook *db.CookBook, theme *themes.Theme) (*DetailModel, e
 		cookbook:       cookbook,
 		scrollPosition: 0,
 		Recipe:         nil,
+		scale:          1,
+		units:          unitSystem,
 		width:          0,
 		height:         detailConfig.ViewportHeight,
 		keyMap:         keymaps,
@@ -85,17 +97,33 @@ func (m *DetailModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 	case messages.LoadRecipeMsg:
 		m.scrollPosition = 0
 		m.Recipe = msg.Recipe
+		m.scale = 1
 		m.content = msg.Content
 		m.renderedContent = msg.Markdown
+		m.history = nil
+		if m.Recipe != nil {
+			m.loadHistory()
+			if m.units != units.Original || len(m.history) > 0 {
+				m.content = m.formatContent()
+				m.refreshContent()
+			}
+		}
 		m.modelState = common.ModelStateLoaded
 
+	case messages.CookLoggedMsg:
+		if m.Recipe != nil && m.Recipe.RecipeID == msg.RecipeID {
+			m.loadHistory()
+			m.content = m.formatContent()
+			m.refreshContentKeepScroll()
+		}
+
 	case messages.RatingSelectedMsg:
 		if m.Recipe != nil && m.Recipe.RecipeID == msg.RecipeID {
 			if err := m.cookbook.SetRating(m.Recipe.RecipeID, msg.Rating); err != nil {
 				slog.Error("Failed to set rating", "error", err)
 			} else {
 				m.Recipe.Metadata.Rating = msg.Rating
-				m.content = m.Recipe.FormatRecipeMarkdown()
+				m.content = m.formatContent()
 				m.refreshContentKeepScroll()
 			}
 		}
@@ -117,13 +145,55 @@ func (m *DetailModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 				)
 				cmds = append(cmds, messages.SendOpenModalViewMsg(ratingDialog, common.ModalTypeRating))
 			}
+		case key.Matches(msg, m.keyMap.Collections):
+			if m.Recipe != nil {
+				collectionsDialog, err := dialog.NewCollectionsDialog(m.cookbook, m.Recipe.RecipeID, m.theme)
+				if err != nil {
+					slog.Error("Failed to create collections dialog", "error", err)
+					break
+				}
+				cmds = append(cmds, messages.SendOpenModalViewMsg(collectionsDialog, common.ModalTypeCollections))
+			}
+		case key.Matches(msg, m.keyMap.LogCook):
+			if m.Recipe != nil {
+				event := utils.CookEvent{RecipeID: m.Recipe.RecipeID}
+				if servings, ok := utils.ParseServings(m.DisplayedRecipe().Metadata.Quantity); ok {
+					event.Servings = servings.Min
+				}
+				logCookDialog, err := dialog.NewLogCookDialog(m.cookbook, event, "Log Cook", m.theme)
+				if err != nil {
+					slog.Error("Failed to create log cook dialog", "error", err)
+					break
+				}
+				cmds = append(cmds, messages.SendOpenModalViewMsg(logCookDialog, common.ModalTypeLogCook))
+			}
 		case key.Matches(msg, m.keyMap.CookingMode):
 			if m.Recipe != nil && len(m.Recipe.Metadata.Instructions) > 0 {
 				cmds = append(cmds,
 					messages.SendSessionStateMsg(common.SessionStateCooking),
-					messages.SendEnterCookingModeMsg(m.Recipe),
+					messages.SendEnterCookingModeMsg(m.DisplayedRecipe()),
 				)
 			}
+		case key.Matches(msg, m.keyMap.ScaleUp):
+			m.stepScale(1)
+		case key.Matches(msg, m.keyMap.ScaleDown):
+			m.stepScale(-1)
+		case key.Matches(msg, m.keyMap.ResetScale):
+			m.setScale(1)
+		case key.Matches(msg, m.keyMap.ToggleUnits):
+			if m.Recipe != nil {
+				m.units = m.units.Next()
+				m.content = m.formatContent()
+				m.refreshContentKeepScroll()
+			}
+		case key.Matches(msg, m.keyMap.AddToList):
+			if m.Recipe != nil {
+				if err := m.addToShoppingList(); err != nil {
+					slog.Error("Failed to add recipe to shopping list", "error", err)
+				} else {
+					cmds = append(cmds, messages.SendSessionStateMsg(common.SessionStateShoppingList))
+				}
+			}
 		case key.Matches(msg, m.keyMap.CursorUp):
 			m.ScrollUp(m.config.ScrollSpeed)
 		case key.Matches(msg, m.keyMap.CursorDown):
@@ -172,6 +242,85 @@ func (m *DetailModel) View() string {
 	return style.Render(visibleContent)
 }
 
+// DisplayedRecipe returns the recipe with the current scale factor and unit
+// system applied
+func (m *DetailModel) DisplayedRecipe() *utils.RecipeRaw {
+	if m.Recipe == nil || (m.scale == 1 && m.units == units.Original) {
+		return m.Recipe
+	}
+	return m.Recipe.Scaled(m.scale).ConvertedTo(m.units)
+}
+
+// stepScale changes the servings by one when the recipe's yield is known,
+// otherwise the scale factor by a half.
+func (m *DetailModel) stepScale(direction int) {
+	if m.Recipe == nil {
+		return
+	}
+
+	if base, ok := utils.ParseServings(m.Recipe.Metadata.Quantity); ok && base.Min > 0 {
+		servings := math.Round(base.Min*m.scale) + float64(direction)
+		if servings >= 1 {
+			m.setScale(servings / base.Min)
+		}
+		return
+	}
+
+	if scale := m.scale + 0.5*float64(direction); scale >= 0.5 {
+		m.setScale(scale)
+	}
+}
+
+func (m *DetailModel) setScale(scale float64) {
+	if m.Recipe == nil || scale == m.scale {
+		return
+	}
+	m.scale = scale
+	m.content = m.formatContent()
+	m.refreshContentKeepScroll()
+}
+
+// formatContent renders the displayed recipe as markdown, noting the scale
+// factor and unit system under the title when they differ from the saved
+// recipe, followed by its cook history.
+func (m *DetailModel) formatContent() string {
+	content := m.DisplayedRecipe().FormatRecipeMarkdown()
+	if history := utils.FormatCookHistory(m.history); history != "" {
+		content += "\n" + history
+	}
+	var notes []string
+	if m.scale != 1 {
+		note := fmt.Sprintf("⚖️ Scaled ×%s", utils.FormatAmount(m.scale))
+		if m.Recipe.Metadata.Quantity != "" {
+			note += fmt.Sprintf(" (original: %s)", m.Recipe.Metadata.Quantity)
+		}
+		notes = append(notes, note)
+	}
+	if m.units != units.Original {
+		notes = append(notes, fmt.Sprintf("📏 %s units", m.units.Label()))
+	}
+	if len(notes) == 0 {
+		return content
+	}
+
+	note := "> " + strings.Join(notes, " · ")
+	title, rest, found := strings.Cut(content, "\n")
+	if !found {
+		return note + "\n\n" + content
+	}
+	return title + "\n\n" + note + "\n" + rest
+}
+
+// loadHistory reads the cook log of the recipe
+func (m *DetailModel) loadHistory() {
+	history, err := m.cookbook.CookEvents(m.Recipe.RecipeID)
+	if err != nil {
+		slog.Error("Failed to load cook history", "error", err)
+		return
+	}
+	m.history = history
+}
+
 func (m *DetailModel) FetchRecipeData(recipe_id uint) tea.Cmd {
 	return func() tea.Msg {
 		recipe, err := m.cookbook.GetFullRecipe(recipe_id)
@@ -302,3 +451,18 @@ func (m *DetailModel) GetCurrentTheme() *themes.Theme {
 func (m *DetailModel) SetTheme(theme *themes.Theme) {
 	m.theme = theme
 }
+
+// addToShoppingList adds the recipe, at its current scale, to the latest
+// shopping list, creating one when there is none yet.
+func (m *DetailModel) addToShoppingList() error {
+	recipes := []utils.ShoppingListRecipe{{RecipeID: m.Recipe.RecipeID, Scale: m.scale}}
+	list, err := m.cookbook.LatestShoppingList()
+	if err != nil {
+		return err
+	}
+	if list == nil {
+		_, err = m.cookbook.CreateShoppingList("", recipes)
+		return err
+	}
+	return m.cookbook.AddRecipesToShoppingList(list.ID, recipes)
+}
@@ -1,11 +1,10 @@
 package dialog
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand"
-	"strconv"
 	"strings"
 
 	"github.com/GarroshIcecream/yummy/internal/config"
@@ -37,8 +36,11 @@ type AddRecipeFromURLDialogCmp struct {
 	errorMsg           string
 	fetchedURL         string
 	pythonPath         string // optional path to Python for recipe-scrapers (from config)
-	existingID         uint   // non-zero when URL already exists in cookbook
-	llmIngredientModel string // Ollama model name for ingredient parsing
+	scraperConfig      config.ScraperConfig
+	backend            string   // scraper backend that produced the recipe
+	backendErrors      []string // per-backend failures, shown under the error
+	existingID         uint     // non-zero when URL already exists in cookbook
+	llmIngredientModel string   // Ollama model name for ingredient parsing
 }
 
 func NewAddRecipeFromURLDialog(cookbook *db.CookBook, theme *themes.Theme) (*AddRecipeFromURLDialogCmp, error) {
@@ -77,6 +79,7 @@ func NewAddRecipeFromURLDialog(cookbook *db.CookBook, theme *themes.Theme) (*Add
 		height:             dialogConfig.Height,
 		theme:              theme,
 		pythonPath:         dialogConfig.PythonPath,
+		scraperConfig:      cfg.Scraper,
 		llmIngredientModel: llmModel,
 	}, nil
 }
@@ -103,15 +106,30 @@ func (m *AddRecipeFromURLDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Scraping done — pick a fun quip for the ingredient parsing phase.
 		quip := ingredientParsingQuips[rand.Intn(len(ingredientParsingQuips))]
 		m.loadingText = quip
+		m.backend = msg.backend
+		m.backendErrors = formatBackendAttempts(msg.attempts)
 		return m, parseAndSaveCmd(msg.scraper, msg.url, msg.llmModel, m.cookbook)
 
 	case scrapeAndSaveResultMsg:
 		m.loading = false
 		if msg.err != nil {
-			m.errorMsg = friendlyScrapeError(msg.err)
+			m.errorMsg = scrape.FriendlyError(msg.err)
+			var scrapeErr *scrape.Error
+			if errors.As(msg.err, &scrapeErr) {
+				m.backendErrors = formatBackendAttempts(scrapeErr.Attempts)
+				if len(scrapeErr.Attempts) == 1 {
+					m.errorMsg = scrape.FriendlyError(scrapeErr.Attempts[0].Err)
+					m.backendErrors = nil
+				} else if len(scrapeErr.Attempts) > 1 {
+					m.errorMsg = "No scraper could read this recipe"
+				}
+			}
 			return m, nil
 		}
 		statusMsg := config.GetListConfig().ViewStatusMessageRecipeAdded
+		if m.backend != "" {
+			statusMsg += fmt.Sprintf(" (via %s)", m.backend)
+		}
 		cmds = append(cmds, messages.SendCloseModalViewMsg())
 		cmds = append(cmds, messages.SendSessionStateMsg(common.SessionStateList))
 		cmds = append(cmds, messages.SendRecipeAddedFromURLMsg(msg.recipeID, statusMsg))
@@ -150,11 +168,14 @@ func (m *AddRecipeFromURLDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			m.errorMsg = ""
+			m.backend = ""
+			m.backendErrors = nil
 			m.fetchedURL = urlStr
 			m.loading = true
 
+			backends := scrape.BackendsForURL(urlStr, m.scraperConfig.Backends, m.scraperConfig.HostBackends)
 			cmds = append(cmds, m.spinner.Tick, scrapeURLCmd(
-				urlStr, m.pythonPath, m.llmIngredientModel,
+				urlStr, backends, scrape.Options{PythonPath: m.pythonPath}, m.llmIngredientModel,
 			))
 		}
 	}
@@ -225,8 +246,14 @@ func (m *AddRecipeFromURLDialogCmp) View() string {
 		status = lipgloss.NewStyle().Width(innerWidth).Align(lipgloss.Center).Render(
 			m.theme.AddRecipeFromURLSpinner.Render(m.spinner.View()) +
 				m.theme.AddRecipeFromURLPrompt.Render(" "+spinnerText))
+		if m.backend != "" {
+			status += "\n" + row(m.theme.AddRecipeFromURLHelp, lipgloss.Center, "scraped via "+m.backend)
+		}
 	} else if m.errorMsg != "" {
 		status = row(m.theme.AddRecipeFromURLError, lipgloss.Center, m.errorMsg)
+		for _, backendErr := range m.backendErrors {
+			status += "\n" + row(m.theme.AddRecipeFromURLHelp, lipgloss.Center, backendErr)
+		}
 	}
 
 	// Help — subtle keys, centered
@@ -299,6 +326,8 @@ type scrapeDoneMsg struct {
 	scraper  scrape.Scraper
 	url      string
 	llmModel string
+	backend  string
+	attempts []scrape.BackendAttempt
 }
 
 // scrapeAndSaveResultMsg is the internal result of the full pipeline.
@@ -318,21 +347,36 @@ var ingredientParsingQuips = []string{
 	"Translating chef-speak…",
 }
 
-// scrapeURLCmd handles only the URL scraping phase.
-func scrapeURLCmd(url, pythonPath, llmModel string) tea.Cmd {
+// scrapeURLCmd handles only the URL scraping phase, trying each backend in order.
+func scrapeURLCmd(url string, backends []string, opts scrape.Options, llmModel string) tea.Cmd {
 	return func() tea.Msg {
-		scraper, err := scrape.ScrapeURL(url, pythonPath)
+		result, err := scrape.Scrape(url, backends, opts)
 		if err != nil {
 			return scrapeAndSaveResultMsg{err: err}
 		}
-		return scrapeDoneMsg{scraper: scraper, url: url, llmModel: llmModel}
+		return scrapeDoneMsg{
+			scraper:  result.Scraper,
+			url:      url,
+			llmModel: llmModel,
+			backend:  result.Backend,
+			attempts: result.Attempts,
+		}
+	}
+}
+
+// formatBackendAttempts renders failed backend attempts as "backend: reason" lines.
+func formatBackendAttempts(attempts []scrape.BackendAttempt) []string {
+	lines := make([]string, 0, len(attempts))
+	for _, a := range attempts {
+		lines = append(lines, fmt.Sprintf("%s: %s", a.Backend, scrape.FriendlyError(a.Err)))
 	}
+	return lines
 }
 
 // parseAndSaveCmd handles ingredient parsing (with LLM) and saving to DB.
 func parseAndSaveCmd(s scrape.Scraper, url, llmModel string, cookbook *db.CookBook) tea.Cmd {
 	return func() tea.Msg {
-		recipeData := recipeRawFromScraper(s, url, llmModel)
+		recipeData := scrape.RecipeRawFromScraper(s, url, llmModel)
 
 		recipeID, err := cookbook.SaveScrapedRecipe(recipeData)
 		if err != nil {
@@ -343,138 +387,3 @@ func parseAndSaveCmd(s scrape.Scraper, url, llmModel string, cookbook *db.CookBo
 		return scrapeAndSaveResultMsg{recipeID: recipeID}
 	}
 }
-
-// friendlyScrapeError turns a raw scrape error into a short, user-friendly message.
-func friendlyScrapeError(err error) string {
-	msg := err.Error()
-	low := strings.ToLower(msg)
-
-	switch {
-	case strings.Contains(low, "isn't currently supported") ||
-		strings.Contains(low, "not supported"):
-		return "Can't scrape this website yet"
-	case strings.Contains(low, "no python found"):
-		return "Python 3 is needed for scraping"
-	case strings.Contains(low, "no such host") ||
-		strings.Contains(low, "dial tcp") ||
-		strings.Contains(low, "connection refused"):
-		return "Can't reach that website"
-	case strings.Contains(low, "404") || strings.Contains(low, "not found"):
-		return "Page not found"
-	case strings.Contains(low, "timeout") || strings.Contains(low, "deadline exceeded"):
-		return "Request timed out"
-	case strings.Contains(low, "no recipe found") || strings.Contains(low, "no schema found"):
-		return "No recipe found on this page"
-	default:
-		if len(msg) > 80 {
-			msg = msg[:77] + "..."
-		}
-		return msg
-	}
-}
-
-// recipeRawFromScraper converts a scrape.Scraper into utils.RecipeRaw for saving.
-// When useLLM is true it attempts to parse ingredients via Ollama (experimental),
-// falling back to the regex parser on any error.
-func recipeRawFromScraper(s scrape.Scraper, sourceURL string, llmModel string) *utils.RecipeRaw {
-	r := &utils.RecipeRaw{
-		Metadata: utils.RecipeMetadata{
-			URL:          sourceURL,
-			Ingredients:  []utils.Ingredient{},
-			Instructions: []string{},
-			Categories:   []string{},
-		},
-	}
-
-	if name, ok := s.Name(); ok && name != "" {
-		r.RecipeName = strings.TrimSpace(name)
-	} else {
-		r.RecipeName = "Imported Recipe #" + strconv.Itoa(rand.Intn(1000))
-	}
-	if desc, ok := s.Description(); ok {
-		r.RecipeDescription = strings.TrimSpace(desc)
-	}
-	if author, ok := s.Author(); ok {
-		r.Metadata.Author = strings.TrimSpace(author)
-	}
-	if ct, ok := s.CookTime(); ok {
-		r.Metadata.CookTime = ct
-	}
-	if pt, ok := s.PrepTime(); ok {
-		r.Metadata.PrepTime = pt
-	}
-	if tt, ok := s.TotalTime(); ok {
-		r.Metadata.TotalTime = tt
-	}
-	if y, ok := s.Yields(); ok && y != "" {
-		r.Metadata.Quantity = strings.TrimSpace(y)
-	}
-	if ingList, ok := s.Ingredients(); ok {
-		r.Metadata.Ingredients = parseIngredientList(ingList, llmModel)
-	}
-	if instr, ok := s.Instructions(); ok {
-		for _, step := range instr {
-			if t := strings.TrimSpace(step); t != "" {
-				r.Metadata.Instructions = append(r.Metadata.Instructions, t)
-			}
-		}
-	}
-	if cat, ok := s.Categories(); ok {
-		for _, c := range cat {
-			if t := strings.TrimSpace(c); t != "" {
-				r.Metadata.Categories = append(r.Metadata.Categories, t)
-			}
-		}
-	}
-	return r
-}
-
-// parseIngredientList parses a list of raw ingredient strings. When useLLM is
-// true it sends the entire list to an Ollama model for structured extraction
-// and falls back to the regex parser if the LLM call fails. Even on the regex
-// fallback path, LLM is still used to extract base names for highlighting.
-func parseIngredientList(rawList []string, llmModel string) []utils.Ingredient {
-	// Clean up the raw list first.
-	cleaned := make([]string, 0, len(rawList))
-	for _, s := range rawList {
-		if t := strings.TrimSpace(s); t != "" {
-			cleaned = append(cleaned, t)
-		}
-	}
-	if len(cleaned) == 0 {
-		return []utils.Ingredient{}
-	}
-
-	// Experimental: try full LLM-based parsing (amount + unit + name + base_name).
-	if llmModel != "" {
-		slog.Info("Attempting LLM ingredient parsing", "model", llmModel, "count", len(cleaned))
-		parsed, err := utils.ParseIngredientsWithLLM(context.Background(), cleaned, llmModel)
-		if err != nil {
-			slog.Error("LLM ingredient parsing failed, falling back to regex", "error", err)
-		} else if len(parsed) > 0 {
-			slog.Info("LLM ingredient parsing succeeded", "count", len(parsed))
-			return parsed
-		}
-	}
-
-	// Regex fallback for amount/unit/name parsing.
-	ingredients := make([]utils.Ingredient, 0, len(cleaned))
-	for _, ingStr := range cleaned {
-		if parsed, err := utils.ParseIngredient(ingStr); err == nil {
-			ingredients = append(ingredients, parsed)
-		} else {
-			ingredients = append(ingredients, utils.Ingredient{Name: ingStr})
-		}
-	}
-
-	// Even on the regex path, try the lightweight LLM base-name extraction
-	// so we get good highlighting tokens.
-	if llmModel != "" && len(ingredients) > 0 {
-		slog.Info("Attempting LLM base name extraction", "model", llmModel, "count", len(ingredients))
-		if err := utils.ExtractBaseNamesWithLLM(context.Background(), ingredients, llmModel); err != nil {
-			slog.Error("LLM base name extraction failed, using full names for highlighting", "error", err)
-		}
-	}
-
-	return ingredients
-}
@@ -0,0 +1,403 @@
+package dialog
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	common "github.com/GarroshIcecream/yummy/internal/models/common"
+	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
+	themes "github.com/GarroshIcecream/yummy/internal/themes"
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CollectionsDialogCmp manages the collections of a recipe: it toggles the
+// recipe in and out of collections, creates new ones and reorders the
+// recipes of a collection.
+type CollectionsDialogCmp struct {
+	cookbook    *db.CookBook
+	recipeID    uint
+	collections []utils.Collection
+	member      map[uint]bool
+
+	// Order pane, showing the recipes of one collection
+	ordering *utils.Collection
+	recipes  []utils.RecipeRaw
+
+	creating  bool
+	nameInput textinput.Model
+	err       error
+
+	selectedIndex int
+	scrollOffset  int
+	deleteKey     key.Binding
+	width         int
+	height        int
+	theme         *themes.Theme
+}
+
+func NewCollectionsDialog(cookbook *db.CookBook, recipeID uint, theme *themes.Theme) (*CollectionsDialogCmp, error) {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		return nil, fmt.Errorf("global config not set")
+	}
+
+	dialogConfig := cfg.RecipeSelectorDialog
+	ti := textinput.New()
+	ti.Placeholder = "Name, e.g. Thanksgiving 2026"
+	ti.CharLimit = 64
+	if w := dialogConfig.Width - 8; w > 10 {
+		ti.Width = w
+	} else {
+		ti.Width = 40
+	}
+
+	d := &CollectionsDialogCmp{
+		cookbook:  cookbook,
+		recipeID:  recipeID,
+		nameInput: ti,
+		deleteKey: cfg.Keymap.ToKeyMap().Delete,
+		width:     dialogConfig.Width,
+		height:    dialogConfig.Height,
+		theme:     theme,
+	}
+	if err := d.loadCollections(); err != nil {
+		return nil, fmt.Errorf("failed to load collections: %w", err)
+	}
+	return d, nil
+}
+
+func (d *CollectionsDialogCmp) loadCollections() error {
+	collections, err := d.cookbook.Collections()
+	if err != nil {
+		return err
+	}
+	ids, err := d.cookbook.RecipeCollections(d.recipeID)
+	if err != nil {
+		return err
+	}
+
+	d.collections = collections
+	d.member = make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		d.member[id] = true
+	}
+	d.selectedIndex = min(d.selectedIndex, max(len(d.collections)-1, 0))
+	d.ensureVisible()
+	return nil
+}
+
+func (d *CollectionsDialogCmp) loadRecipes() error {
+	recipes, err := d.cookbook.CollectionRecipes(d.ordering.ID)
+	if err != nil {
+		return err
+	}
+	d.recipes = recipes
+	d.selectedIndex = min(d.selectedIndex, max(len(d.recipes)-1, 0))
+	d.ensureVisible()
+	return nil
+}
+
+func (d *CollectionsDialogCmp) Init() tea.Cmd {
+	return nil
+}
+
+func (d *CollectionsDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if d.creating {
+		return d.updateCreating(msg)
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return d, nil
+	}
+
+	if keyMsg.String() == "esc" {
+		return d, messages.SendCloseModalViewMsg()
+	}
+	d.err = nil
+	if d.ordering != nil {
+		d.updateOrdering(keyMsg)
+		return d, nil
+	}
+
+	switch keyMsg.String() {
+	case "enter", " ":
+		if d.selectedIndex < len(d.collections) {
+			d.toggle(d.collections[d.selectedIndex])
+		}
+
+	case "n":
+		d.creating = true
+		d.nameInput.SetValue("")
+		return d, d.nameInput.Focus()
+
+	case "o", "right", "l":
+		if d.selectedIndex < len(d.collections) {
+			selected := d.collections[d.selectedIndex]
+			d.ordering = &selected
+			d.selectedIndex, d.scrollOffset = 0, 0
+			if d.err = d.loadRecipes(); d.err != nil {
+				d.ordering = nil
+			}
+		}
+
+	case "up", "k":
+		d.move(-1, len(d.collections))
+
+	case "down", "j":
+		d.move(1, len(d.collections))
+	}
+
+	return d, nil
+}
+
+// updateCreating handles the name input of a new collection, which the
+// recipe is added to
+func (d *CollectionsDialogCmp) updateCreating(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			d.creating = false
+			d.nameInput.Blur()
+			return d, nil
+
+		case "enter":
+			name := strings.TrimSpace(d.nameInput.Value())
+			if name == "" {
+				return d, nil
+			}
+			id, err := d.cookbook.CreateCollection(name)
+			if err == nil {
+				err = d.cookbook.AddToCollection(id, d.recipeID)
+			}
+			if err == nil {
+				err = d.loadCollections()
+			}
+			if err != nil {
+				d.err = err
+				return d, nil
+			}
+
+			d.creating = false
+			d.nameInput.Blur()
+			for i, collection := range d.collections {
+				if collection.ID == id {
+					d.selectedIndex = i
+				}
+			}
+			d.ensureVisible()
+			return d, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	d.nameInput, cmd = d.nameInput.Update(msg)
+	d.err = nil
+	return d, cmd
+}
+
+// updateOrdering handles the order pane of a collection
+func (d *CollectionsDialogCmp) updateOrdering(keyMsg tea.KeyMsg) {
+	switch {
+	case keyMsg.String() == "backspace" || keyMsg.String() == "left" || keyMsg.String() == "h":
+		for i, collection := range d.collections {
+			if collection.ID == d.ordering.ID {
+				d.selectedIndex = i
+			}
+		}
+		d.ordering, d.recipes = nil, nil
+		d.err = d.loadCollections()
+
+	case keyMsg.String() == "K" || keyMsg.String() == "shift+up":
+		d.shift(-1)
+
+	case keyMsg.String() == "J" || keyMsg.String() == "shift+down":
+		d.shift(1)
+
+	case keyMsg.String() == "x" || key.Matches(keyMsg, d.deleteKey):
+		if d.selectedIndex < len(d.recipes) {
+			recipeID := d.recipes[d.selectedIndex].RecipeID
+			if d.err = d.cookbook.RemoveFromCollection(d.ordering.ID, recipeID); d.err == nil {
+				d.err = d.loadRecipes()
+			}
+		}
+
+	case keyMsg.String() == "up" || keyMsg.String() == "k":
+		d.move(-1, len(d.recipes))
+
+	case keyMsg.String() == "down" || keyMsg.String() == "j":
+		d.move(1, len(d.recipes))
+	}
+}
+
+// toggle adds the recipe to the collection, or takes it out
+func (d *CollectionsDialogCmp) toggle(collection utils.Collection) {
+	var err error
+	if d.member[collection.ID] {
+		err = d.cookbook.RemoveFromCollection(collection.ID, d.recipeID)
+	} else {
+		err = d.cookbook.AddToCollection(collection.ID, d.recipeID)
+	}
+	if err == nil {
+		err = d.loadCollections()
+	}
+	if err != nil {
+		slog.Error("Failed to update collection", "collection", collection.Name, "error", err)
+		d.err = err
+	}
+}
+
+// shift moves the selected recipe of the order pane by offset
+func (d *CollectionsDialogCmp) shift(offset int) {
+	if d.selectedIndex >= len(d.recipes) {
+		return
+	}
+	recipeID := d.recipes[d.selectedIndex].RecipeID
+	if d.err = d.cookbook.MoveInCollection(d.ordering.ID, recipeID, offset); d.err != nil {
+		return
+	}
+	if d.err = d.loadRecipes(); d.err == nil {
+		d.move(offset, len(d.recipes))
+	}
+}
+
+func (d *CollectionsDialogCmp) move(offset int, count int) {
+	next := d.selectedIndex + offset
+	if next >= 0 && next < count {
+		d.selectedIndex = next
+		d.ensureVisible()
+	}
+}
+
+func (d *CollectionsDialogCmp) ensureVisible() {
+	if d.selectedIndex < d.scrollOffset {
+		d.scrollOffset = d.selectedIndex
+	}
+	if d.selectedIndex >= d.scrollOffset+maxVisibleRecipes {
+		d.scrollOffset = d.selectedIndex - maxVisibleRecipes + 1
+	}
+}
+
+func (d *CollectionsDialogCmp) View() string {
+	innerWidth := d.width - 6
+	if innerWidth < 20 {
+		innerWidth = 20
+	}
+
+	// Header
+	title := "Collections"
+	if d.ordering != nil {
+		title = d.ordering.Name
+	}
+	titleLeft := d.theme.RecipeSelectorTitle.Render(title)
+	escHint := d.theme.RecipeSelectorHelp.Render("esc")
+	pad := innerWidth - lipgloss.Width(titleLeft) - lipgloss.Width(escHint)
+	if pad < 1 {
+		pad = 1
+	}
+	header := titleLeft + strings.Repeat(" ", pad) + escHint
+	sep := d.theme.RecipeSelectorHelp.Render(strings.Repeat("─", innerWidth))
+
+	var labels, details []string
+	var help string
+	if d.ordering != nil {
+		for i, recipe := range d.recipes {
+			labels = append(labels, fmt.Sprintf("%d. %s", i+1, recipe.RecipeName))
+			details = append(details, "")
+		}
+		help = fmt.Sprintf("K/J move • x remove • %s back", "⌫")
+	} else {
+		for _, collection := range d.collections {
+			marker := "[ ] "
+			if d.member[collection.ID] {
+				marker = "[x] "
+			}
+			labels = append(labels, marker+collection.Name)
+			details = append(details, recipeCount(collection.RecipeCount))
+		}
+		help = "space toggle • n new • o order"
+	}
+
+	end := min(d.scrollOffset+maxVisibleRecipes, len(labels))
+	var rows []string
+	if d.scrollOffset > 0 {
+		rows = append(rows, d.theme.RecipeSelectorHelp.Render(
+			strings.Repeat(" ", innerWidth/2-1)+"▲"))
+	}
+
+	for i := d.scrollOffset; i < end; i++ {
+		label := "  " + labels[i]
+		gap := max(innerWidth-lipgloss.Width(label)-lipgloss.Width(details[i]), 2)
+		if i == d.selectedIndex && !d.creating {
+			rows = append(rows, d.theme.RecipeSelectorSelected.
+				Width(innerWidth).
+				MaxWidth(innerWidth).
+				Render(label+strings.Repeat(" ", gap)+details[i]))
+		} else {
+			rows = append(rows, d.theme.DialogUnselectedRow.Render(label)+
+				strings.Repeat(" ", gap)+d.theme.RecipeSelectorHelp.Render(details[i]))
+		}
+	}
+
+	if end < len(labels) {
+		rows = append(rows, d.theme.RecipeSelectorHelp.Render(
+			strings.Repeat(" ", innerWidth/2-1)+"▼"))
+	}
+
+	if len(labels) == 0 {
+		if d.ordering != nil {
+			rows = append(rows, d.theme.RecipeSelectorHelp.Render("This collection is empty."))
+		} else {
+			rows = append(rows, d.theme.RecipeSelectorHelp.Render("No collections yet. Press n to create one."))
+		}
+	}
+
+	parts := []string{header, sep}
+	parts = append(parts, rows...)
+	if d.creating {
+		parts = append(parts, sep, d.nameInput.View())
+		help = "enter create and add • esc cancel"
+	}
+	if d.err != nil {
+		help = "⚠️  " + d.err.Error()
+	}
+	parts = append(parts, sep, d.theme.RecipeSelectorHelp.Width(innerWidth).Render(help))
+	content := lipgloss.JoinVertical(lipgloss.Left, parts...)
+
+	rendered := d.theme.RecipeSelectorDialog.
+		Width(d.width).
+		Render(content)
+
+	return d.theme.RecipeSelectorContainer.Render(rendered)
+}
+
+// recipeCount describes the size of a collection
+func recipeCount(n int) string {
+	if n == 1 {
+		return "1 recipe"
+	}
+	return fmt.Sprintf("%d recipes", n)
+}
+
+func (d *CollectionsDialogCmp) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+	if w := d.width - 8; w > 10 {
+		d.nameInput.Width = w
+	}
+}
+
+func (d *CollectionsDialogCmp) GetSize() (int, int) {
+	return d.width, d.height
+}
+
+func (d *CollectionsDialogCmp) GetModelState() common.ModelState {
+	return common.ModelStateLoaded
+}
@@ -20,6 +20,7 @@ const (
 	ActionStateSelector  = "state_selector"
 	ActionAddRecipe      = "add_recipe"
 	ActionRecipeSelector = "recipe_selector"
+	ActionSavedSearches  = "saved_searches"
 )
 
 // CommandItem represents a single command in the palette.
@@ -54,6 +55,7 @@ func NewCommandPaletteDialog(theme *themes.Theme) (*CommandPaletteDialogCmp, err
 		{Name: "Change Model", Shortcut: strings.Join(km.ModelSelector, " / "), Action: ActionModelSelector},
 		{Name: "Add Recipe from URL", Shortcut: strings.Join(km.Add, " / "), Action: ActionAddRecipe},
 		{Name: "Find Recipe", Shortcut: strings.Join(km.RecipeSelector, " / "), Action: ActionRecipeSelector},
+		{Name: "Collections", Action: ActionSavedSearches},
 	}
 
 	ti := textinput.New()
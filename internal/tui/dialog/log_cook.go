@@ -0,0 +1,233 @@
+package dialog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	common "github.com/GarroshIcecream/yummy/internal/models/common"
+	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
+	themes "github.com/GarroshIcecream/yummy/internal/themes"
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fields of the log cook dialog, in tab order
+const (
+	logCookDate = iota
+	logCookServings
+	logCookRating
+	logCookTweaks
+	logCookNotes
+	logCookFieldCount
+)
+
+var logCookLabels = [logCookFieldCount]string{"Date", "Servings", "Rating", "Tweaks", "Notes"}
+
+// LogCookDialogCmp records a recipe being cooked, or fills in the details of
+// a cook event already logged.
+type LogCookDialogCmp struct {
+	cookbook *db.CookBook
+	event    utils.CookEvent
+	title    string
+	inputs   [logCookFieldCount]textinput.Model
+	focused  int
+	err      error
+	width    int
+	height   int
+	theme    *themes.Theme
+}
+
+// NewLogCookDialog opens the dialog for a cook event. An event without an
+// ID is created when saved.
+func NewLogCookDialog(cookbook *db.CookBook, event utils.CookEvent, title string, theme *themes.Theme) (*LogCookDialogCmp, error) {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		return nil, fmt.Errorf("global config not set")
+	}
+
+	dialogConfig := cfg.RecipeSelectorDialog
+	if event.Date.IsZero() {
+		event.Date = utils.StartOfDay(time.Now())
+	}
+
+	d := &LogCookDialogCmp{
+		cookbook: cookbook,
+		event:    event,
+		title:    title,
+		width:    dialogConfig.Width,
+		height:   dialogConfig.Height,
+		theme:    theme,
+	}
+
+	placeholders := [logCookFieldCount]string{
+		"YYYY-MM-DD, today or yesterday",
+		"e.g. 4",
+		"1-5",
+		"e.g. doubled the garlic",
+		"How did it go?",
+	}
+	for i := range d.inputs {
+		ti := textinput.New()
+		ti.Placeholder = placeholders[i]
+		ti.CharLimit = 200
+		d.inputs[i] = ti
+	}
+	d.inputs[logCookDate].SetValue(event.Date.Format(utils.PlanDateLayout))
+	if event.Servings > 0 {
+		d.inputs[logCookServings].SetValue(strconv.FormatFloat(event.Servings, 'f', -1, 64))
+	}
+	if event.Rating > 0 {
+		d.inputs[logCookRating].SetValue(strconv.Itoa(int(event.Rating)))
+	}
+	d.inputs[logCookTweaks].SetValue(event.Tweaks)
+	d.inputs[logCookNotes].SetValue(event.Notes)
+	d.SetSize(d.width, d.height)
+
+	// A new event starts at its date, a logged one at its notes
+	if event.ID == 0 {
+		d.focused = logCookDate
+	} else {
+		d.focused = logCookRating
+	}
+	d.inputs[d.focused].Focus()
+	return d, nil
+}
+
+func (d *LogCookDialogCmp) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (d *LogCookDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			return d, messages.SendCloseModalViewMsg()
+
+		case "tab", "down":
+			return d, d.focus((d.focused + 1) % logCookFieldCount)
+
+		case "shift+tab", "up":
+			return d, d.focus((d.focused + logCookFieldCount - 1) % logCookFieldCount)
+
+		case "enter":
+			if err := d.save(); err != nil {
+				d.err = err
+				return d, nil
+			}
+			return d, tea.Sequence(messages.SendCloseModalViewMsg(), messages.SendCookLoggedMsg(d.event.RecipeID))
+		}
+	}
+
+	var cmd tea.Cmd
+	d.inputs[d.focused], cmd = d.inputs[d.focused].Update(msg)
+	d.err = nil
+	return d, cmd
+}
+
+func (d *LogCookDialogCmp) focus(field int) tea.Cmd {
+	d.inputs[d.focused].Blur()
+	d.focused = field
+	return d.inputs[d.focused].Focus()
+}
+
+// save validates the fields and writes the event
+func (d *LogCookDialogCmp) save() error {
+	event := d.event
+	date, err := utils.ParseCookDate(d.inputs[logCookDate].Value(), time.Now())
+	if err != nil {
+		return err
+	}
+	event.Date = date
+
+	event.Servings = 0
+	if text := strings.TrimSpace(d.inputs[logCookServings].Value()); text != "" {
+		servings, ok := utils.ParseQuantity(text)
+		if !ok || servings.Min <= 0 {
+			return fmt.Errorf("invalid servings %q", text)
+		}
+		event.Servings = servings.Min
+	}
+
+	event.Rating = 0
+	if text := strings.TrimSpace(d.inputs[logCookRating].Value()); text != "" {
+		rating, err := strconv.Atoi(text)
+		if err != nil || rating < 1 || rating > 5 {
+			return fmt.Errorf("rating must be a number from 1 to 5")
+		}
+		event.Rating = int8(rating)
+	}
+
+	event.Tweaks = strings.TrimSpace(d.inputs[logCookTweaks].Value())
+	event.Notes = strings.TrimSpace(d.inputs[logCookNotes].Value())
+
+	id, err := d.cookbook.LogCook(event)
+	if err != nil {
+		return err
+	}
+	event.ID = id
+	d.event = event
+	return nil
+}
+
+func (d *LogCookDialogCmp) View() string {
+	innerWidth := d.width - 6
+	if innerWidth < 20 {
+		innerWidth = 20
+	}
+
+	// Header
+	titleLeft := d.theme.RecipeSelectorTitle.Render(d.title)
+	escHint := d.theme.RecipeSelectorHelp.Render("esc")
+	pad := innerWidth - lipgloss.Width(titleLeft) - lipgloss.Width(escHint)
+	if pad < 1 {
+		pad = 1
+	}
+	header := titleLeft + strings.Repeat(" ", pad) + escHint
+	sep := d.theme.RecipeSelectorHelp.Render(strings.Repeat("─", innerWidth))
+
+	rows := []string{header, sep}
+	for i, input := range d.inputs {
+		label := fmt.Sprintf("%-9s", logCookLabels[i])
+		if i == d.focused {
+			label = d.theme.RecipeSelectorTitle.Render(label)
+		} else {
+			label = d.theme.RecipeSelectorHelp.Render(label)
+		}
+		rows = append(rows, label+input.View())
+	}
+
+	footer := "tab next field • enter save"
+	if d.err != nil {
+		footer = "⚠️  " + d.err.Error()
+	}
+	rows = append(rows, sep, d.theme.RecipeSelectorHelp.Width(innerWidth).Render(footer))
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
+
+	rendered := d.theme.RecipeSelectorDialog.
+		Width(d.width).
+		Render(content)
+
+	return d.theme.RecipeSelectorContainer.Render(rendered)
+}
+
+func (d *LogCookDialogCmp) SetSize(width, height int) {
+	d.width = width
+	d.height = height
+	for i := range d.inputs {
+		d.inputs[i].Width = max(d.width-20, 10)
+	}
+}
+
+func (d *LogCookDialogCmp) GetSize() (int, int) {
+	return d.width, d.height
+}
+
+func (d *LogCookDialogCmp) GetModelState() common.ModelState {
+	return common.ModelStateLoaded
+}
@@ -1,11 +1,14 @@
 package dialog
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"sort"
 	"strings"
 
 	"github.com/GarroshIcecream/yummy/internal/config"
+	yummy_llm "github.com/GarroshIcecream/yummy/internal/llm"
 	common "github.com/GarroshIcecream/yummy/internal/models/common"
 	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
 	themes "github.com/GarroshIcecream/yummy/internal/themes"
@@ -27,6 +30,30 @@ type ModelSelectorDialogCmp struct {
 	width         int
 	height        int
 	theme         *themes.Theme
+
+	// Pulling a model that is not installed yet
+	pulling      string // model being pulled
+	pullProgress yummy_llm.PullProgress
+	pullErr      error
+}
+
+// modelPullProgressMsg is an update of the model being pulled
+type modelPullProgressMsg struct {
+	progress yummy_llm.PullProgress
+	pull     *modelPull
+}
+
+// modelPullDoneMsg is sent when the pull has finished or failed
+type modelPullDoneMsg struct {
+	modelName string
+	err       error
+}
+
+// modelPull streams the progress of a pull from its goroutine to the dialog
+type modelPull struct {
+	modelName string
+	progress  chan yummy_llm.PullProgress
+	done      chan error
 }
 
 func NewModelSelectorDialog(installedModels []string, currentModelName string, theme *themes.Theme) (*ModelSelectorDialogCmp, error) {
@@ -71,6 +98,23 @@ func (m *ModelSelectorDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
+	case modelPullProgressMsg:
+		m.pullProgress = msg.progress
+		return m, listenForPull(msg.pull)
+
+	case modelPullDoneMsg:
+		m.pulling = ""
+		if msg.err != nil {
+			slog.Error("Failed to pull model", "model", msg.modelName, "error", msg.err)
+			m.pullErr = msg.err
+			return m, nil
+		}
+		return m, tea.Sequence(
+			messages.SendModelPulledMsg(msg.modelName),
+			messages.SendCloseModalViewMsg(),
+			messages.SendModelSelectedMsg(msg.modelName),
+		)
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "esc":
@@ -78,10 +122,16 @@ func (m *ModelSelectorDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 
 		case "enter":
+			if m.pulling != "" {
+				return m, nil
+			}
 			if len(m.filtered) > 0 && m.selectedIndex < len(m.filtered) {
 				selected := m.filtered[m.selectedIndex]
 				cmds = append(cmds, messages.SendModelSelectedMsg(selected.Name))
 				cmds = append(cmds, messages.SendCloseModalViewMsg())
+			} else if name := strings.TrimSpace(m.searchInput.Value()); name != "" && yummy_llm.CurrentProvider().CanPull() {
+				// Not installed: pull it from the Ollama library
+				cmds = append(cmds, m.startPull(name))
 			}
 			return m, tea.Batch(cmds...)
 
@@ -99,6 +149,10 @@ func (m *ModelSelectorDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if m.pulling != "" {
+		return m, nil
+	}
+
 	prevValue := m.searchInput.Value()
 	var cmd tea.Cmd
 	m.searchInput, cmd = m.searchInput.Update(msg)
@@ -106,11 +160,50 @@ func (m *ModelSelectorDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	if m.searchInput.Value() != prevValue {
 		m.applyFilter()
+		m.pullErr = nil
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
+// startPull pulls a model in the background. The pull goes on if the dialog
+// is closed, and the model shows up at the next Ollama check.
+func (m *ModelSelectorDialogCmp) startPull(modelName string) tea.Cmd {
+	m.pulling = modelName
+	m.pullProgress = yummy_llm.PullProgress{Status: "starting"}
+	m.pullErr = nil
+
+	pull := &modelPull{
+		modelName: modelName,
+		progress:  make(chan yummy_llm.PullProgress, 1),
+		done:      make(chan error, 1),
+	}
+	client := yummy_llm.NewOllamaClient(yummy_llm.OllamaHost())
+	go func() {
+		pull.done <- client.Pull(context.Background(), modelName, func(progress yummy_llm.PullProgress) {
+			// Drop updates the dialog has not caught up with, or that no one reads
+			select {
+			case pull.progress <- progress:
+			default:
+			}
+		})
+	}()
+
+	return listenForPull(pull)
+}
+
+// listenForPull waits for the next update of a pull
+func listenForPull(pull *modelPull) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case progress := <-pull.progress:
+			return modelPullProgressMsg{progress: progress, pull: pull}
+		case err := <-pull.done:
+			return modelPullDoneMsg{modelName: pull.modelName, err: err}
+		}
+	}
+}
+
 func (m *ModelSelectorDialogCmp) applyFilter() {
 	query := strings.ToLower(m.searchInput.Value())
 	if query == "" {
@@ -167,8 +260,23 @@ func (m *ModelSelectorDialogCmp) View() string {
 		}
 	}
 
-	if len(m.filtered) == 0 {
+	query := strings.TrimSpace(m.searchInput.Value())
+	canPull := yummy_llm.CurrentProvider().CanPull()
+	switch {
+	case m.pulling != "":
+		rows = append(rows,
+			m.theme.ModelSelectorTitle.Render("Pulling "+m.pulling+"…"),
+			m.theme.ModelSelectorHelp.Render(formatPullProgress(m.pullProgress, innerWidth)))
+	case m.pullErr != nil:
+		rows = append(rows, m.theme.ModelSelectorHelp.Width(innerWidth).Render("⚠️  "+m.pullErr.Error()))
+	case len(m.filtered) == 0 && query != "" && !canPull:
 		rows = append(rows, m.theme.ModelSelectorHelp.Render("No matching models"))
+	case len(m.filtered) == 0 && query != "":
+		rows = append(rows, m.theme.ModelSelectorHelp.Render("No matching models • enter to pull "+query))
+	case len(m.filtered) == 0 && !canPull:
+		rows = append(rows, m.theme.ModelSelectorHelp.Render("No models on "+yummy_llm.CurrentProvider().Name()))
+	case len(m.filtered) == 0:
+		rows = append(rows, m.theme.ModelSelectorHelp.Render("No models installed • type a name to pull it"))
 	}
 
 	parts := []string{header, "", searchLine, sep}
@@ -182,6 +290,19 @@ func (m *ModelSelectorDialogCmp) View() string {
 	return m.theme.ModelSelectorContainer.Render(rendered)
 }
 
+// formatPullProgress renders a pull update as its status and, while a layer
+// downloads, a progress bar
+func formatPullProgress(progress yummy_llm.PullProgress, width int) string {
+	percent := progress.Percent()
+	if percent < 0 {
+		return progress.Status
+	}
+	barWidth := max(width-lipgloss.Width(progress.Status)-8, 10)
+	filled := barWidth * percent / 100
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	return fmt.Sprintf("%s %s %3d%%", progress.Status, bar, percent)
+}
+
 func (m *ModelSelectorDialogCmp) SetSize(width, height int) {
 	m.width = width
 	m.height = height
@@ -30,6 +30,9 @@ type RecipeSelectorDialogCmp struct {
 	width         int
 	height        int
 	theme         *themes.Theme
+
+	// onSelect replaces opening the chosen recipe in the detail view
+	onSelect func(recipeID uint) tea.Cmd
 }
 
 func NewRecipeSelectorDialog(cookbook *db.CookBook, theme *themes.Theme) (*RecipeSelectorDialogCmp, error) {
@@ -70,6 +73,13 @@ func NewRecipeSelectorDialog(cookbook *db.CookBook, theme *themes.Theme) (*Recip
 	}, nil
 }
 
+// OnSelect makes the dialog hand the chosen recipe to fn instead of opening
+// it, for views that pick a recipe for something (e.g. a meal plan slot).
+func (r *RecipeSelectorDialogCmp) OnSelect(fn func(recipeID uint) tea.Cmd) *RecipeSelectorDialogCmp {
+	r.onSelect = fn
+	return r
+}
+
 func (r *RecipeSelectorDialogCmp) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -87,6 +97,9 @@ func (r *RecipeSelectorDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			if len(r.filtered) > 0 && r.selectedIndex < len(r.filtered) {
 				selected := r.filtered[r.selectedIndex]
+				if r.onSelect != nil {
+					return r, tea.Sequence(messages.SendCloseModalViewMsg(), r.onSelect(selected.ID))
+				}
 				return r, tea.Sequence(
 					messages.SendCloseModalViewMsg(),
 					messages.SendSessionStateMsg(common.SessionStateDetail),
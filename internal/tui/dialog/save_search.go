@@ -0,0 +1,128 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	common "github.com/GarroshIcecream/yummy/internal/models/common"
+	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
+	themes "github.com/GarroshIcecream/yummy/internal/themes"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SaveSearchDialogCmp asks for a name to save the list filter's query under.
+type SaveSearchDialogCmp struct {
+	cookbook  *db.CookBook
+	query     string
+	nameInput textinput.Model
+	err       error
+	width     int
+	height    int
+	theme     *themes.Theme
+}
+
+func NewSaveSearchDialog(cookbook *db.CookBook, query string, theme *themes.Theme) (*SaveSearchDialogCmp, error) {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		return nil, fmt.Errorf("global config not set")
+	}
+
+	dialogConfig := cfg.RecipeSelectorDialog
+	ti := textinput.New()
+	ti.Placeholder = "Name, e.g. Quick weeknight"
+	ti.Focus()
+	ti.CharLimit = 64
+	if w := dialogConfig.Width - 8; w > 10 {
+		ti.Width = w
+	} else {
+		ti.Width = 40
+	}
+
+	return &SaveSearchDialogCmp{
+		cookbook:  cookbook,
+		query:     query,
+		nameInput: ti,
+		width:     dialogConfig.Width,
+		height:    dialogConfig.Height,
+		theme:     theme,
+	}, nil
+}
+
+func (s *SaveSearchDialogCmp) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (s *SaveSearchDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			return s, messages.SendCloseModalViewMsg()
+
+		case "enter":
+			name := strings.TrimSpace(s.nameInput.Value())
+			if name == "" {
+				return s, nil
+			}
+			if _, err := s.cookbook.SaveSearch(name, s.query); err != nil {
+				s.err = err
+				return s, nil
+			}
+			return s, tea.Sequence(messages.SendCloseModalViewMsg(), messages.SendSearchSavedMsg(name))
+		}
+	}
+
+	var cmd tea.Cmd
+	s.nameInput, cmd = s.nameInput.Update(msg)
+	s.err = nil
+	return s, cmd
+}
+
+func (s *SaveSearchDialogCmp) View() string {
+	innerWidth := s.width - 6
+	if innerWidth < 20 {
+		innerWidth = 20
+	}
+
+	// Header
+	titleLeft := s.theme.RecipeSelectorTitle.Render("Save Search")
+	escHint := s.theme.RecipeSelectorHelp.Render("esc")
+	pad := innerWidth - lipgloss.Width(titleLeft) - lipgloss.Width(escHint)
+	if pad < 1 {
+		pad = 1
+	}
+	header := titleLeft + strings.Repeat(" ", pad) + escHint
+	sep := s.theme.RecipeSelectorHelp.Render(strings.Repeat("─", innerWidth))
+
+	query := s.theme.RecipeSelectorHelp.Width(innerWidth).Render("Query: " + s.query)
+	footer := s.theme.RecipeSelectorHelp.Width(innerWidth).Render("enter save • saving under an existing name replaces it")
+	if s.err != nil {
+		footer = s.theme.RecipeSelectorHelp.Width(innerWidth).Render("⚠️  " + s.err.Error())
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, header, "", s.nameInput.View(), sep, query, "", footer)
+	rendered := s.theme.RecipeSelectorDialog.
+		Width(s.width).
+		Render(content)
+
+	return s.theme.RecipeSelectorContainer.Render(rendered)
+}
+
+func (s *SaveSearchDialogCmp) SetSize(width, height int) {
+	s.width = width
+	s.height = height
+	if w := s.width - 8; w > 10 {
+		s.nameInput.Width = w
+	}
+}
+
+func (s *SaveSearchDialogCmp) GetSize() (int, int) {
+	return s.width, s.height
+}
+
+func (s *SaveSearchDialogCmp) GetModelState() common.ModelState {
+	return common.ModelStateLoaded
+}
@@ -0,0 +1,232 @@
+package dialog
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	common "github.com/GarroshIcecream/yummy/internal/models/common"
+	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
+	themes "github.com/GarroshIcecream/yummy/internal/themes"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SavedSearchesDialogCmp lists the collections and saved searches. Choosing
+// one shows the recipe list filtered to its recipes.
+type SavedSearchesDialogCmp struct {
+	cookbook      *db.CookBook
+	entries       []collectionEntry
+	selectedIndex int
+	scrollOffset  int
+	deleteKey     key.Binding
+	width         int
+	height        int
+	theme         *themes.Theme
+}
+
+// collectionEntry is a row of the dialog: a manual collection, shown with its
+// recipe count, or a saved search, shown with its query
+type collectionEntry struct {
+	name         string
+	detail       string
+	query        string
+	collectionID uint
+	searchID     uint
+}
+
+func NewSavedSearchesDialog(cookbook *db.CookBook, theme *themes.Theme) (*SavedSearchesDialogCmp, error) {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		return nil, fmt.Errorf("global config not set")
+	}
+
+	collections, err := cookbook.Collections()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load collections: %w", err)
+	}
+	searches, err := cookbook.SavedSearches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved searches: %w", err)
+	}
+
+	var entries []collectionEntry
+	for _, collection := range collections {
+		entries = append(entries, collectionEntry{
+			name:         collection.Name,
+			detail:       recipeCount(collection.RecipeCount),
+			query:        "@collection " + collection.Slug,
+			collectionID: collection.ID,
+		})
+	}
+	for _, search := range searches {
+		entries = append(entries, collectionEntry{
+			name:     search.Name,
+			detail:   search.Query,
+			query:    search.Query,
+			searchID: search.ID,
+		})
+	}
+
+	return &SavedSearchesDialogCmp{
+		cookbook:  cookbook,
+		entries:   entries,
+		deleteKey: cfg.Keymap.ToKeyMap().Delete,
+		width:     cfg.RecipeSelectorDialog.Width,
+		height:    cfg.RecipeSelectorDialog.Height,
+		theme:     theme,
+	}, nil
+}
+
+func (s *SavedSearchesDialogCmp) Init() tea.Cmd {
+	return nil
+}
+
+func (s *SavedSearchesDialogCmp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return s, nil
+	}
+
+	switch {
+	case keyMsg.String() == "esc":
+		return s, messages.SendCloseModalViewMsg()
+
+	case keyMsg.String() == "enter":
+		if s.selectedIndex < len(s.entries) {
+			selected := s.entries[s.selectedIndex]
+			return s, tea.Sequence(
+				messages.SendCloseModalViewMsg(),
+				messages.SendSessionStateMsg(common.SessionStateList),
+				messages.SendApplyFilterMsg(selected.name, selected.query),
+			)
+		}
+
+	case key.Matches(keyMsg, s.deleteKey):
+		if s.selectedIndex < len(s.entries) {
+			selected := s.entries[s.selectedIndex]
+			var err error
+			if selected.collectionID != 0 {
+				err = s.cookbook.DeleteCollection(selected.collectionID)
+			} else {
+				err = s.cookbook.DeleteSavedSearch(selected.searchID)
+			}
+			if err != nil {
+				slog.Error("Failed to delete collection", "name", selected.name, "error", err)
+				return s, nil
+			}
+			s.entries = append(s.entries[:s.selectedIndex], s.entries[s.selectedIndex+1:]...)
+			if s.selectedIndex >= len(s.entries) && s.selectedIndex > 0 {
+				s.selectedIndex--
+			}
+			s.ensureVisible()
+		}
+
+	case keyMsg.String() == "up" || keyMsg.String() == "k":
+		if s.selectedIndex > 0 {
+			s.selectedIndex--
+			s.ensureVisible()
+		}
+
+	case keyMsg.String() == "down" || keyMsg.String() == "j":
+		if s.selectedIndex < len(s.entries)-1 {
+			s.selectedIndex++
+			s.ensureVisible()
+		}
+	}
+
+	return s, nil
+}
+
+func (s *SavedSearchesDialogCmp) ensureVisible() {
+	if s.selectedIndex < s.scrollOffset {
+		s.scrollOffset = s.selectedIndex
+	}
+	if s.selectedIndex >= s.scrollOffset+maxVisibleRecipes {
+		s.scrollOffset = s.selectedIndex - maxVisibleRecipes + 1
+	}
+}
+
+func (s *SavedSearchesDialogCmp) View() string {
+	innerWidth := s.width - 6
+	if innerWidth < 20 {
+		innerWidth = 20
+	}
+
+	// Header
+	titleLeft := s.theme.RecipeSelectorTitle.Render("Collections")
+	escHint := s.theme.RecipeSelectorHelp.Render("esc")
+	pad := innerWidth - lipgloss.Width(titleLeft) - lipgloss.Width(escHint)
+	if pad < 1 {
+		pad = 1
+	}
+	header := titleLeft + strings.Repeat(" ", pad) + escHint
+	sep := s.theme.RecipeSelectorHelp.Render(strings.Repeat("─", innerWidth))
+
+	end := min(s.scrollOffset+maxVisibleRecipes, len(s.entries))
+	var rows []string
+	if s.scrollOffset > 0 {
+		rows = append(rows, s.theme.RecipeSelectorHelp.Render(
+			strings.Repeat(" ", innerWidth/2-1)+"▲"))
+	}
+
+	for i, entry := range s.entries[s.scrollOffset:end] {
+		// Name left, recipe count or query right, truncated to fit
+		name := "  " + entry.name
+		query := entry.detail
+		room := innerWidth - lipgloss.Width(name) - 2
+		if runes := []rune(query); room < len(runes) {
+			query = string(runes[:max(room-1, 0)]) + "…"
+		}
+		gap := max(innerWidth-lipgloss.Width(name)-lipgloss.Width(query), 2)
+
+		if s.scrollOffset+i == s.selectedIndex {
+			rows = append(rows, s.theme.RecipeSelectorSelected.
+				Width(innerWidth).
+				MaxWidth(innerWidth).
+				Render(name+strings.Repeat(" ", gap)+query))
+		} else {
+			rows = append(rows, s.theme.DialogUnselectedRow.Render(name)+
+				strings.Repeat(" ", gap)+s.theme.RecipeSelectorHelp.Render(query))
+		}
+	}
+
+	if end < len(s.entries) {
+		rows = append(rows, s.theme.RecipeSelectorHelp.Render(
+			strings.Repeat(" ", innerWidth/2-1)+"▼"))
+	}
+
+	if len(s.entries) == 0 {
+		rows = append(rows, s.theme.RecipeSelectorHelp.Render("No collections yet. Add a recipe to one from its"))
+		rows = append(rows, s.theme.RecipeSelectorHelp.Render("detail view, or save a filter from the list."))
+	}
+
+	help := s.theme.RecipeSelectorHelp.Render(fmt.Sprintf("enter show • %s delete", s.deleteKey.Help().Key))
+
+	parts := []string{header, sep}
+	parts = append(parts, rows...)
+	parts = append(parts, sep, help)
+	content := lipgloss.JoinVertical(lipgloss.Left, parts...)
+
+	rendered := s.theme.RecipeSelectorDialog.
+		Width(s.width).
+		Render(content)
+
+	return s.theme.RecipeSelectorContainer.Render(rendered)
+}
+
+func (s *SavedSearchesDialogCmp) SetSize(width, height int) {
+	s.width = width
+	s.height = height
+}
+
+func (s *SavedSearchesDialogCmp) GetSize() (int, int) {
+	return s.width, s.height
+}
+
+func (s *SavedSearchesDialogCmp) GetModelState() common.ModelState {
+	return common.ModelStateLoaded
+}
@@ -43,6 +43,9 @@ func NewStateSelectorDialog(theme *themes.Theme) (*StateSelectorDialogCmp, error
 		common.SessionStateDetail,
 		common.SessionStateEdit,
 		common.SessionStateChat,
+		common.SessionStateShoppingList,
+		common.SessionStatePantry,
+		common.SessionStateMealPlan,
 	}
 
 	return &StateSelectorDialogCmp{
@@ -48,10 +48,17 @@ type EditModel struct {
 	servings    string
 	url         string
 	categories  []string
+	notes       string
 
-	// Ingredients and instructions
-	ingredients  []utils.Ingredient
-	instructions []string
+	// Ingredients and instructions, edited as one line per item with
+	// "# Title" lines for ingredient groups and instruction sections
+	ingredients      []utils.Ingredient
+	instructions     []string
+	ingredientsText  string
+	instructionsText string
+
+	// Loaded metadata, so fields without a form input survive a save
+	metadata utils.RecipeMetadata
 
 	// Forms
 	mainForm        *huh.Form
@@ -186,8 +193,12 @@ func (m *EditModel) loadRecipe(recipe *utils.RecipeRaw) {
 	m.servings = recipe.Metadata.Quantity
 	m.url = recipe.Metadata.URL
 	m.categories = recipe.Metadata.Categories
+	m.notes = recipe.Metadata.Notes
 	m.ingredients = recipe.Metadata.Ingredients
 	m.instructions = recipe.Metadata.Instructions
+	m.ingredientsText = utils.FormatIngredientsText(recipe.Metadata.Ingredients)
+	m.instructionsText = utils.FormatInstructionsText(recipe.Metadata)
+	m.metadata = recipe.Metadata
 }
 
 func (m *EditModel) extractFormRecipe() (*utils.RecipeRaw, error) {
@@ -200,6 +211,9 @@ func (m *EditModel) extractFormRecipe() (*utils.RecipeRaw, error) {
 		return nil, err
 	}
 
+	ingredients := utils.ParseIngredientsText(m.mainForm.GetString("ingredients"), m.ingredients)
+	instructions, sections, stepNotes := utils.ParseInstructionsText(m.mainForm.GetString("instructions"))
+
 	recipe := &utils.RecipeRaw{
 		RecipeName:        m.mainForm.GetString("name"),
 		RecipeDescription: m.mainForm.GetString("description"),
@@ -211,10 +225,25 @@ func (m *EditModel) extractFormRecipe() (*utils.RecipeRaw, error) {
 			Quantity:     m.mainForm.GetString("servings"),
 			URL:          m.mainForm.GetString("url"),
 			Categories:   m.mainForm.Get("categories").([]string),
-			Ingredients:  m.mainForm.Get("ingredients").([]utils.Ingredient),
-			Instructions: m.mainForm.Get("instructions").([]string),
+			Ingredients:  ingredients,
+			Instructions: instructions,
+			ImageURL:     m.metadata.ImageURL,
+			Language:     m.metadata.Language,
+			Favourite:    m.metadata.Favourite,
+			Rating:       m.metadata.Rating,
+			Cuisines:     m.metadata.Cuisines,
+			Keywords:     m.metadata.Keywords,
+			Diets:        m.metadata.Diets,
+			Nutrients:    m.metadata.Nutrients,
+			Notes:        strings.TrimSpace(m.mainForm.GetString("notes")),
+			StepNotes:    stepNotes,
+
+			InstructionSections: sections,
 		},
 	}
+	if m.recipeID != nil {
+		recipe.RecipeID = *m.recipeID
+	}
 
 	return recipe, nil
 }
@@ -316,6 +345,30 @@ func (m *EditModel) setupForms() {
 				Placeholder("https://example.com/recipe").
 				Validate(utils.ValidateURL),
 
+			huh.NewText().
+				Key("ingredients").
+				Title("Ingredients").
+				Description("One ingredient per line; start a line with '#' to begin a group (e.g. '# For the sauce')").
+				Value(&m.ingredientsText).
+				Lines(8).
+				Placeholder("# For the dough\n2 cup flour (sifted)"),
+
+			huh.NewText().
+				Key("instructions").
+				Title("Instructions").
+				Description("One step per line; start a line with '#' to begin a section and with '>' to annotate the step above").
+				Value(&m.instructionsText).
+				Lines(8).
+				Placeholder("# For the dough\nMix the flour with water.\n> Use less water next time"),
+
+			huh.NewText().
+				Key("notes").
+				Title("Notes").
+				Description("Your own notes on the recipe (markdown)").
+				Value(&m.notes).
+				Lines(5).
+				Placeholder("Great with a squeeze of lemon..."),
+
 			huh.NewMultiSelect[string]().
 				Key("categories").
 				Title("Categories").
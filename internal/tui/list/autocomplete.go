@@ -2,143 +2,62 @@ package list
 
 import (
 	"strings"
+	"unicode"
 
 	db "github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/GarroshIcecream/yummy/internal/utils"
 )
 
-// generateFilterSuggestions generates autocomplete suggestions based on the query
-func generateFilterSuggestions(query string, cookbook *db.CookBook) []string {
-	query = strings.TrimSpace(query)
-	queryLower := strings.ToLower(query)
-	var suggestions []string
-	maxItems := 10
+// maxSuggestions caps the number of autocomplete suggestions
+const maxSuggestions = 10
 
-	if query == "" {
+// generateFilterSuggestions suggests completions for the last term of the
+// filter query: a command after "@", a value after a field command, or a
+// recipe name. Each suggestion is the whole query with that term completed,
+// since the filter input matches suggestions against its whole value.
+func generateFilterSuggestions(query string, cookbook *db.CookBook) []string {
+	if strings.TrimSpace(query) == "" {
 		// Show filter commands when empty
-		return []string{
-			"@author ",
-			"@category ",
-			"@ingredients ",
-			"@description ",
-			"@url ",
-			"@fav",
-		}
+		return utils.QueryCommands
 	}
 
-	// Check if it's a filter command
-	if strings.HasPrefix(query, "@") {
-		// Handle filter commands
-		if authorPrefix, ok := strings.CutPrefix(query, "@author "); ok {
-			authors, err := cookbook.GetAllAuthors()
-			if err == nil {
-				for _, author := range authors {
-					if strings.HasPrefix(strings.ToLower(author), strings.ToLower(authorPrefix)) {
-						suggestions = append(suggestions, "@author "+author)
-						if len(suggestions) >= maxItems {
-							break
-						}
-					}
-				}
-			}
-		} else if categoryPrefix, ok := strings.CutPrefix(query, "@category "); ok {
-			categories, err := cookbook.GetAllCategories()
-			if err == nil {
-				for _, category := range categories {
-					if strings.HasPrefix(strings.ToLower(category), strings.ToLower(categoryPrefix)) {
-						suggestions = append(suggestions, "@category "+category)
-						if len(suggestions) >= maxItems {
-							break
-						}
-					}
-				}
-			}
-		} else if ingredientPrefix, ok := strings.CutPrefix(query, "@ingredients "); ok {
-			recipes, err := cookbook.AllRecipes()
-			if err == nil {
-				ingredientMap := make(map[string]bool)
-				for _, recipe := range recipes {
-					for _, ing := range recipe.Metadata.Ingredients {
-						ingName := strings.ToLower(ing.Name)
-						if strings.HasPrefix(ingName, strings.ToLower(ingredientPrefix)) {
-							if !ingredientMap[ing.Name] {
-								ingredientMap[ing.Name] = true
-								suggestions = append(suggestions, "@ingredients "+ing.Name)
-								if len(suggestions) >= maxItems {
-									break
-								}
-							}
-						}
-					}
-					if len(suggestions) >= maxItems {
-						break
-					}
-				}
-			}
-		} else if descPrefix, ok := strings.CutPrefix(query, "@description "); ok {
-			recipes, err := cookbook.AllRecipes()
-			if err == nil {
-				descMap := make(map[string]bool)
-				for _, recipe := range recipes {
-					if recipe.RecipeDescription != "" {
-						desc := strings.ToLower(recipe.RecipeDescription)
-						if strings.Contains(desc, strings.ToLower(descPrefix)) {
-							shortDesc := recipe.RecipeDescription
-							if len(shortDesc) > 50 {
-								shortDesc = shortDesc[:47] + "..."
-							}
-							key := "@description " + shortDesc
-							if !descMap[key] && len(suggestions) < maxItems {
-								descMap[key] = true
-								suggestions = append(suggestions, key)
-							}
-						}
-					}
-				}
-			}
-		} else if urlPrefix, ok := strings.CutPrefix(query, "@url "); ok {
-			recipes, err := cookbook.AllRecipes()
-			if err == nil {
-				urlMap := make(map[string]bool)
-				for _, recipe := range recipes {
-					if recipe.Metadata.URL != "" {
-						url := strings.ToLower(recipe.Metadata.URL)
-						if strings.Contains(url, strings.ToLower(urlPrefix)) {
-							if !urlMap[recipe.Metadata.URL] {
-								urlMap[recipe.Metadata.URL] = true
-								suggestions = append(suggestions, "@url "+recipe.Metadata.URL)
-								if len(suggestions) >= maxItems {
-									break
-								}
-							}
-						}
-					}
-				}
+	head, term := splitLastTerm(query)
+	// A negation or group opens the term it applies to
+	trimmed := strings.TrimLeft(term, "-(")
+	head, term = head+term[:len(term)-len(trimmed)], trimmed
+	termLower := strings.ToLower(strings.TrimPrefix(term, `"`))
+
+	var suggestions []string
+	switch field, ok := commandBefore(head); {
+	case strings.HasPrefix(term, "@"):
+		for _, command := range utils.QueryCommands {
+			if strings.HasPrefix(command, termLower) {
+				suggestions = append(suggestions, head+command)
 			}
-		} else {
-			// Suggest filter commands
-			filterCommands := []string{
-				"@author ",
-				"@category ",
-				"@ingredients ",
-				"@description ",
-				"@url ",
-				"@fav",
+		}
+
+	case ok:
+		seen := make(map[string]bool)
+		for _, value := range filterValues(field, cookbook) {
+			key := strings.ToLower(value)
+			if seen[key] || !strings.HasPrefix(key, termLower) {
+				continue
 			}
-			for _, cmd := range filterCommands {
-				if strings.HasPrefix(cmd, queryLower) {
-					suggestions = append(suggestions, cmd)
-				}
+			seen[key] = true
+			suggestions = append(suggestions, head+quoteFilterValue(value))
+			if len(suggestions) >= maxSuggestions {
+				break
 			}
 		}
-	} else {
+
+	case strings.TrimSpace(head) == "":
 		// Regular recipe name search - suggest recipe names
 		recipes, err := cookbook.AllRecipes()
 		if err == nil {
 			for _, recipe := range recipes {
-				recipeName := recipe.RecipeName
-				if strings.HasPrefix(strings.ToLower(recipeName), queryLower) {
-					suggestions = append(suggestions, recipeName)
-					if len(suggestions) >= maxItems {
+				if strings.HasPrefix(strings.ToLower(recipe.RecipeName), termLower) {
+					suggestions = append(suggestions, head+recipe.RecipeName)
+					if len(suggestions) >= maxSuggestions {
 						break
 					}
 				}
@@ -148,3 +67,97 @@ func generateFilterSuggestions(query string, cookbook *db.CookBook) []string {
 
 	return suggestions
 }
+
+// splitLastTerm splits the query before its last term, the text after the
+// last space that is not inside a quoted phrase
+func splitLastTerm(query string) (string, string) {
+	start, inQuote := 0, false
+	for i, r := range query {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case unicode.IsSpace(r) && !inQuote:
+			start = i + 1
+		}
+	}
+	return query[:start], query[start:]
+}
+
+// commandBefore returns the field command ending head, if any, so the term
+// after it is that command's value
+func commandBefore(head string) (string, bool) {
+	fields := strings.Fields(head)
+	if len(fields) == 0 || !strings.HasSuffix(head, " ") {
+		return "", false
+	}
+	last := strings.TrimLeft(fields[len(fields)-1], "-(")
+	name, ok := strings.CutPrefix(strings.ToLower(last), "@")
+	if !ok {
+		return "", false
+	}
+	_, known := utils.QueryFields[name]
+	return name, known || name == "collection"
+}
+
+// filterValues lists the known values of a field command. Descriptions are
+// free text, so they have none.
+func filterValues(command string, cookbook *db.CookBook) []string {
+	var (
+		values []string
+		err    error
+	)
+	switch command {
+	case "author":
+		values, err = cookbook.GetAllAuthors()
+	case "category":
+		values, err = cookbook.GetAllCategories()
+	case "ingredients":
+		values, err = cookbook.GetAllIngredientNames()
+	case "collection":
+		var (
+			collections []utils.Collection
+			searches    []utils.SavedSearch
+		)
+		if collections, err = cookbook.Collections(); err == nil {
+			searches, err = cookbook.SavedSearches()
+		}
+		for _, collection := range collections {
+			values = append(values, collection.Name)
+		}
+		for _, search := range searches {
+			values = append(values, search.Name)
+		}
+	case "name", "url":
+		var recipes []utils.RecipeRaw
+		recipes, err = cookbook.AllRecipes()
+		for _, recipe := range recipes {
+			switch command {
+			case "name":
+				values = append(values, recipe.RecipeName)
+			case "url":
+				values = append(values, recipe.Metadata.URL)
+			}
+		}
+	}
+	if err != nil {
+		return nil
+	}
+
+	nonEmpty := values[:0]
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	return nonEmpty
+}
+
+// quoteFilterValue quotes a value that is not a single word of the query
+func quoteFilterValue(value string) string {
+	if strings.ContainsFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`()|"`, r)
+	}) {
+		return `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	}
+	return value
+}
@@ -0,0 +1,36 @@
+package list
+
+import (
+	"io"
+	"strings"
+
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// searchDelegate renders recipes like the default delegate, but while the
+// list is filtered it shows where each recipe matched instead of its
+// description.
+type searchDelegate struct {
+	list.DefaultDelegate
+	snippets *snippetCache
+}
+
+func newSearchDelegate(styles list.DefaultItemStyles, snippets *snippetCache) searchDelegate {
+	d := list.NewDefaultDelegate()
+	d.Styles = styles
+	return searchDelegate{DefaultDelegate: d, snippets: snippets}
+}
+
+func (d searchDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if recipe, ok := item.(utils.RecipeRaw); ok && m.FilterState() != list.Unfiltered {
+		if snippet, ok := d.snippets.get(recipe.RecipeID); ok && snippet != "" {
+			snippet = strings.ReplaceAll(snippet, db.SnippetStart, "")
+			snippet = strings.ReplaceAll(snippet, db.SnippetEnd, "")
+			recipe.RecipeDescription = strings.Join(strings.Fields(snippet), " ")
+			item = recipe
+		}
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
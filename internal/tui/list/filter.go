@@ -1,239 +1,132 @@
 package list
 
 import (
-	"encoding/json"
 	"log/slog"
+	"strconv"
 	"strings"
+	"sync"
+	"unicode"
 
-	common "github.com/GarroshIcecream/yummy/internal/models/common"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	"github.com/GarroshIcecream/yummy/internal/utils"
 	"github.com/charmbracelet/bubbles/list"
 )
 
-// CustomFilter handles filtering with special commands like @author and @category
-func CustomFilter(query string, targets []string) []list.Rank {
-	query = strings.TrimSpace(query)
-
-	// Handle special filter commands
-	if strings.HasPrefix(query, "@author ") {
-		author := strings.TrimSpace(strings.TrimPrefix(query, "@author "))
-		if author == "" {
-			return []list.Rank{}
-		}
-		return filterByJSONField(author, targets, common.AuthorField)
-	}
+// snippetCache holds the search snippets of the current filter by recipe ID.
+// The list runs its filter in a command, so access is locked.
+type snippetCache struct {
+	mu   sync.RWMutex
+	byID map[uint]string
+}
 
-	if strings.HasPrefix(query, "@category ") {
-		categoryInput := strings.TrimSpace(strings.TrimPrefix(query, "@category "))
-		if categoryInput == "" {
-			return []list.Rank{}
-		}
-		return filterByArrayField(categoryInput, targets, common.CategoryField)
-	}
+func (c *snippetCache) set(snippets map[uint]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byID = snippets
+}
 
-	if strings.HasPrefix(query, "@description ") {
-		description := strings.TrimSpace(strings.TrimPrefix(query, "@description "))
-		if description == "" {
-			return []list.Rank{}
-		}
-		return filterByJSONField(description, targets, common.DescriptionField)
-	}
+func (c *snippetCache) get(recipeID uint) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snippet, ok := c.byID[recipeID]
+	return snippet, ok
+}
 
-	if strings.HasPrefix(query, "@ingredients ") {
-		ingredientsInput := strings.TrimSpace(strings.TrimPrefix(query, "@ingredients "))
-		if ingredientsInput == "" {
+// newSearchFilter returns the list's filter function. It parses the filter
+// input as a query (see utils.ParseQuery), runs it over the cookbook and
+// returns the matching items best first, keeping each match's snippet in
+// snippets. Input that does not parse yet matches nothing.
+func newSearchFilter(cookbook *db.CookBook, snippets *snippetCache) list.FilterFunc {
+	return func(term string, targets []string) []list.Rank {
+		query, err := utils.ParseQuery(term)
+		if err != nil {
+			slog.Debug("Invalid filter query", "query", term, "error", err)
 			return []list.Rank{}
 		}
-		return filterByArrayField(ingredientsInput, targets, common.IngredientsField)
-	}
 
-	if strings.HasPrefix(query, "@url ") {
-		url := strings.TrimSpace(strings.TrimPrefix(query, "@url "))
-		if url == "" {
+		results, err := cookbook.SearchQuery(query)
+		if err != nil {
+			slog.Error("Failed to search recipes", "query", term, "error", err)
 			return []list.Rank{}
 		}
-		return filterByJSONField(url, targets, common.URLField)
-	}
-
-	if strings.HasPrefix(query, "@fav") {
-		return filterFieldBool(targets, common.FavouriteField)
-	}
-
-	// Default to fuzzy search on title field for regular text
-	return filterByJSONField(query, targets, common.TitleField)
-}
-
-func filterFieldBool(targets []string, fieldName common.FilterField) []list.Rank {
-	var ranks []list.Rank
-	for i, target := range targets {
-		var filterData map[string]any
-		if err := json.Unmarshal([]byte(target), &filterData); err != nil {
-			continue
-		}
 
-		value, exists := filterData[string(fieldName)]
-		if !exists {
-			continue
-		}
-
-		if b, ok := value.(bool); ok && b {
-			ranks = append(ranks, list.Rank{
-				Index: i,
-			})
-		}
-	}
-	return ranks
-}
-
-// filterByArrayField filters recipes by a comma-separated list for array fields (categories, ingredients)
-func filterByArrayField(input string, targets []string, fieldName common.FilterField) []list.Rank {
-	var ranks []list.Rank
-
-	// Split the input by commas and trim whitespace
-	parts := strings.Split(input, ",")
-	var searchTerms []string
-	for _, part := range parts {
-		trimmed := strings.TrimSpace(part)
-		if trimmed != "" {
-			searchTerms = append(searchTerms, trimmed)
-		}
-	}
-
-	if len(searchTerms) == 0 {
-		return []list.Rank{}
-	}
-
-	for i, target := range targets {
-		// Try to parse the target as JSON
-		var filterData map[string]interface{}
-		if err := json.Unmarshal([]byte(target), &filterData); err != nil {
-			// If JSON parsing fails, fall back to simple string matching with AND logic
-			allTermsMatched := true
-			var firstMatchedTerm string
-			for _, searchTerm := range searchTerms {
-				if !strings.Contains(strings.ToLower(target), strings.ToLower(searchTerm)) {
-					allTermsMatched = false
-					break
-				}
-				if firstMatchedTerm == "" {
-					firstMatchedTerm = searchTerm
-				}
+		indexByID := make(map[uint]int, len(targets))
+		for i, target := range targets {
+			if id, ok := filterValueID(target); ok {
+				indexByID[id] = i
 			}
-
-			if allTermsMatched && firstMatchedTerm != "" {
-				matchedIndexes := findMatchedIndices(target, firstMatchedTerm)
-				ranks = append(ranks, list.Rank{
-					Index:          i,
-					MatchedIndexes: matchedIndexes,
-				})
-			}
-			continue
 		}
 
-		// Get the specified field from the JSON
-		fieldValue, exists := filterData[string(fieldName)]
-		if !exists {
-			continue
-		}
-
-		// Handle array fields
-		var fieldArray []string
-		switch v := fieldValue.(type) {
-		case []interface{}:
-			for _, item := range v {
-				if str, ok := item.(string); ok {
-					fieldArray = append(fieldArray, str)
-				}
+		words := highlightWords(query)
+		found := make(map[uint]string, len(results))
+		ranks := make([]list.Rank, 0, len(results))
+		for _, result := range results {
+			index, ok := indexByID[result.Recipe.RecipeID]
+			if !ok {
+				continue
 			}
-		default:
-			continue
-		}
-
-		// Check if ALL search terms match any of the field values (AND logic)
-		allTermsMatched := true
-		var matchedTerms []string
+			found[result.Recipe.RecipeID] = result.Snippet
 
-		for _, searchTerm := range searchTerms {
-			termFound := false
-			for _, fieldItem := range fieldArray {
-				if strings.Contains(strings.ToLower(fieldItem), strings.ToLower(searchTerm)) {
-					termFound = true
-					matchedTerms = append(matchedTerms, searchTerm)
-					break
-				}
+			var matched []int
+			for _, word := range words {
+				matched = append(matched, findMatchedIndices(result.Recipe.Title(), word)...)
 			}
-			if !termFound {
-				allTermsMatched = false
-				break
-			}
-		}
-
-		if allTermsMatched {
-			// Use the first matched term for highlighting
-			matchedIndexes := findMatchedIndices(strings.Join(fieldArray, " "), matchedTerms[0])
-			ranks = append(ranks, list.Rank{
-				Index:          i,
-				MatchedIndexes: matchedIndexes,
-			})
+			ranks = append(ranks, list.Rank{Index: index, MatchedIndexes: matched})
 		}
+		snippets.set(found)
+		return ranks
 	}
-
-	return ranks
 }
 
-// filterByJSONField filters recipes by a specific field in the JSON filter data
-func filterByJSONField(searchTerm string, targets []string, fieldName common.FilterField) []list.Rank {
-	var ranks []list.Rank
-
-	for i, target := range targets {
-		var filterData map[string]any
-
-		err := json.Unmarshal([]byte(target), &filterData)
-		if err != nil {
-			slog.Error("Failed to unmarshal filter data", "error", err)
-			continue
-		}
-
-		fieldValue, exists := filterData[string(fieldName)]
-		if !exists {
-			slog.Error("Failed to get field value", "field name", fieldName, "error", err)
-			continue
+// highlightWords collects the words of the query's text terms that may
+// match a recipe's name, skipping negated terms
+func highlightWords(query utils.Query) []string {
+	var words []string
+	switch q := query.(type) {
+	case utils.QueryText:
+		if q.Field == "" || q.Field == "name" {
+			words = strings.FieldsFunc(q.Text, func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			})
 		}
-
-		fieldValueString, ok := fieldValue.(string)
-		if !ok {
-			slog.Error("Failed to convert field value to string", "field value", fieldValue, "error", err)
-			continue
+	case utils.QueryAnd:
+		for _, term := range q {
+			words = append(words, highlightWords(term)...)
 		}
-
-		matchedIndexes := findMatchedIndices(fieldValueString, searchTerm)
-		if len(matchedIndexes) > 0 {
-			if fieldName != common.TitleField {
-				matchedIndexes = []int{}
-			}
-			ranks = append(ranks, list.Rank{Index: i, MatchedIndexes: matchedIndexes})
+	case utils.QueryOr:
+		for _, term := range q {
+			words = append(words, highlightWords(term)...)
 		}
 	}
+	return words
+}
 
-	return ranks
+// filterValueID reads the recipe ID back from a RecipeRaw filter value
+func filterValueID(target string) (uint, bool) {
+	idText, _, _ := strings.Cut(target, "\t")
+	id, err := strconv.ParseUint(idText, 10, 32)
+	return uint(id), err == nil
 }
 
-// findMatchedIndices finds the indices of matched characters in the target string
+// findMatchedIndices finds the rune indices of every occurrence of search in
+// target, case-insensitively
 func findMatchedIndices(target string, search string) []int {
 	var indices []int
-	targetLower := strings.ToLower(target)
-	searchLower := strings.ToLower(search)
+	targetLower := []rune(strings.ToLower(target))
+	searchLower := []rune(strings.ToLower(search))
+	if len(searchLower) == 0 {
+		return nil
+	}
 
-	start := 0
-	for {
-		index := strings.Index(targetLower[start:], searchLower)
-		if index == -1 {
-			break
+	for start := 0; start+len(searchLower) <= len(targetLower); {
+		if string(targetLower[start:start+len(searchLower)]) != string(searchLower) {
+			start++
+			continue
 		}
-		actualIndex := start + index
-		for j := 0; j < len(searchLower); j++ {
-			indices = append(indices, actualIndex+j)
+		for j := range searchLower {
+			indices = append(indices, start+j)
 		}
-		start = actualIndex + len(searchLower)
+		start += len(searchLower)
 	}
 
 	return indices
@@ -28,6 +28,8 @@ type ListModel struct {
 	height     int
 	keyMap     config.ListKeyMap
 	theme      *themes.Theme
+	snippets   *snippetCache
+	sort       utils.RecipeSort
 }
 
 func NewListModel(cookbook *db.CookBook, theme *themes.Theme) (*ListModel, error) {
@@ -37,21 +39,22 @@ func NewListModel(cookbook *db.CookBook, theme *themes.Theme) (*ListModel, error
 		return nil, err
 	}
 
-	var items []list.Item
-	for _, recipe := range recipes {
-		items = append(items, recipe)
-	}
-
 	cfg := config.GetGlobalConfig()
 	if cfg == nil {
 		return nil, fmt.Errorf("global config not set")
 	}
 
 	listConfig := cfg.List
+	sortBy, err := utils.ParseRecipeSort(listConfig.Sort)
+	if err != nil {
+		slog.Warn("Invalid list sort in config, sorting by name", "error", err)
+		sortBy = utils.SortByName
+	}
+	items := recipeItems(recipes, sortBy)
 	keymaps := cfg.Keymap.ToKeyMap().GetListKeyMap()
 
-	d := list.NewDefaultDelegate()
-	d.Styles = theme.DelegateStyles
+	snippets := &snippetCache{}
+	d := newSearchDelegate(theme.DelegateStyles, snippets)
 	windowWidth, windowHeight, err := term.GetSize(os.Stdout.Fd())
 	if err != nil {
 		slog.Error("Failed to get terminal size", "error", err)
@@ -64,7 +67,7 @@ func NewListModel(cookbook *db.CookBook, theme *themes.Theme) (*ListModel, error
 	l.KeyMap = keymaps.ListKeyMap
 	l.SetStatusBarItemName(listConfig.ItemNameSingular, listConfig.ItemNamePlural)
 	l.StatusMessageLifetime = time.Duration(listConfig.ViewStatusMessageTTL) * time.Millisecond
-	l.Filter = CustomFilter
+	l.Filter = newSearchFilter(cookbook, snippets)
 	l.AdditionalShortHelpKeys = keymaps.AdditionalShortHelpKeys
 	l.AdditionalFullHelpKeys = keymaps.AdditionalFullHelpKeys
 
@@ -80,6 +83,8 @@ func NewListModel(cookbook *db.CookBook, theme *themes.Theme) (*ListModel, error
 		modelState: common.ModelStateLoaded,
 		RecipeList: l,
 		theme:      theme,
+		snippets:   snippets,
+		sort:       sortBy,
 	}, nil
 }
 
@@ -101,6 +106,13 @@ func (m *ListModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 		cmds = append(cmds, m.RefreshRecipeList())
 		cmds = append(cmds, m.RecipeList.NewStatusMessage(msg.StatusMessage))
 
+	case messages.ApplyFilterMsg:
+		m.RecipeList.SetFilterText(msg.Query)
+		cmds = append(cmds, m.RecipeList.NewStatusMessage(" 🔖 "+msg.Name))
+
+	case messages.SearchSavedMsg:
+		cmds = append(cmds, m.RecipeList.NewStatusMessage(m.config.ViewStatusMessageSearchSaved))
+
 	case messages.SetFavouriteMsg:
 		newFavourite, err := m.cookbook.SetFavourite(msg.RecipeID)
 		if err != nil {
@@ -140,6 +152,21 @@ func (m *ListModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
 				if i, ok := m.SelectedItemToRecipeWithDescription(); ok {
 					cmds = append(cmds, messages.SendSetFavouriteMsg(i.RecipeID))
 				}
+
+			case key.Matches(msg, m.keyMap.Sort):
+				m.sort = m.sort.Next()
+				cmds = append(cmds, m.RefreshRecipeList())
+				cmds = append(cmds, m.RecipeList.NewStatusMessage(" ↕️ Sorted by "+m.sort.Label()))
+
+			case key.Matches(msg, m.keyMap.SaveSearch):
+				if m.RecipeList.FilterState() == list.FilterApplied {
+					saveSearchDialog, err := dialog.NewSaveSearchDialog(m.cookbook, m.RecipeList.FilterValue(), m.theme)
+					if err != nil {
+						slog.Error("Failed to create save search dialog", "error", err)
+						return m, nil
+					}
+					cmds = append(cmds, messages.SendOpenModalViewMsg(saveSearchDialog, common.ModalTypeSaveSearch))
+				}
 			}
 		}
 	}
@@ -185,13 +212,18 @@ func (m *ListModel) RefreshRecipeList() tea.Cmd {
 		return nil
 	}
 
-	var items []list.Item
+	cmd := m.RecipeList.SetItems(recipeItems(recipes, m.sort))
+	return cmd
+}
+
+// recipeItems sorts recipes into the list's items
+func recipeItems(recipes []utils.RecipeRaw, sortBy utils.RecipeSort) []list.Item {
+	utils.SortRecipes(recipes, sortBy)
+	items := make([]list.Item, 0, len(recipes))
 	for _, recipe := range recipes {
 		items = append(items, recipe)
 	}
-
-	cmd := m.RecipeList.SetItems(items)
-	return cmd
+	return items
 }
 
 // SetSize sets the width and height of the model
@@ -224,7 +256,5 @@ func (m *ListModel) GetCurrentTheme() *themes.Theme {
 func (m *ListModel) SetTheme(theme *themes.Theme) {
 	m.theme = theme
 	m.RecipeList.Styles = theme.ListStyles
-	d := list.NewDefaultDelegate()
-	d.Styles = theme.DelegateStyles
-	m.RecipeList.SetDelegate(d)
+	m.RecipeList.SetDelegate(newSearchDelegate(theme.DelegateStyles, m.snippets))
 }
@@ -62,6 +62,11 @@ func NewMainMenuModel(cookbook *db.CookBook, theme *themes.Theme) (*MainMenuMode
 			description: "Search and jump to a specific recipe by name",
 			action:      dialog.ActionRecipeSelector,
 		},
+		{
+			title:       "Collections",
+			description: "Jump to a collection or the recipes of a saved filter",
+			action:      dialog.ActionSavedSearches,
+		},
 		{
 			title:       "Add Recipe",
 			description: "Import a new recipe from any URL",
@@ -73,6 +78,21 @@ func NewMainMenuModel(cookbook *db.CookBook, theme *themes.Theme) (*MainMenuMode
 			state:       common.SessionStateDetail,
 			handler:     func() tea.Cmd { return RandomRecipeCmd(cookbook) },
 		},
+		{
+			title:       "Meal Plan",
+			description: "Plan your week's breakfasts, lunches and dinners",
+			state:       common.SessionStateMealPlan,
+		},
+		{
+			title:       "Pantry",
+			description: "Track what you have on hand and see what you can cook",
+			state:       common.SessionStatePantry,
+		},
+		{
+			title:       "Shopping List",
+			description: "Tick off the groceries for your planned recipes",
+			state:       common.SessionStateShoppingList,
+		},
 		{
 			title:       "AI Assistant",
 			description: "Chat with AI for cooking tips and recipe ideas",
@@ -0,0 +1,327 @@
+package mealplan
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	common "github.com/GarroshIcecream/yummy/internal/models/common"
+	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
+	themes "github.com/GarroshIcecream/yummy/internal/themes"
+	dialog "github.com/GarroshIcecream/yummy/internal/tui/dialog"
+	utils "github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// slotLabelWidth is the width of the column holding the meal slot names
+const slotLabelWidth = 11
+
+// recipePickedMsg carries the recipe chosen for a slot in the recipe selector
+type recipePickedMsg struct {
+	day      time.Time
+	slot     utils.MealSlot
+	recipeID uint
+}
+
+type MealPlanModel struct {
+	// Configuration
+	cookbook   *db.CookBook
+	theme      *themes.Theme
+	keyMap     config.MealPlanKeyMap
+	modelState common.ModelState
+
+	// Plan
+	WeekStart time.Time
+	Entries   []utils.MealPlanEntry
+
+	// UI
+	dayIdx  int // 0 is Monday
+	slotIdx int
+	status  string
+	width   int
+	height  int
+}
+
+func NewMealPlanModel(cookbook *db.CookBook, theme *themes.Theme) (*MealPlanModel, error) {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		return nil, fmt.Errorf("global config not set")
+	}
+
+	now := time.Now()
+	return &MealPlanModel{
+		cookbook:   cookbook,
+		theme:      theme,
+		keyMap:     cfg.Keymap.ToKeyMap().GetMealPlanKeyMap(),
+		modelState: common.ModelStateLoaded,
+		WeekStart:  utils.WeekStart(now),
+		dayIdx:     int(utils.StartOfDay(now).Sub(utils.WeekStart(now)).Hours() / 24),
+	}, nil
+}
+
+func (m *MealPlanModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *MealPlanModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case messages.SessionStateMsg:
+		if msg.SessionState == common.SessionStateMealPlan {
+			m.reload()
+		}
+
+	case recipePickedMsg:
+		if _, err := m.cookbook.PlanMeal(msg.day, msg.slot, msg.recipeID); err != nil {
+			slog.Error("Failed to plan meal", "error", err)
+			m.status = "Could not plan the meal: " + err.Error()
+		}
+		m.reload()
+
+	case tea.KeyMsg:
+		m.status = ""
+		switch {
+		case key.Matches(msg, m.keyMap.CursorLeft):
+			m.moveDay(-1)
+		case key.Matches(msg, m.keyMap.CursorRight):
+			m.moveDay(1)
+		case key.Matches(msg, m.keyMap.CursorUp):
+			m.slotIdx = (m.slotIdx + len(utils.MealSlots) - 1) % len(utils.MealSlots)
+		case key.Matches(msg, m.keyMap.CursorDown):
+			m.slotIdx = (m.slotIdx + 1) % len(utils.MealSlots)
+		case key.Matches(msg, m.keyMap.PrevWeek):
+			m.WeekStart = m.WeekStart.AddDate(0, 0, -7)
+			m.reload()
+		case key.Matches(msg, m.keyMap.NextWeek):
+			m.WeekStart = m.WeekStart.AddDate(0, 0, 7)
+			m.reload()
+		case key.Matches(msg, m.keyMap.Add):
+			cmds = append(cmds, m.pickRecipe())
+		case key.Matches(msg, m.keyMap.Enter):
+			if entry, ok := m.selectedEntry(); ok {
+				cmds = append(cmds,
+					messages.SendSessionStateMsg(common.SessionStateDetail),
+					messages.SendRecipeSelectedMsg(entry.RecipeID),
+				)
+			} else {
+				cmds = append(cmds, m.pickRecipe())
+			}
+		case key.Matches(msg, m.keyMap.Delete):
+			if entry, ok := m.selectedEntry(); ok {
+				if err := m.cookbook.DeleteMealPlanEntry(entry.ID); err != nil {
+					slog.Error("Failed to remove planned meal", "error", err)
+				}
+				m.reload()
+			}
+		case key.Matches(msg, m.keyMap.AddToList):
+			cmds = append(cmds, m.shopForWeek())
+		}
+	}
+
+	return m, tea.Sequence(cmds...)
+}
+
+func (m *MealPlanModel) reload() {
+	entries, err := m.cookbook.MealPlan(m.WeekStart, m.WeekStart.AddDate(0, 0, 7))
+	if err != nil {
+		slog.Error("Failed to load meal plan", "error", err)
+		return
+	}
+	m.Entries = entries
+}
+
+// moveDay moves the cursor by delta days, turning to the next or previous
+// week past either end.
+func (m *MealPlanModel) moveDay(delta int) {
+	m.dayIdx += delta
+	switch {
+	case m.dayIdx < 0:
+		m.dayIdx = 6
+		m.WeekStart = m.WeekStart.AddDate(0, 0, -7)
+		m.reload()
+	case m.dayIdx > 6:
+		m.dayIdx = 0
+		m.WeekStart = m.WeekStart.AddDate(0, 0, 7)
+		m.reload()
+	}
+}
+
+func (m *MealPlanModel) selectedDay() time.Time {
+	return m.WeekStart.AddDate(0, 0, m.dayIdx)
+}
+
+func (m *MealPlanModel) entryAt(day time.Time, slot utils.MealSlot) (utils.MealPlanEntry, bool) {
+	for _, entry := range m.Entries {
+		if entry.Slot == slot && entry.Date.Equal(day) {
+			return entry, true
+		}
+	}
+	return utils.MealPlanEntry{}, false
+}
+
+func (m *MealPlanModel) selectedEntry() (utils.MealPlanEntry, bool) {
+	return m.entryAt(m.selectedDay(), utils.MealSlots[m.slotIdx])
+}
+
+// pickRecipe opens the recipe selector for the selected slot
+func (m *MealPlanModel) pickRecipe() tea.Cmd {
+	d, err := dialog.NewRecipeSelectorDialog(m.cookbook, m.theme)
+	if err != nil {
+		slog.Error("Failed to create recipe selector dialog", "error", err)
+		return nil
+	}
+
+	day, slot := m.selectedDay(), utils.MealSlots[m.slotIdx]
+	d.OnSelect(func(recipeID uint) tea.Cmd {
+		return func() tea.Msg {
+			return recipePickedMsg{day: day, slot: slot, recipeID: recipeID}
+		}
+	})
+	return messages.SendOpenModalViewMsg(d, common.ModalTypeRecipeSelector)
+}
+
+// shopForWeek creates a shopping list for the week's meals, a recipe planned
+// several times counting that many times over.
+func (m *MealPlanModel) shopForWeek() tea.Cmd {
+	if len(m.Entries) == 0 {
+		m.status = "Nothing planned this week"
+		return nil
+	}
+
+	var recipes []utils.ShoppingListRecipe
+	index := make(map[uint]int)
+	for _, entry := range m.Entries {
+		if i, ok := index[entry.RecipeID]; ok {
+			recipes[i].Scale++
+			continue
+		}
+		index[entry.RecipeID] = len(recipes)
+		recipes = append(recipes, utils.ShoppingListRecipe{RecipeID: entry.RecipeID, Scale: 1})
+	}
+
+	name := "Week of " + m.WeekStart.Format("Jan 2")
+	if _, err := m.cookbook.CreateShoppingList(name, recipes); err != nil {
+		slog.Error("Failed to create shopping list for the week", "error", err)
+		m.status = "Could not create the shopping list: " + err.Error()
+		return nil
+	}
+	return messages.SendSessionStateMsg(common.SessionStateShoppingList)
+}
+
+func (m *MealPlanModel) View() string {
+	var content strings.Builder
+
+	weekEnd := m.WeekStart.AddDate(0, 0, 6)
+	content.WriteString(m.theme.PlanTitle.Render(fmt.Sprintf("📅 Week of %s – %s",
+		m.WeekStart.Format("Mon Jan 2"), weekEnd.Format("Mon Jan 2 2006"))))
+	content.WriteString("\n\n")
+	content.WriteString(m.renderGrid())
+	content.WriteString("\n\n")
+
+	day, slot := m.selectedDay(), utils.MealSlots[m.slotIdx]
+	selected := day.Format("Monday Jan 2") + " · " + slot.Label() + ": "
+	if entry, ok := m.selectedEntry(); ok {
+		selected += entry.RecipeName
+	} else {
+		selected += "nothing planned"
+	}
+	content.WriteString(m.theme.PlanCell.Render(selected) + "\n")
+	if m.status != "" {
+		content.WriteString(m.theme.PlanToday.Render(m.status) + "\n")
+	}
+	content.WriteString("\n" + m.renderHelp())
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
+}
+
+func (m *MealPlanModel) renderGrid() string {
+	colWidth := max((m.width-4-slotLabelWidth)/7, 8)
+	today := utils.StartOfDay(time.Now())
+
+	var rows []string
+	header := []string{lipgloss.NewStyle().Width(slotLabelWidth).Render("")}
+	for i := range 7 {
+		day := m.WeekStart.AddDate(0, 0, i)
+		style := m.theme.PlanDayHeader
+		if day.Equal(today) {
+			style = m.theme.PlanToday
+		}
+		header = append(header, style.Width(colWidth).Render(day.Format("Mon 2")))
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, header...))
+
+	for s, slot := range utils.MealSlots {
+		row := []string{m.theme.PlanSlotLabel.Width(slotLabelWidth).Render(slot.Label())}
+		for i := range 7 {
+			text, style := "·", m.theme.PlanCellEmpty
+			if entry, ok := m.entryAt(m.WeekStart.AddDate(0, 0, i), slot); ok {
+				text, style = truncate(entry.RecipeName, colWidth-1), m.theme.PlanCell
+			}
+			if i == m.dayIdx && s == m.slotIdx {
+				style = m.theme.PlanCursor
+			}
+			// Render the text alone so the cursor underline stops at the name
+			row = append(row, lipgloss.NewStyle().Width(colWidth).Render(style.Render(text)))
+		}
+		rows = append(rows, "", lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+func (m *MealPlanModel) renderHelp() string {
+	parts := []string{
+		"←↑↓→ move",
+		m.keyMap.PrevWeek.Help().Key + "/" + m.keyMap.NextWeek.Help().Key + " week",
+		m.keyMap.Add.Help().Key + " plan meal",
+		m.keyMap.Delete.Help().Key + " remove",
+		m.keyMap.AddToList.Help().Key + " shop for week",
+		m.keyMap.Enter.Help().Key + " open recipe",
+	}
+	return m.theme.PlanHelp.Render(strings.Join(parts, "  "))
+}
+
+// truncate shortens s to at most width runes, ending it with "…" when cut
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// PlannedCount returns the number of meals planned in the shown week
+func (m *MealPlanModel) PlannedCount() int {
+	return len(m.Entries)
+}
+
+func (m *MealPlanModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *MealPlanModel) GetSize() (width, height int) {
+	return m.width, m.height
+}
+
+func (m *MealPlanModel) GetModelState() common.ModelState {
+	return m.modelState
+}
+
+func (m *MealPlanModel) GetSessionState() common.SessionState {
+	return common.SessionStateMealPlan
+}
+
+func (m *MealPlanModel) GetCurrentTheme() *themes.Theme {
+	return m.theme
+}
+
+func (m *MealPlanModel) SetTheme(theme *themes.Theme) {
+	m.theme = theme
+}
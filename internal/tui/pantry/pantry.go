@@ -0,0 +1,356 @@
+package pantry
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	common "github.com/GarroshIcecream/yummy/internal/models/common"
+	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
+	themes "github.com/GarroshIcecream/yummy/internal/themes"
+	utils "github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxMatches is how many recipes the "what can I cook" pane lists
+const maxMatches = 10
+
+// expiringDays is how close an expiry date has to be to be highlighted
+const expiringDays = 3
+
+type pane int
+
+const (
+	paneItems pane = iota
+	paneMatches
+)
+
+type PantryModel struct {
+	// Configuration
+	cookbook   *db.CookBook
+	theme      *themes.Theme
+	keyMap     config.PantryKeyMap
+	modelState common.ModelState
+
+	// Pantry
+	Items   []utils.PantryItem
+	Matches []utils.PantryMatch
+
+	// UI
+	focus     pane
+	cursor    int // index into Items
+	matchIdx  int // index into Matches
+	input     textinput.Model
+	editing   bool
+	editingID uint // pantry item being edited, 0 when adding
+	err       string
+	width     int
+	height    int
+}
+
+func NewPantryModel(cookbook *db.CookBook, theme *themes.Theme) (*PantryModel, error) {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		return nil, fmt.Errorf("global config not set")
+	}
+
+	ti := textinput.New()
+	ti.Placeholder = "2 kg potatoes, expires 2026-10-30"
+	ti.CharLimit = 120
+	ti.Width = 48
+
+	return &PantryModel{
+		cookbook:   cookbook,
+		theme:      theme,
+		keyMap:     cfg.Keymap.ToKeyMap().GetPantryKeyMap(),
+		modelState: common.ModelStateLoaded,
+		input:      ti,
+	}, nil
+}
+
+func (m *PantryModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *PantryModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case messages.SessionStateMsg:
+		if msg.SessionState == common.SessionStatePantry {
+			m.reload()
+		}
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m, m.updateInput(msg)
+		}
+
+		switch {
+		case key.Matches(msg, m.keyMap.SwitchPane):
+			if m.focus == paneItems && len(m.Matches) > 0 {
+				m.focus = paneMatches
+			} else {
+				m.focus = paneItems
+			}
+		case key.Matches(msg, m.keyMap.CursorUp):
+			m.moveCursor(-1)
+		case key.Matches(msg, m.keyMap.CursorDown):
+			m.moveCursor(1)
+		case key.Matches(msg, m.keyMap.Add):
+			m.startEditing(utils.PantryItem{})
+			cmds = append(cmds, textinput.Blink)
+		case key.Matches(msg, m.keyMap.Edit):
+			if item, ok := m.selectedItem(); ok {
+				m.startEditing(item)
+				cmds = append(cmds, textinput.Blink)
+			}
+		case key.Matches(msg, m.keyMap.Delete):
+			if item, ok := m.selectedItem(); ok {
+				if err := m.cookbook.DeletePantryItem(item.ID); err != nil {
+					slog.Error("Failed to delete pantry item", "error", err)
+				}
+				m.reload()
+			}
+		case key.Matches(msg, m.keyMap.Enter):
+			if m.focus == paneMatches && m.matchIdx < len(m.Matches) {
+				cmds = append(cmds,
+					messages.SendSessionStateMsg(common.SessionStateDetail),
+					messages.SendRecipeSelectedMsg(m.Matches[m.matchIdx].RecipeID),
+				)
+			}
+		}
+	}
+
+	return m, tea.Sequence(cmds...)
+}
+
+// updateInput handles keys while an item is being added or edited
+func (m *PantryModel) updateInput(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keyMap.Back):
+		m.stopEditing()
+		return nil
+	case key.Matches(msg, m.keyMap.Enter):
+		item, err := utils.ParsePantryEntry(m.input.Value())
+		if err != nil {
+			m.err = err.Error()
+			return nil
+		}
+		if m.editingID != 0 {
+			item.ID = m.editingID
+			err = m.cookbook.UpdatePantryItem(item)
+		} else {
+			_, err = m.cookbook.AddPantryItem(item)
+		}
+		if err != nil {
+			m.err = err.Error()
+			return nil
+		}
+		m.stopEditing()
+		m.reload()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return cmd
+}
+
+func (m *PantryModel) startEditing(item utils.PantryItem) {
+	m.editing = true
+	m.editingID = item.ID
+	m.err = ""
+	value := ""
+	if item.ID != 0 {
+		value = strings.TrimSpace(strings.Join([]string{item.Amount, item.Unit, item.Name}, " "))
+		if item.ExpiresAt != nil {
+			value += ", expires " + item.ExpiresAt.Format(utils.PantryDateLayout)
+		}
+	}
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.input.Focus()
+}
+
+func (m *PantryModel) stopEditing() {
+	m.editing = false
+	m.editingID = 0
+	m.err = ""
+	m.input.Blur()
+	m.input.Reset()
+}
+
+// IsEditing reports whether an item is being typed in, so esc cancels the
+// input instead of leaving the view.
+func (m *PantryModel) IsEditing() bool {
+	return m.editing
+}
+
+func (m *PantryModel) reload() {
+	items, err := m.cookbook.PantryItems()
+	if err != nil {
+		slog.Error("Failed to load pantry", "error", err)
+		return
+	}
+	matches, err := m.cookbook.RankRecipesByPantry()
+	if err != nil {
+		slog.Error("Failed to match recipes to pantry", "error", err)
+	}
+
+	m.Items = items
+	m.Matches = m.Matches[:0]
+	for _, match := range matches {
+		if match.Coverage == 0 || len(m.Matches) >= maxMatches {
+			break
+		}
+		m.Matches = append(m.Matches, match)
+	}
+	m.cursor = min(m.cursor, max(len(m.Items)-1, 0))
+	m.matchIdx = min(m.matchIdx, max(len(m.Matches)-1, 0))
+	if len(m.Matches) == 0 {
+		m.focus = paneItems
+	}
+}
+
+func (m *PantryModel) moveCursor(delta int) {
+	if m.focus == paneMatches {
+		if n := len(m.Matches); n > 0 {
+			m.matchIdx = (m.matchIdx + delta + n) % n
+		}
+		return
+	}
+	if n := len(m.Items); n > 0 {
+		m.cursor = (m.cursor + delta + n) % n
+	}
+}
+
+func (m *PantryModel) selectedItem() (utils.PantryItem, bool) {
+	if m.focus != paneItems || m.cursor >= len(m.Items) {
+		return utils.PantryItem{}, false
+	}
+	return m.Items[m.cursor], true
+}
+
+func (m *PantryModel) View() string {
+	paneWidth := max(m.width/2-2, 20)
+	left := lipgloss.NewStyle().Width(paneWidth).Render(m.renderItems())
+	right := lipgloss.NewStyle().Width(paneWidth).Render(m.renderMatches())
+
+	var content strings.Builder
+	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, "  ", right))
+	content.WriteString("\n\n")
+	if m.editing {
+		content.WriteString(m.theme.PantryTitle.Render("Item: ") + m.input.View() + "\n")
+		if m.err != "" {
+			content.WriteString(m.theme.PantryExpired.UnsetStrikethrough().Render(m.err) + "\n")
+		}
+	}
+	content.WriteString(m.renderHelp())
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(content.String())
+}
+
+func (m *PantryModel) renderItems() string {
+	var b strings.Builder
+	b.WriteString(m.theme.PantryTitle.Render("🥫 Pantry") + "\n\n")
+	if len(m.Items) == 0 {
+		add := m.keyMap.Add.Help().Key
+		b.WriteString(m.theme.PantryHelp.Render("Nothing here yet — press " + add + " to add an ingredient"))
+		return b.String()
+	}
+
+	now := time.Now()
+	for i, item := range m.Items {
+		cursor := "  "
+		if m.focus == paneItems && i == m.cursor {
+			cursor = m.theme.PantryCursor.Render("▸ ")
+		}
+
+		line := m.theme.PantryItem.Render(item.Name)
+		if amount := strings.TrimSpace(item.Amount + " " + item.Unit); amount != "" {
+			line = m.theme.PantryItemAmount.Render(amount) + " " + line
+		}
+		if item.ExpiresAt != nil {
+			date := item.ExpiresAt.Format(utils.PantryDateLayout)
+			switch {
+			case item.Expired(now):
+				line += " " + m.theme.PantryExpired.Render("expired "+date)
+			case item.ExpiresWithin(now, expiringDays):
+				line += " " + m.theme.PantryExpiring.Render("expires "+date)
+			default:
+				line += " " + m.theme.PantryHelp.Render("expires "+date)
+			}
+		}
+		b.WriteString(cursor + line + "\n")
+	}
+	return b.String()
+}
+
+func (m *PantryModel) renderMatches() string {
+	var b strings.Builder
+	b.WriteString(m.theme.PantryTitle.Render("🍽️ What can I cook") + "\n\n")
+	if len(m.Matches) == 0 {
+		b.WriteString(m.theme.PantryHelp.Render("No recipe uses what is in the pantry yet"))
+		return b.String()
+	}
+
+	for i, match := range m.Matches {
+		cursor := "  "
+		if m.focus == paneMatches && i == m.matchIdx {
+			cursor = m.theme.PantryCursor.Render("▸ ")
+		}
+		coverage := m.theme.PantryCoverage.Render(fmt.Sprintf("%3.0f%%", match.Coverage*100))
+		b.WriteString(cursor + coverage + " " + m.theme.PantryRecipe.Render(match.RecipeName) + "\n")
+		if len(match.Missing) > 0 {
+			b.WriteString("       " + m.theme.PantryHelp.Render("missing: "+strings.Join(match.Missing, ", ")) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (m *PantryModel) renderHelp() string {
+	help := m.theme.PantryHelp
+	if m.editing {
+		return help.Render(m.keyMap.Enter.Help().Key + " save  " + m.keyMap.Back.Help().Key + " cancel")
+	}
+
+	bindings := []key.Binding{m.keyMap.Add, m.keyMap.Edit, m.keyMap.Delete, m.keyMap.SwitchPane}
+	parts := []string{"↑↓ navigate"}
+	for _, binding := range bindings {
+		parts = append(parts, binding.Help().Key+" "+binding.Help().Desc)
+	}
+	parts = append(parts, m.keyMap.Enter.Help().Key+" open recipe")
+	return help.Render(strings.Join(parts, "  "))
+}
+
+func (m *PantryModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *PantryModel) GetSize() (width, height int) {
+	return m.width, m.height
+}
+
+func (m *PantryModel) GetModelState() common.ModelState {
+	return m.modelState
+}
+
+func (m *PantryModel) GetSessionState() common.SessionState {
+	return common.SessionStatePantry
+}
+
+func (m *PantryModel) GetCurrentTheme() *themes.Theme {
+	return m.theme
+}
+
+func (m *PantryModel) SetTheme(theme *themes.Theme) {
+	m.theme = theme
+}
@@ -0,0 +1,215 @@
+package shopping
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/config"
+	db "github.com/GarroshIcecream/yummy/internal/db"
+	common "github.com/GarroshIcecream/yummy/internal/models/common"
+	messages "github.com/GarroshIcecream/yummy/internal/models/msg"
+	themes "github.com/GarroshIcecream/yummy/internal/themes"
+	utils "github.com/GarroshIcecream/yummy/internal/utils"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ShoppingListModel struct {
+	// Configuration
+	cookbook   *db.CookBook
+	theme      *themes.Theme
+	keyMap     config.ShoppingListKeyMap
+	modelState common.ModelState
+
+	// Shopping list
+	List   *utils.ShoppingList
+	cursor int // index into List.Items
+
+	// UI
+	width  int
+	height int
+}
+
+func NewShoppingListModel(cookbook *db.CookBook, theme *themes.Theme) (*ShoppingListModel, error) {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		return nil, fmt.Errorf("global config not set")
+	}
+
+	return &ShoppingListModel{
+		cookbook:   cookbook,
+		theme:      theme,
+		keyMap:     cfg.Keymap.ToKeyMap().GetShoppingListKeyMap(),
+		modelState: common.ModelStateLoaded,
+	}, nil
+}
+
+func (m *ShoppingListModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ShoppingListModel) Update(msg tea.Msg) (common.TUIModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case messages.SessionStateMsg:
+		// Reload on entry so recipes added from the detail view show up
+		if msg.SessionState == common.SessionStateShoppingList {
+			m.loadLatest()
+		}
+
+	case tea.KeyMsg:
+		if m.List == nil || len(m.List.Items) == 0 {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keyMap.CursorUp):
+			if m.cursor > 0 {
+				m.cursor--
+			} else {
+				m.cursor = len(m.List.Items) - 1
+			}
+		case key.Matches(msg, m.keyMap.CursorDown):
+			if m.cursor < len(m.List.Items)-1 {
+				m.cursor++
+			} else {
+				m.cursor = 0
+			}
+		case key.Matches(msg, m.keyMap.ToggleItem):
+			item := &m.List.Items[m.cursor]
+			if err := m.cookbook.SetShoppingItemChecked(item.ID, !item.Checked); err != nil {
+				slog.Error("Failed to tick shopping list item", "error", err)
+				break
+			}
+			item.Checked = !item.Checked
+		}
+	}
+
+	return m, nil
+}
+
+func (m *ShoppingListModel) loadLatest() {
+	list, err := m.cookbook.LatestShoppingList()
+	if err != nil {
+		slog.Error("Failed to load shopping list", "error", err)
+		return
+	}
+	m.List = list
+	if m.List == nil || m.cursor >= len(m.List.Items) {
+		m.cursor = 0
+	}
+}
+
+// CheckedCount returns how many items are ticked off and the total
+func (m *ShoppingListModel) CheckedCount() (checked, total int) {
+	if m.List == nil {
+		return 0, 0
+	}
+	for _, item := range m.List.Items {
+		if item.Checked {
+			checked++
+		}
+	}
+	return checked, len(m.List.Items)
+}
+
+func (m *ShoppingListModel) View() string {
+	if m.List == nil || len(m.List.Items) == 0 {
+		empty := m.theme.ShoppingHelp.Render("No shopping list yet — add a recipe to one from its detail view")
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, empty)
+	}
+
+	var header strings.Builder
+	header.WriteString(m.theme.ShoppingTitle.Render("🛒 " + m.List.Name))
+	if summary := m.List.RecipeSummary(); summary != "" {
+		header.WriteString("\n" + m.theme.ShoppingHelp.Render("For: "+summary))
+	}
+
+	lines, cursorLine := m.renderItems()
+	help := m.renderHelp()
+
+	// Keep the cursor visible when the list is taller than the view
+	visible := m.height - lipgloss.Height(header.String()) - lipgloss.Height(help) - 2
+	if visible > 0 && len(lines) > visible {
+		start := cursorLine - visible/2
+		start = max(0, min(start, len(lines)-visible))
+		lines = lines[start : start+visible]
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header.String(),
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		help,
+	)
+}
+
+// renderItems renders the items grouped by aisle and returns the lines with
+// the index of the line holding the cursor.
+func (m *ShoppingListModel) renderItems() ([]string, int) {
+	var lines []string
+	cursorLine := 0
+	index := 0
+	for _, group := range utils.GroupShoppingItems(m.List.Items) {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, m.theme.ShoppingAisle.Render(group.Aisle))
+		for _, item := range group.Items {
+			cursor := "  "
+			if index == m.cursor {
+				cursor = m.theme.ShoppingCursor.Render("▸ ")
+				cursorLine = len(lines)
+			}
+			lines = append(lines, cursor+m.renderItem(item))
+			index++
+		}
+	}
+	return lines, cursorLine
+}
+
+func (m *ShoppingListModel) renderItem(item utils.ShoppingItem) string {
+	if item.Checked {
+		return m.theme.ShoppingItemChecked.Render("[x] " + utils.FormatShoppingItemLine(item))
+	}
+
+	amount := item.AmountText()
+	line := "[ ] "
+	if amount != "" {
+		line += m.theme.ShoppingItemAmount.Render(amount) + " "
+	}
+	return line + m.theme.ShoppingItem.Render(item.Name)
+}
+
+func (m *ShoppingListModel) renderHelp() string {
+	help := m.theme.ShoppingHelp
+	return help.Render("↑↓ navigate  ") +
+		help.Render(m.keyMap.ToggleItem.Help().Key+" "+m.keyMap.ToggleItem.Help().Desc+"  ") +
+		help.Render(m.keyMap.Back.Help().Key+" back")
+}
+
+func (m *ShoppingListModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *ShoppingListModel) GetSize() (width, height int) {
+	return m.width, m.height
+}
+
+func (m *ShoppingListModel) GetModelState() common.ModelState {
+	return m.modelState
+}
+
+func (m *ShoppingListModel) GetSessionState() common.SessionState {
+	return common.SessionStateShoppingList
+}
+
+func (m *ShoppingListModel) GetCurrentTheme() *themes.Theme {
+	return m.theme
+}
+
+func (m *ShoppingListModel) SetTheme(theme *themes.Theme) {
+	m.theme = theme
+}
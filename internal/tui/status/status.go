@@ -10,6 +10,9 @@ import (
 	"github.com/GarroshIcecream/yummy/internal/tui/chat"
 	"github.com/GarroshIcecream/yummy/internal/tui/detail"
 	yummy_list "github.com/GarroshIcecream/yummy/internal/tui/list"
+	"github.com/GarroshIcecream/yummy/internal/tui/mealplan"
+	"github.com/GarroshIcecream/yummy/internal/tui/pantry"
+	"github.com/GarroshIcecream/yummy/internal/tui/shopping"
 	"github.com/GarroshIcecream/yummy/internal/utils"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -19,6 +22,7 @@ type StatusLine struct {
 	height      int
 	linePadding int
 	theme       themes.Theme
+	llmStatus   string // shown in every view, e.g. "🤖 gemma3:4b" or "🤖 offline"
 }
 
 // this sucks as we need to think of some other fields that are applicable to us
@@ -52,6 +56,11 @@ func (s *StatusLine) SetTheme(theme *themes.Theme) {
 	s.theme = *theme
 }
 
+// SetLLMStatus sets the LLM indicator at the right of the status line
+func (s *StatusLine) SetLLMStatus(llmStatus string) {
+	s.llmStatus = llmStatus
+}
+
 func (s *StatusLine) Render(info StatusInfo) string {
 	if s.width <= 0 {
 		return ""
@@ -113,6 +122,10 @@ func (s *StatusLine) renderRightSide(info StatusInfo) string {
 		parts = append(parts, ModeInfoText)
 	}
 
+	if s.llmStatus != "" {
+		parts = append(parts, s.theme.StatusLineInfo.Render(s.llmStatus))
+	}
+
 	separator := s.theme.StatusLineSeparator.Render(" | ")
 	return strings.Join(parts, separator)
 }
@@ -176,8 +189,7 @@ func CreateStatusInfo(currentModel common.TUIModel) StatusInfo {
 	case common.SessionStateChat:
 		info.Mode = common.StatusModeChat
 		if chatModel, ok := currentModel.(*chat.ChatModel); ok {
-			modelName := chatModel.ExecutorService.GetCurrentModelName()
-			info.ModeInfo = "Chat Mode | " + modelName
+			info.ModeInfo = "Chat Mode"
 
 			// Get session summary if available
 			summary, err := chatModel.ExecutorService.GetSessionSummary()
@@ -200,6 +212,30 @@ func CreateStatusInfo(currentModel common.TUIModel) StatusInfo {
 				info.ModeInfo = "Cooking Mode"
 			}
 		}
+
+	case common.SessionStateShoppingList:
+		info.Mode = common.StatusModeShopping
+		info.Description = common.SessionStateShoppingList.GetStateName()
+		if shoppingModel, ok := currentModel.(*shopping.ShoppingListModel); ok && shoppingModel.List != nil {
+			checked, total := shoppingModel.CheckedCount()
+			info.Description = shoppingModel.List.Name
+			info.ModeInfo = fmt.Sprintf("%d/%d items", checked, total)
+		}
+
+	case common.SessionStatePantry:
+		info.Mode = common.StatusModePantry
+		info.Description = common.SessionStatePantry.GetStateName()
+		if pantryModel, ok := currentModel.(*pantry.PantryModel); ok {
+			info.ModeInfo = fmt.Sprintf("%d items | %d recipes", len(pantryModel.Items), len(pantryModel.Matches))
+		}
+
+	case common.SessionStateMealPlan:
+		info.Mode = common.StatusModeMealPlan
+		info.Description = common.SessionStateMealPlan.GetStateName()
+		if planModel, ok := currentModel.(*mealplan.MealPlanModel); ok {
+			info.Description = "Week of " + planModel.WeekStart.Format("Jan 2")
+			info.ModeInfo = fmt.Sprintf("%d meals planned", planModel.PlannedCount())
+		}
 	}
 
 	return info
@@ -16,6 +16,9 @@ import (
 	edit "github.com/GarroshIcecream/yummy/internal/tui/edit"
 	yummy_list "github.com/GarroshIcecream/yummy/internal/tui/list"
 	main_menu "github.com/GarroshIcecream/yummy/internal/tui/main_menu"
+	mealplan "github.com/GarroshIcecream/yummy/internal/tui/mealplan"
+	pantry "github.com/GarroshIcecream/yummy/internal/tui/pantry"
+	shopping "github.com/GarroshIcecream/yummy/internal/tui/shopping"
 	status "github.com/GarroshIcecream/yummy/internal/tui/status"
 	"github.com/charmbracelet/bubbles/key"
 	list "github.com/charmbracelet/bubbles/list"
@@ -90,20 +93,41 @@ func New(cookbook *db.CookBook, sessionLog *db.SessionLog, themeManager *themes.
 		return nil, err
 	}
 
-	cookingModel, err := detail.NewCookingModel(currentTheme)
+	cookingModel, err := detail.NewCookingModel(cookbook, currentTheme)
 	if err != nil {
 		slog.Error("Failed to create cooking mode", "error", err)
 		return nil, err
 	}
 
+	shoppingModel, err := shopping.NewShoppingListModel(cookbook, currentTheme)
+	if err != nil {
+		slog.Error("Failed to create shopping list", "error", err)
+		return nil, err
+	}
+
+	pantryModel, err := pantry.NewPantryModel(cookbook, currentTheme)
+	if err != nil {
+		slog.Error("Failed to create pantry", "error", err)
+		return nil, err
+	}
+
+	mealPlanModel, err := mealplan.NewMealPlanModel(cookbook, currentTheme)
+	if err != nil {
+		slog.Error("Failed to create meal plan", "error", err)
+		return nil, err
+	}
+
 	// Create models
 	models := map[common.SessionState]common.TUIModel{
-		common.SessionStateMainMenu: mainMenu,
-		common.SessionStateList:     list,
-		common.SessionStateDetail:   detailModel,
-		common.SessionStateEdit:     editModel,
-		common.SessionStateChat:     chatModel,
-		common.SessionStateCooking:  cookingModel,
+		common.SessionStateMainMenu:     mainMenu,
+		common.SessionStateList:         list,
+		common.SessionStateDetail:       detailModel,
+		common.SessionStateEdit:         editModel,
+		common.SessionStateChat:         chatModel,
+		common.SessionStateCooking:      cookingModel,
+		common.SessionStateShoppingList: shoppingModel,
+		common.SessionStatePantry:       pantryModel,
+		common.SessionStateMealPlan:     mealPlanModel,
 	}
 
 	// Create status line
@@ -134,6 +158,11 @@ func (m *Manager) Init() tea.Cmd {
 		cmds = append(cmds, currentModel.Init())
 	}
 
+	// Look for Ollama in the background so the cookbook opens straight away
+	if chatModel, ok := m.models[common.SessionStateChat].(*chat.ChatModel); ok {
+		cmds = append(cmds, chat.CheckOllamaStatus(chatModel.ExecutorService.GetCurrentModelName(), true))
+	}
+
 	return tea.Batch(cmds...)
 }
 
@@ -163,6 +192,32 @@ func (m *Manager) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case messages.CookLoggedMsg:
+		// The cook history is shown in the detail view, whichever view logged the cook
+		if detailModel, exists := m.models[common.SessionStateDetail]; exists {
+			model, cmd := detailModel.Update(msg)
+			m.models[common.SessionStateDetail] = model
+			return m, cmd
+		}
+
+	case chat.OllamaStatusMsg:
+		// Chat and cooking help follow Ollama coming and going; the rest of
+		// the app works without it
+		modelName := msg.Model
+		if chatModel, ok := m.models[common.SessionStateChat].(*chat.ChatModel); ok {
+			chatModel.SetOllamaStatus(msg)
+			modelName = chatModel.ExecutorService.GetCurrentModelName()
+			if cookingModel, ok := m.models[common.SessionStateCooking].(*detail.CookingModel); ok {
+				cookingModel.SetLLMStatus(chatModel.ExecutorService.UnavailableReason())
+			}
+		}
+		return m, chat.ScheduleOllamaCheck(modelName)
+
+	case messages.ModelPulledMsg:
+		if chatModel, ok := m.models[common.SessionStateChat].(*chat.ChatModel); ok {
+			chatModel.ExecutorService.AddInstalledModel(msg.ModelName)
+		}
+
 	case messages.CloseModalViewMsg:
 		m.ModalView = false
 		m.overlayModel = nil
@@ -212,6 +267,14 @@ func (m *Manager) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			cmds = append(cmds, messages.SendOpenModalViewMsg(d, common.ModalTypeRecipeSelector))
 
+		case dialog.ActionSavedSearches:
+			d, err := dialog.NewSavedSearchesDialog(m.Cookbook, theme)
+			if err != nil {
+				slog.Error("Failed to create collections dialog", "error", err)
+				return m, nil
+			}
+			cmds = append(cmds, messages.SendOpenModalViewMsg(d, common.ModalTypeSavedSearches))
+
 		case dialog.ActionAddRecipe:
 			d, err := dialog.NewAddRecipeFromURLDialog(m.Cookbook, theme)
 			if err != nil {
@@ -282,6 +345,15 @@ func (m *Manager) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+			// Let the pantry model handle esc while an item is being typed in
+			if m.CurrentSessionState == common.SessionStatePantry {
+				if pantryModel, ok := m.models[common.SessionStatePantry].(*pantry.PantryModel); ok {
+					if pantryModel.IsEditing() {
+						break
+					}
+				}
+			}
+
 			if m.CurrentSessionState == common.SessionStateList {
 				if listModel, ok := m.models[common.SessionStateList].(*yummy_list.ListModel); ok {
 					if listModel.RecipeList.FilterState() != list.Filtering {
@@ -386,6 +458,9 @@ func (m Manager) View() string {
 	if m.GetCurrentModel().GetModelState() == common.ModelStateLoaded {
 		currentModel := m.GetCurrentModel()
 		statusInfo := status.CreateStatusInfo(currentModel)
+		if chatModel, ok := m.models[common.SessionStateChat].(*chat.ChatModel); ok {
+			m.statusLine.SetLLMStatus(chatModel.ExecutorService.StatusIndicator())
+		}
 		statusLine := m.statusLine.Render(statusInfo)
 		content = lipgloss.JoinVertical(lipgloss.Left, content, statusLine)
 	}
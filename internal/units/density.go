@@ -0,0 +1,97 @@
+package units
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Density is how much a millilitre of an ingredient weighs. Dry ingredients
+// are weighed rather than measured by volume in metric and UK kitchens.
+type Density struct {
+	Density float64 // grams per millilitre
+	Dry     bool
+}
+
+// densities holds typical densities for common ingredients, derived from
+// standard cup weights (e.g. 125 g per cup of flour).
+var densities = map[string]Density{
+	"flour":             {0.53, true},
+	"bread flour":       {0.55, true},
+	"whole wheat flour": {0.51, true},
+	"almond flour":      {0.41, true},
+	"cornstarch":        {0.54, true},
+	"sugar":             {0.85, true},
+	"brown sugar":       {0.93, true},
+	"powdered sugar":    {0.51, true},
+	"icing sugar":       {0.51, true},
+	"caster sugar":      {0.83, true},
+	"cocoa":             {0.36, true},
+	"cocoa powder":      {0.36, true},
+	"butter":            {0.96, true},
+	"peanut butter":     {1.08, true},
+	"oats":              {0.38, true},
+	"rice":              {0.78, true},
+	"salt":              {1.22, true},
+	"baking soda":       {0.93, true},
+	"baking powder":     {0.81, true},
+	"chocolate chips":   {0.72, true},
+	"parmesan":          {0.42, true},
+	"cheese":            {0.47, true},
+	"breadcrumbs":       {0.45, true},
+	"nuts":              {0.6, true},
+	"walnuts":           {0.5, true},
+	"almonds":           {0.6, true},
+	"raisins":           {0.63, true},
+	"water":             {1.0, false},
+	"milk":              {1.03, false},
+	"buttermilk":        {1.03, false},
+	"cream":             {1.0, false},
+	"yogurt":            {1.03, false},
+	"oil":               {0.92, false},
+	"olive oil":         {0.92, false},
+	"honey":             {1.42, false},
+	"maple syrup":       {1.32, false},
+	"stock":             {1.0, false},
+	"broth":             {1.0, false},
+	"wine":              {0.99, false},
+	"vinegar":           {1.01, false},
+}
+
+// densityPatterns matches ingredient names against the density table,
+// longest names first so "brown sugar" wins over "sugar".
+var densityPatterns []struct {
+	name string
+	re   *regexp.Regexp
+}
+
+func init() {
+	names := make([]string, 0, len(densities))
+	for name := range densities {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		densityPatterns = append(densityPatterns, struct {
+			name string
+			re   *regexp.Regexp
+		}{name, regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)})
+	}
+}
+
+// LookupDensity finds the density of an ingredient by name, matching the
+// most specific known ingredient it mentions ("sifted all-purpose flour" → flour).
+func LookupDensity(ingredient string) (Density, bool) {
+	ingredient = strings.ToLower(ingredient)
+	for _, p := range densityPatterns {
+		if p.re.MatchString(ingredient) {
+			return densities[p.name], true
+		}
+	}
+	return Density{}, false
+}
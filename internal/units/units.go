@@ -0,0 +1,206 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Dimension is the physical quantity a unit measures.
+type Dimension int
+
+const (
+	Volume Dimension = iota + 1
+	Mass
+)
+
+// System is a measurement system recipes can be displayed in.
+type System string
+
+const (
+	Original System = "original" // units as written in the recipe
+	Metric   System = "metric"
+	US       System = "us"
+	UK       System = "uk"
+)
+
+// Systems lists every supported system, in the order the UI cycles through them.
+var Systems = []System{Original, Metric, US, UK}
+
+// ParseSystem parses a system name (case-insensitive). An empty name is Original.
+func ParseSystem(name string) (System, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return Original, nil
+	}
+	for _, s := range Systems {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown unit system: %s. Supported systems: original, metric, us, uk", name)
+}
+
+// Next returns the system after s in Systems, wrapping around.
+func (s System) Next() System {
+	for i, system := range Systems {
+		if system == s {
+			return Systems[(i+1)%len(Systems)]
+		}
+	}
+	return Original
+}
+
+// Label returns a display name for the system.
+func (s System) Label() string {
+	switch s {
+	case Metric:
+		return "Metric"
+	case US:
+		return "US"
+	case UK:
+		return "UK"
+	default:
+		return "Original"
+	}
+}
+
+// Unit is a convertible unit. Names are the normalised spellings produced by
+// utils.CorpusMeasuresMap ("cup", "tbl", "gram").
+type Unit struct {
+	Name      string
+	Dimension Dimension
+	Size      float64 // millilitres for volume units, grams for mass units
+}
+
+// Metric reports whether the unit belongs to the metric system.
+func (u Unit) Metric() bool {
+	switch u.Name {
+	case "milliliter", "liter", "gram", "kilogram":
+		return true
+	}
+	return false
+}
+
+var (
+	milliliter = Unit{"milliliter", Volume, 1}
+	liter      = Unit{"liter", Volume, 1000}
+	tsp        = Unit{"tsp", Volume, 4.92892}
+	tbl        = Unit{"tbl", Volume, 14.7868}
+	cup        = Unit{"cup", Volume, 236.588}
+	flOz       = Unit{"fl oz", Volume, 29.5735}
+	pint       = Unit{"pint", Volume, 473.176}
+	quart      = Unit{"quart", Volume, 946.353}
+	ukFlOz     = Unit{"fl oz", Volume, 28.4131}
+	ukPint     = Unit{"pint", Volume, 568.261}
+	gram       = Unit{"gram", Mass, 1}
+	kilogram   = Unit{"kilogram", Mass, 1000}
+	ounce      = Unit{"ounce", Mass, 28.3495}
+	pound      = Unit{"pound", Mass, 453.592}
+)
+
+// knownUnits maps unit names to their definitions. Ambiguous names (cup, pint,
+// fl oz) are read as US measures, which is what most imported recipes use.
+var knownUnits = map[string]Unit{}
+
+func init() {
+	for _, u := range []Unit{milliliter, liter, tsp, tbl, cup, flOz, pint, quart, gram, kilogram, ounce, pound} {
+		knownUnits[u.Name] = u
+	}
+}
+
+// Lookup returns the unit with the given normalised name.
+func Lookup(name string) (Unit, bool) {
+	u, ok := knownUnits[strings.ToLower(strings.TrimSpace(name))]
+	return u, ok
+}
+
+// step is one rung of a system's unit ladder: the unit is used for amounts
+// below limit (in millilitres or grams).
+type step struct {
+	unit  Unit
+	limit float64
+}
+
+// ladders lists, per system and dimension, the units amounts are displayed
+// in, smallest first.
+var ladders = map[System]map[Dimension][]step{
+	Metric: {
+		Volume: {{tsp, tbl.Size}, {tbl, cup.Size / 4}, {milliliter, 1000}, {liter, math.Inf(1)}},
+		Mass:   {{gram, 1000}, {kilogram, math.Inf(1)}},
+	},
+	US: {
+		Volume: {{tsp, tbl.Size}, {tbl, cup.Size / 4}, {cup, math.Inf(1)}},
+		Mass:   {{ounce, pound.Size}, {pound, math.Inf(1)}},
+	},
+	UK: {
+		Volume: {{tsp, tbl.Size}, {tbl, 2 * ukFlOz.Size}, {ukFlOz, ukPint.Size}, {ukPint, math.Inf(1)}},
+		Mass:   {{ounce, pound.Size}, {pound, math.Inf(1)}},
+	},
+}
+
+// native lists the units a system keeps as written instead of converting.
+var native = map[System]map[string]bool{
+	Metric: {"tsp": true, "tbl": true, "milliliter": true, "liter": true, "gram": true, "kilogram": true},
+	US:     {"tsp": true, "tbl": true, "cup": true, "fl oz": true, "pint": true, "quart": true, "ounce": true, "pound": true},
+	UK:     {"tsp": true, "tbl": true, "ounce": true, "pound": true},
+}
+
+// minWeighedVolume is the smallest volume (¼ cup) of a dry ingredient that
+// metric and UK output weigh instead of measuring with spoons.
+const minWeighedVolume = 59
+
+// Convert converts value between two units of the same dimension.
+func Convert(value float64, from, to Unit) (float64, error) {
+	if from.Dimension != to.Dimension {
+		return 0, fmt.Errorf("cannot convert %s to %s without a density", from.Name, to.Name)
+	}
+	return value * from.Size / to.Size, nil
+}
+
+// ConvertWithDensity converts value between any two units, using density
+// (grams per millilitre) when going between volume and mass.
+func ConvertWithDensity(value float64, from, to Unit, density float64) (float64, error) {
+	if from.Dimension == to.Dimension {
+		return Convert(value, from, to)
+	}
+	if density <= 0 {
+		return 0, fmt.Errorf("cannot convert %s to %s without a density", from.Name, to.Name)
+	}
+
+	base := value * from.Size
+	if from.Dimension == Volume {
+		base *= density
+	} else {
+		base /= density
+	}
+	return base / to.Size, nil
+}
+
+// Target picks the unit an amount of ingredient measured in from should be
+// shown in for system. value is the largest amount that will be displayed
+// (the upper end of a range), so both ends share a unit. It reports false when
+// the amount should be left as written.
+func Target(value float64, from Unit, ingredient string, system System) (Unit, bool) {
+	if system == Original || native[system][from.Name] {
+		return Unit{}, false
+	}
+
+	dimension, base := from.Dimension, value*from.Size
+	if d, ok := LookupDensity(ingredient); ok {
+		switch {
+		case system == US && from.Dimension == Mass:
+			dimension, base = Volume, base/d.Density
+		case system != US && from.Dimension == Volume && d.Dry && base >= minWeighedVolume:
+			dimension, base = Mass, base*d.Density
+		}
+	}
+
+	ladder := ladders[system][dimension]
+	for _, s := range ladder {
+		if base < s.limit {
+			return s.unit, true
+		}
+	}
+	return ladder[len(ladder)-1].unit, true
+}
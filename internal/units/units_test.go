@@ -0,0 +1,67 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	got, err := Convert(2, cup, tbl)
+	if err != nil || math.Abs(got-32) > 0.01 {
+		t.Errorf("Convert(2 cup, tbl) = %v, %v; want 32", got, err)
+	}
+	if _, err := Convert(1, cup, gram); err == nil {
+		t.Error("expected an error converting volume to mass without a density")
+	}
+
+	flour, _ := LookupDensity("all-purpose flour")
+	got, err = ConvertWithDensity(1, cup, gram, flour.Density)
+	if err != nil || math.Abs(got-125) > 1 {
+		t.Errorf("ConvertWithDensity(1 cup flour, gram) = %v, %v; want ~125", got, err)
+	}
+}
+
+func TestLookupDensity(t *testing.T) {
+	brown, _ := LookupDensity("Light brown sugar")
+	white, _ := LookupDensity("sugar")
+	if brown.Density == white.Density {
+		t.Errorf("brown sugar matched plain sugar: %v", brown)
+	}
+	if _, ok := LookupDensity("buttermilk biscuits"); !ok {
+		t.Error("expected buttermilk to be known")
+	}
+	if d, _ := LookupDensity("buttermilk"); d.Dry {
+		t.Error("buttermilk matched butter")
+	}
+	if _, ok := LookupDensity("eggs"); ok {
+		t.Error("eggs should have no density")
+	}
+}
+
+func TestTarget(t *testing.T) {
+	tests := []struct {
+		value      float64
+		from       Unit
+		ingredient string
+		system     System
+		want       string
+		convert    bool
+	}{
+		{2, cup, "flour", Metric, "gram", true},
+		{2, cup, "milk", Metric, "milliliter", true},
+		{1, tsp, "salt", Metric, "", false},
+		{6, cup, "water", Metric, "liter", true},
+		{500, gram, "flour", US, "cup", true},
+		{500, gram, "beef", US, "pound", true},
+		{4, ounce, "beef", US, "", false},
+		{2, cup, "milk", UK, "fl oz", true},
+		{2, cup, "milk", Original, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Target(tt.value, tt.from, tt.ingredient, tt.system)
+		if ok != tt.convert || got.Name != tt.want {
+			t.Errorf("Target(%v %s %s, %s) = %q, %v; want %q, %v",
+				tt.value, tt.from.Name, tt.ingredient, tt.system, got.Name, ok, tt.want, tt.convert)
+		}
+	}
+}
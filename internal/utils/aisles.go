@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Aisle names, in the order a shopping list walks the store
+const (
+	AisleProduce   = "Produce"
+	AisleMeat      = "Meat & Fish"
+	AisleDairy     = "Dairy & Eggs"
+	AisleBakery    = "Bakery"
+	AislePantry    = "Pantry"
+	AisleSpices    = "Spices & Seasonings"
+	AisleFrozen    = "Frozen"
+	AisleBeverages = "Beverages"
+	AisleOther     = "Other"
+)
+
+// Aisles lists every aisle in store order.
+var Aisles = []string{
+	AisleProduce, AisleMeat, AisleDairy, AisleBakery, AislePantry,
+	AisleSpices, AisleFrozen, AisleBeverages, AisleOther,
+}
+
+// aisleKeywords maps ingredient words to aisles. More specific entries come
+// first so "peanut butter" lands in the pantry rather than with the dairy.
+var aisleKeywords = []struct {
+	aisle    string
+	keywords []string
+}{
+	{AisleFrozen, []string{"frozen", "ice cream"}},
+	{AisleProduce, []string{"bell pepper", "bell peppers", "garlic", "chili pepper", "chili peppers"}},
+	{AislePantry, []string{"flour", "peanut butter", "coconut milk", "tomato paste", "tomato sauce", "canned", "broth", "stock"}},
+	{AisleSpices, []string{
+		"salt", "pepper", "peppercorns", "cumin", "paprika", "cinnamon", "nutmeg", "oregano", "thyme",
+		"rosemary", "bay leaf", "bay leaves", "chili powder", "chilli flakes", "curry", "turmeric",
+		"cardamom", "coriander seeds", "vanilla", "garam masala", "allspice", "seasoning",
+	}},
+	{AisleProduce, []string{
+		"onion", "onions", "shallot", "shallots", "tomato", "tomatoes", "potato", "potatoes",
+		"carrot", "carrots", "celery", "lettuce", "spinach", "kale", "cabbage", "cucumber", "zucchini",
+		"courgette", "eggplant", "aubergine", "peppers", "chili", "chilli", "jalapeno",
+		"mushroom", "mushrooms", "broccoli", "cauliflower", "leek", "leeks", "ginger", "lemon", "lemons",
+		"lime", "limes", "orange", "oranges", "apple", "apples", "banana", "bananas", "berries",
+		"strawberries", "blueberries", "avocado", "herbs", "parsley", "cilantro", "basil", "mint",
+		"dill", "chives", "scallion", "scallions", "spring onion", "corn", "peas", "bean sprouts",
+		"squash", "pumpkin", "sweet potato", "beetroot", "radish", "asparagus", "fruit",
+	}},
+	{AisleMeat, []string{
+		"chicken", "beef", "pork", "lamb", "turkey", "bacon", "ham", "sausage", "sausages", "mince",
+		"steak", "veal", "duck", "fish", "salmon", "tuna", "cod", "shrimp", "prawns", "anchovies",
+		"mussels", "clams", "crab", "chorizo", "prosciutto", "pancetta",
+	}},
+	{AisleDairy, []string{
+		"milk", "butter", "cheese", "cream", "yogurt", "yoghurt", "egg", "eggs", "parmesan",
+		"mozzarella", "cheddar", "feta", "ricotta", "mascarpone", "buttermilk", "creme fraiche",
+	}},
+	{AisleBakery, []string{"bread", "baguette", "buns", "rolls", "tortilla", "tortillas", "pita", "breadcrumbs", "brioche"}},
+	{AisleBeverages, []string{"wine", "beer", "juice", "coffee", "tea", "soda", "sparkling water"}},
+	{AislePantry, []string{
+		"sugar", "rice", "pasta", "spaghetti", "noodles", "oats", "oil", "vinegar", "honey",
+		"syrup", "baking powder", "baking soda", "yeast", "cornstarch", "cocoa", "chocolate", "nuts",
+		"almonds", "walnuts", "beans", "lentils", "chickpeas", "soy sauce", "mustard", "ketchup",
+		"mayonnaise", "jam", "raisins", "cereal", "quinoa", "couscous",
+	}},
+}
+
+var aisleMatchers = func() []struct {
+	aisle string
+	re    *regexp.Regexp
+} {
+	var matchers []struct {
+		aisle string
+		re    *regexp.Regexp
+	}
+	for _, entry := range aisleKeywords {
+		quoted := make([]string, len(entry.keywords))
+		for i, keyword := range entry.keywords {
+			quoted[i] = regexp.QuoteMeta(keyword)
+		}
+		matchers = append(matchers, struct {
+			aisle string
+			re    *regexp.Regexp
+		}{entry.aisle, regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)\b`)})
+	}
+	return matchers
+}()
+
+// AisleFor guesses the store aisle for an ingredient name, AisleOther when
+// nothing matches.
+func AisleFor(name string) string {
+	name = strings.ToLower(name)
+	for _, m := range aisleMatchers {
+		if m.re.MatchString(name) {
+			return m.aisle
+		}
+	}
+	return AisleOther
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SavedSearch is a named filter query. It works as a smart collection: the
+// recipes in it are whichever match the query at the time.
+type SavedSearch struct {
+	ID    uint
+	Name  string
+	Slug  string
+	Query string
+}
+
+// Collection is a hand-picked set of recipes in the user's order, like
+// "Thanksgiving 2026" or "Kids like it".
+type Collection struct {
+	ID          uint
+	Name        string
+	Slug        string
+	RecipeCount int
+}
+
+// CollectionSlug turns a collection name into the short name it is picked by
+// on the command line ("Quick Weeknight!" → "quick-weeknight").
+func CollectionSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else if r != '\'' && r != '’' {
+			dash = true
+		}
+	}
+	return b.String()
+}
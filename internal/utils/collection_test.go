@@ -0,0 +1,18 @@
+package utils
+
+import "testing"
+
+func TestCollectionSlug(t *testing.T) {
+	tests := map[string]string{
+		"Quick weeknight":        "quick-weeknight",
+		"  Mom's Sunday Roasts!": "moms-sunday-roasts",
+		"Soups & Stews / Winter": "soups-stews-winter",
+		"Crème brûlée":           "crème-brûlée",
+		"!!!":                    "",
+	}
+	for name, want := range tests {
+		if got := CollectionSlug(name); got != want {
+			t.Errorf("CollectionSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
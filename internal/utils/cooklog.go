@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CookEvent records one time a recipe was cooked. Date is midnight of the
+// day in local time.
+type CookEvent struct {
+	ID       uint
+	RecipeID uint
+	Date     time.Time
+	Servings float64 // 0 when not noted
+	Rating   int8    // 1-5, 0 when not rated
+	Notes    string
+	Tweaks   string // changes made to the recipe this time
+}
+
+// ParseCookDate parses "2026-10-17", "today" or "yesterday"
+func ParseCookDate(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := StartOfDay(now)
+	switch s {
+	case "", "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	date, err := time.ParseInLocation(PlanDateLayout, s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD, today or yesterday)", s)
+	}
+	if date.After(today) {
+		return time.Time{}, fmt.Errorf("%s is in the future", s)
+	}
+	return date, nil
+}
+
+// FormatCookHistory renders cook events, newest first, as a markdown
+// section for the recipe detail view. It is empty without events.
+func FormatCookHistory(events []CookEvent) string {
+	if len(events) == 0 {
+		return ""
+	}
+
+	var s strings.Builder
+	if len(events) == 1 {
+		s.WriteString("### 📖 Cook History · cooked once\n\n")
+	} else {
+		s.WriteString(fmt.Sprintf("### 📖 Cook History · cooked %d times\n\n", len(events)))
+	}
+
+	for _, event := range events {
+		line := "• **" + event.Date.Format("Jan 2, 2006") + "**"
+		if event.Servings > 0 {
+			line += fmt.Sprintf(" · %s servings", FormatAmount(event.Servings))
+		}
+		if rating := FormatRating(event.Rating); rating != "" {
+			line += " · " + rating
+		}
+		s.WriteString(line + "\n\n")
+		if event.Tweaks != "" {
+			s.WriteString(fmt.Sprintf("  🔧 *%s*\n\n", event.Tweaks))
+		}
+		if event.Notes != "" {
+			s.WriteString(fmt.Sprintf("  📝 %s\n\n", event.Notes))
+		}
+	}
+	return s.String()
+}
+
+// RecipeSort is an order of the recipe list
+type RecipeSort string
+
+const (
+	SortByName       RecipeSort = "name"
+	SortByLastCooked RecipeSort = "last-cooked"
+)
+
+// RecipeSorts lists every order in the order the list cycles through them
+var RecipeSorts = []RecipeSort{SortByName, SortByLastCooked}
+
+// ParseRecipeSort parses an order name, case-insensitively
+func ParseRecipeSort(s string) (RecipeSort, error) {
+	sortBy := RecipeSort(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range RecipeSorts {
+		if sortBy == known {
+			return sortBy, nil
+		}
+	}
+	return "", fmt.Errorf("unknown sort %q (use name or last-cooked)", s)
+}
+
+// Next returns the order after this one, wrapping around
+func (s RecipeSort) Next() RecipeSort {
+	for i, known := range RecipeSorts {
+		if s == known {
+			return RecipeSorts[(i+1)%len(RecipeSorts)]
+		}
+	}
+	return SortByName
+}
+
+// Label returns the order for display ("last cooked")
+func (s RecipeSort) Label() string {
+	return strings.ReplaceAll(string(s), "-", " ")
+}
+
+// SortRecipes orders recipes by name, or most recently cooked first with
+// recipes never cooked last, by name.
+func SortRecipes(recipes []RecipeRaw, by RecipeSort) {
+	sort.SliceStable(recipes, func(i, j int) bool {
+		a, b := recipes[i], recipes[j]
+		if by == SortByLastCooked && !a.Metadata.LastCooked.Equal(b.Metadata.LastCooked) {
+			return a.Metadata.LastCooked.After(b.Metadata.LastCooked)
+		}
+		return strings.ToLower(a.RecipeName) < strings.ToLower(b.RecipeName)
+	})
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCookDate(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "2026-10-17"},
+		{"today", "2026-10-17"},
+		{"Yesterday", "2026-10-16"},
+		{"2026-09-30", "2026-09-30"},
+	}
+	for _, tt := range tests {
+		got, err := ParseCookDate(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseCookDate(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got.Format(PlanDateLayout) != tt.want {
+			t.Errorf("ParseCookDate(%q) = %s, want %s", tt.input, got.Format(PlanDateLayout), tt.want)
+		}
+	}
+
+	for _, bad := range []string{"tomorrow", "2026-10-18", "last week"} {
+		if _, err := ParseCookDate(bad, now); err == nil {
+			t.Errorf("ParseCookDate(%q) expected an error", bad)
+		}
+	}
+}
+
+func TestSortRecipes(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.Local) }
+	recipes := []RecipeRaw{
+		{RecipeName: "soup"},
+		{RecipeName: "Bread", Metadata: RecipeMetadata{LastCooked: day(3)}},
+		{RecipeName: "apple pie"},
+		{RecipeName: "Curry", Metadata: RecipeMetadata{LastCooked: day(12)}},
+	}
+
+	names := func() string {
+		var out []string
+		for _, r := range recipes {
+			out = append(out, r.RecipeName)
+		}
+		return strings.Join(out, ", ")
+	}
+
+	SortRecipes(recipes, SortByLastCooked)
+	if got, want := names(), "Curry, Bread, apple pie, soup"; got != want {
+		t.Errorf("by last cooked = %s, want %s", got, want)
+	}
+	SortRecipes(recipes, SortByName)
+	if got, want := names(), "apple pie, Bread, Curry, soup"; got != want {
+		t.Errorf("by name = %s, want %s", got, want)
+	}
+
+	if SortByName.Next() != SortByLastCooked || SortByLastCooked.Next() != SortByName {
+		t.Error("Next should cycle through every sort")
+	}
+	if _, err := ParseRecipeSort("rating"); err == nil {
+		t.Error("ParseRecipeSort(rating) expected an error")
+	}
+}
+
+func TestFormatCookHistory(t *testing.T) {
+	if got := FormatCookHistory(nil); got != "" {
+		t.Errorf("empty history = %q, want empty", got)
+	}
+
+	history := FormatCookHistory([]CookEvent{
+		{Date: time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local), Servings: 6, Rating: 4, Tweaks: "less chilli", Notes: "kids loved it"},
+		{Date: time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)},
+	})
+	for _, want := range []string{"cooked 2 times", "**Oct 12, 2026** · 6 servings · ★★★★☆", "less chilli", "kids loved it", "**Sep 1, 2026**"} {
+		if !strings.Contains(history, want) {
+			t.Errorf("history missing %q:\n%s", want, history)
+		}
+	}
+}
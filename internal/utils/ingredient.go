@@ -16,13 +16,18 @@ type Ingredient struct {
 	Name     string
 	Details  string
 	BaseName string // core ingredient word(s) for highlighting (e.g. "thyme" from "dried thyme")
+	Group    string // ingredient group, e.g. "For the sauce"; empty for ungrouped recipes
 }
 
 func ParseIngredient(input string) (Ingredient, error) {
 	ingredient := Ingredient{}
-	unitsPattern := strings.Join(CorpusMeasures, "|")
-	re := regexp.MustCompile(fmt.Sprintf(`^(?:(\d+(?:\.\d+)?(?:/\d+(?:\.\d+)?)?(?:\s*-\s*\d+(?:\.\d+)?(?:/\d+(?:\.\d+)?)?)?\s*))?((?:%s)\s+)?([^(]+?)(?:\s*\((.*?)\))?$`, unitsPattern))
-	matches := re.FindStringSubmatch(strings.ToLower(input))
+	measures := make([]string, len(CorpusMeasures))
+	for i, measure := range CorpusMeasures {
+		measures[i] = regexp.QuoteMeta(measure)
+	}
+	unitsPattern := strings.Join(measures, "|")
+	re := regexp.MustCompile(fmt.Sprintf(`(?i)^(?:(%s)\s*)?((?:%s)\s+)?([^(]+?)(?:\s*\((.*?)\))?$`, quantityRangePattern, unitsPattern))
+	matches := re.FindStringSubmatch(strings.TrimSpace(input))
 	if len(matches) == 0 {
 		return ingredient, fmt.Errorf("invalid ingredient")
 	} else if len(matches) < 5 {
@@ -30,7 +35,7 @@ func ParseIngredient(input string) (Ingredient, error) {
 		return ingredient, nil
 	}
 
-	unit := strings.TrimSpace(matches[2])
+	unit := strings.ToLower(strings.TrimSpace(matches[2]))
 	if unit != "" {
 		if normalizedUnit, exists := CorpusMeasuresMap[unit]; exists {
 			unit = normalizedUnit
@@ -46,6 +51,35 @@ func ParseIngredient(input string) (Ingredient, error) {
 	return ingredient, nil
 }
 
+var (
+	formattedIngredientRe = regexp.MustCompile(`^(?:\*\*(.+?)\*\*\s+)?\*([^*]+)\*(?:\s*\((.*)\))?$`)
+	amountTokenRe         = regexp.MustCompile(`^[\d.,/½¼¾⅓⅔⅛-]+$`)
+)
+
+// parseFormattedIngredient parses an ingredient line written by
+// FormatRecipeMarkdown ("**2 cup** *flour* (sifted)"), keeping the amount,
+// unit and name exactly as they were exported.
+func parseFormattedIngredient(text string) (Ingredient, bool) {
+	matches := formattedIngredientRe.FindStringSubmatch(strings.TrimSpace(text))
+	if matches == nil {
+		return Ingredient{}, false
+	}
+
+	ingredient := Ingredient{
+		Name:    strings.TrimSpace(matches[2]),
+		Details: strings.TrimSpace(matches[3]),
+	}
+
+	fields := strings.Fields(matches[1])
+	i := 0
+	for i < len(fields) && amountTokenRe.MatchString(fields[i]) {
+		i++
+	}
+	ingredient.Amount = strings.Join(fields[:i], " ")
+	ingredient.Unit = strings.Join(fields[i:], " ")
+	return ingredient, true
+}
+
 // ingredientStopWords are common words that should NOT be treated as
 // matchable ingredient tokens (too generic / likely to false-positive).
 var ingredientStopWords = map[string]bool{
@@ -166,34 +200,38 @@ func HighlightIngredientsWithStyle(text string, ingredients []Ingredient, style
 	})
 }
 
+// ingredientGroupHeadingRe matches the group/section sub-headings written inside
+// the ingredients and instructions sections (e.g. "#### For the sauce").
+var ingredientGroupHeadingRe = regexp.MustCompile(`^#{4,6}\s+(.+)$`)
+
 // ParseIngredientsFromMarkdown extracts ingredients from the markdown
 func ParseIngredientsFromMarkdown(text string) ([]Ingredient, error) {
-	// Find the ingredients section
-	ingredientsStart := strings.Index(text, "## 🥘 Ingredients")
-	if ingredientsStart == -1 {
+	ingredientsSection, ok := markdownSection(text, "🥘 Ingredients")
+	if !ok {
 		return []Ingredient{}, fmt.Errorf("no ingredients found")
 	}
 
-	// Find the end of ingredients section (next ## or end of text)
-	ingredientsEnd := strings.Index(text[ingredientsStart:], "\n## ")
-	if ingredientsEnd == -1 {
-		ingredientsEnd = len(text)
-	} else {
-		ingredientsEnd += ingredientsStart
-	}
-
-	ingredientsSection := text[ingredientsStart:ingredientsEnd]
 	ingredients := []Ingredient{}
+	group := ""
 	lines := strings.Split(ingredientsSection, "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
+		if match := ingredientGroupHeadingRe.FindStringSubmatch(line); match != nil {
+			group = strings.TrimSpace(match[1])
+			continue
+		}
 		if strings.HasPrefix(line, "• ") {
 			ingredientText := strings.TrimPrefix(line, "• ")
-			ingredient, err := ParseIngredient(ingredientText)
-			if err != nil {
-				slog.Error("Failed to parse ingredient", "error", err)
-				continue
+			ingredient, ok := parseFormattedIngredient(ingredientText)
+			if !ok {
+				var err error
+				ingredient, err = ParseIngredient(stripMarkdownEmphasis(ingredientText))
+				if err != nil {
+					slog.Error("Failed to parse ingredient", "error", err)
+					continue
+				}
 			}
+			ingredient.Group = group
 			ingredients = append(ingredients, ingredient)
 		}
 	}
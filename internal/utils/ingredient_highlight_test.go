@@ -87,6 +87,20 @@ func TestParseIngredient(t *testing.T) {
 			unit:    "cup",
 			ingName: "broth",
 		},
+		{
+			name:    "mixed number amount",
+			input:   "1 1/2 cups flour",
+			amount:  "1 1/2",
+			unit:    "cup",
+			ingName: "flour",
+		},
+		{
+			name:    "unicode fraction amount",
+			input:   "½ cup milk",
+			amount:  "½",
+			unit:    "cup",
+			ingName: "milk",
+		},
 		{
 			name:    "decimal in parenthetical detail",
 			input:   "1 can tomato sauce (8 ounce)",
@@ -95,6 +109,19 @@ func TestParseIngredient(t *testing.T) {
 			ingName: "tomato sauce",
 			details: "8 ounce",
 		},
+		{
+			name:    "unit with a dot",
+			input:   "2 fl. oz cream",
+			amount:  "2",
+			unit:    "fl oz",
+			ingName: "cream",
+		},
+		{
+			name:    "dot in a unit is not a wildcard",
+			input:   "2 flx oz cream",
+			amount:  "2",
+			ingName: "flx oz cream",
+		},
 	}
 
 	for _, tt := range tests {
@@ -8,8 +8,8 @@ import (
 	"strings"
 	"sync"
 
+	yummy_llm "github.com/GarroshIcecream/yummy/internal/llm"
 	"github.com/tmc/langchaingo/llms"
-	"github.com/tmc/langchaingo/llms/ollama"
 )
 
 // llmIngredient mirrors the JSON shape we ask the LLM to produce.
@@ -21,7 +21,7 @@ type llmIngredient struct {
 	BaseName string `json:"base_name"`
 }
 
-// maxParallelLLM limits the number of concurrent Ollama requests to avoid
+// maxParallelLLM limits the number of concurrent LLM requests to avoid
 // overwhelming the local model server.
 const maxParallelLLM = 4
 
@@ -83,7 +83,7 @@ func parseSingleIngredient(ctx context.Context, llm llms.Model, raw string) (llm
 		llms.WithJSONMode(),
 	)
 	if err != nil {
-		return llmIngredient{}, fmt.Errorf("ollama generate: %w", err)
+		return llmIngredient{}, fmt.Errorf("LLM generate: %w", err)
 	}
 
 	if len(resp.Choices) == 0 || resp.Choices[0].Content == "" {
@@ -101,22 +101,22 @@ func parseSingleIngredient(ctx context.Context, llm llms.Model, raw string) (llm
 	return parsed, nil
 }
 
-// ParseIngredientsWithLLM sends parallel requests to a local Ollama model —
+// ParseIngredientsWithLLM sends parallel requests to the configured LLM —
 // one per ingredient — and returns structured Ingredient values. Each request
 // uses JSON mode so the model is forced to return valid JSON.
 //
 // If an individual ingredient fails to parse via LLM, it falls back to the
 // regex-based ParseIngredient for that ingredient only.
 //
-// modelName is the Ollama model to use (e.g. "gemma3:4b").
+// modelName is the model to use on the configured provider (e.g. "gemma3:4b").
 func ParseIngredientsWithLLM(ctx context.Context, rawIngredients []string, modelName string) ([]Ingredient, error) {
 	if len(rawIngredients) == 0 {
 		return []Ingredient{}, nil
 	}
 
-	llm, err := ollama.New(ollama.WithModel(modelName))
+	llm, err := yummy_llm.New(modelName)
 	if err != nil {
-		return nil, fmt.Errorf("create ollama client: %w", err)
+		return nil, fmt.Errorf("create LLM client: %w", err)
 	}
 
 	// Filter out empty lines upfront, keeping track of original indices.
@@ -195,9 +195,9 @@ func ParseIngredientsWithLLM(ctx context.Context, rawIngredients []string, model
 	return ingredients, nil
 }
 
-// ExtractBaseNamesWithLLM takes already-parsed ingredients and asks an Ollama
-// model to extract the core ingredient noun(s) for each. It mutates the
-// BaseName field in-place on the provided slice.
+// ExtractBaseNamesWithLLM takes already-parsed ingredients and asks the LLM
+// to extract the core ingredient noun(s) for each. It mutates the BaseName
+// field in-place on the provided slice.
 //
 // This is the lighter-weight alternative: it only extracts base names rather
 // than doing a full structured parse, so it can be used even when ingredients
@@ -207,9 +207,9 @@ func ExtractBaseNamesWithLLM(ctx context.Context, ingredients []Ingredient, mode
 		return nil
 	}
 
-	llm, err := ollama.New(ollama.WithModel(modelName))
+	llm, err := yummy_llm.New(modelName)
 	if err != nil {
-		return fmt.Errorf("create ollama client: %w", err)
+		return fmt.Errorf("create LLM client: %w", err)
 	}
 
 	// Build numbered list of ingredient names.
@@ -232,7 +232,7 @@ func ExtractBaseNamesWithLLM(ctx context.Context, ingredients []Ingredient, mode
 		llms.WithJSONMode(),
 	)
 	if err != nil {
-		return fmt.Errorf("ollama generate: %w", err)
+		return fmt.Errorf("LLM generate: %w", err)
 	}
 
 	if len(resp.Choices) == 0 || resp.Choices[0].Content == "" {
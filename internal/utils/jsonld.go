@@ -0,0 +1,578 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const schemaOrgContext = "https://schema.org"
+
+// SchemaOrgRecipe is the schema.org Recipe JSON-LD shape written on export.
+// Import goes through RecipeFromSchemaOrg, which accepts the looser shapes
+// found in the wild (strings vs. arrays vs. objects).
+//
+// schema.org has no place for personal notes, so they are written to the
+// yummyNotes and yummyStepNotes extension properties, which other consumers
+// ignore.
+type SchemaOrgRecipe struct {
+	Context            string            `json:"@context"`
+	Type               string            `json:"@type"`
+	Name               string            `json:"name"`
+	Description        string            `json:"description,omitempty"`
+	Author             *SchemaOrgPerson  `json:"author,omitempty"`
+	URL                string            `json:"url,omitempty"`
+	Image              string            `json:"image,omitempty"`
+	InLanguage         string            `json:"inLanguage,omitempty"`
+	DateCreated        string            `json:"dateCreated,omitempty"`
+	RecipeYield        string            `json:"recipeYield,omitempty"`
+	PrepTime           string            `json:"prepTime,omitempty"`
+	CookTime           string            `json:"cookTime,omitempty"`
+	TotalTime          string            `json:"totalTime,omitempty"`
+	RecipeCategory     []string          `json:"recipeCategory,omitempty"`
+	RecipeCuisine      []string          `json:"recipeCuisine,omitempty"`
+	Keywords           string            `json:"keywords,omitempty"`
+	SuitableForDiet    []string          `json:"suitableForDiet,omitempty"`
+	RecipeIngredient   []string          `json:"recipeIngredient"`
+	RecipeInstructions []SchemaOrgHowTo  `json:"recipeInstructions"`
+	AggregateRating    *SchemaOrgRating  `json:"aggregateRating,omitempty"`
+	Nutrition          map[string]string `json:"nutrition,omitempty"`
+	Notes              string            `json:"yummyNotes,omitempty"`
+	StepNotes          []StepNote        `json:"yummyStepNotes,omitempty"`
+}
+
+// SchemaOrgPerson is a schema.org Person
+type SchemaOrgPerson struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// SchemaOrgHowTo is a schema.org HowToStep, or a HowToSection when
+// ItemListElement is set.
+type SchemaOrgHowTo struct {
+	Type            string           `json:"@type"`
+	Name            string           `json:"name,omitempty"`
+	Text            string           `json:"text,omitempty"`
+	ItemListElement []SchemaOrgHowTo `json:"itemListElement,omitempty"`
+}
+
+// SchemaOrgRating is a schema.org AggregateRating
+type SchemaOrgRating struct {
+	Type        string `json:"@type"`
+	RatingValue int8   `json:"ratingValue"`
+	BestRating  int    `json:"bestRating"`
+	RatingCount int    `json:"ratingCount"`
+}
+
+var iso8601DurationRe = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseISO8601Duration parses durations such as "PT1H30M" or "P1DT2H".
+func ParseISO8601Duration(s string) (time.Duration, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	match := iso8601DurationRe.FindStringSubmatch(s)
+	if match == nil || s == "P" || s == "PT" {
+		return 0, fmt.Errorf("invalid ISO-8601 duration: %q", s)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, err
+		}
+		total += time.Duration(value * float64(unit))
+	}
+	return total, nil
+}
+
+// FormatISO8601Duration formats a duration as "PT1H30M". Returns "" for zero durations.
+func FormatISO8601Duration(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	d = d.Round(time.Second)
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if seconds > 0 {
+		fmt.Fprintf(&b, "%dS", seconds)
+	}
+	return b.String()
+}
+
+// FormatIngredientLine renders an ingredient as a single plain-text line,
+// e.g. "2 cup flour (sifted)".
+func FormatIngredientLine(ing Ingredient) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{ing.Amount, ing.Unit, ing.Name} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	line := strings.Join(parts, " ")
+	if ing.Details != "" {
+		line += fmt.Sprintf(" (%s)", ing.Details)
+	}
+	return line
+}
+
+// ToSchemaOrg converts the recipe into schema.org Recipe JSON-LD
+func (r *RecipeRaw) ToSchemaOrg() SchemaOrgRecipe {
+	recipe := SchemaOrgRecipe{
+		Context:            schemaOrgContext,
+		Type:               "Recipe",
+		Name:               r.RecipeName,
+		Description:        r.RecipeDescription,
+		URL:                r.Metadata.URL,
+		Image:              r.Metadata.ImageURL,
+		InLanguage:         r.Metadata.Language,
+		RecipeYield:        r.Metadata.Quantity,
+		PrepTime:           FormatISO8601Duration(r.Metadata.PrepTime),
+		CookTime:           FormatISO8601Duration(r.Metadata.CookTime),
+		TotalTime:          FormatISO8601Duration(r.Metadata.TotalTime),
+		RecipeCategory:     r.Metadata.Categories,
+		RecipeCuisine:      r.Metadata.Cuisines,
+		Keywords:           strings.Join(r.Metadata.Keywords, ", "),
+		RecipeIngredient:   make([]string, 0, len(r.Metadata.Ingredients)),
+		RecipeInstructions: make([]SchemaOrgHowTo, 0, len(r.Metadata.Instructions)),
+		Notes:              r.Metadata.Notes,
+		StepNotes:          r.Metadata.StepNotes,
+	}
+
+	if r.Metadata.Author != "" {
+		recipe.Author = &SchemaOrgPerson{Type: "Person", Name: r.Metadata.Author}
+	}
+	if !r.Metadata.CreatedAt.IsZero() {
+		recipe.DateCreated = r.Metadata.CreatedAt.Format("2006-01-02")
+	}
+	for _, ing := range r.Metadata.Ingredients {
+		recipe.RecipeIngredient = append(recipe.RecipeIngredient, FormatIngredientLine(ing))
+	}
+	for i, step := range r.Metadata.Instructions {
+		howToStep := SchemaOrgHowTo{Type: "HowToStep", Text: step}
+		title, starts := r.Metadata.InstructionSectionAt(i)
+		if title == "" {
+			recipe.RecipeInstructions = append(recipe.RecipeInstructions, howToStep)
+			continue
+		}
+		// Sections of imported recipes may start before the first step or be
+		// out of order, so a step only joins the section just before it
+		last := len(recipe.RecipeInstructions) - 1
+		if starts || last < 0 || recipe.RecipeInstructions[last].Type != "HowToSection" || recipe.RecipeInstructions[last].Name != title {
+			recipe.RecipeInstructions = append(recipe.RecipeInstructions, SchemaOrgHowTo{
+				Type:            "HowToSection",
+				Name:            title,
+				ItemListElement: []SchemaOrgHowTo{howToStep},
+			})
+			continue
+		}
+		section := &recipe.RecipeInstructions[last]
+		section.ItemListElement = append(section.ItemListElement, howToStep)
+	}
+	for _, diet := range r.Metadata.Diets {
+		recipe.SuitableForDiet = append(recipe.SuitableForDiet, DietSchemaURL(diet))
+	}
+	if r.Metadata.Rating > 0 {
+		recipe.AggregateRating = &SchemaOrgRating{Type: "AggregateRating", RatingValue: r.Metadata.Rating, BestRating: 5, RatingCount: 1}
+	}
+	if len(r.Metadata.Nutrients) > 0 {
+		recipe.Nutrition = map[string]string{"@type": "NutritionInformation"}
+		for key, value := range r.Metadata.Nutrients {
+			recipe.Nutrition[key] = value
+		}
+	}
+	return recipe
+}
+
+// FormatRecipeJSONLD formats the recipe as indented schema.org Recipe JSON-LD
+func (r *RecipeRaw) FormatRecipeJSONLD() (string, error) {
+	data, err := json.MarshalIndent(r.ToSchemaOrg(), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal recipe: %v", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// IsSchemaOrgJSON reports whether the JSON document looks like schema.org JSON-LD
+func IsSchemaOrgJSON(content []byte) bool {
+	var doc any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return false
+	}
+	_, ok := FindSchemaOrgRecipe(doc)
+	return ok
+}
+
+// ParseSchemaOrgRecipe parses the first schema.org Recipe found in a JSON-LD document
+func ParseSchemaOrgRecipe(content []byte) (*RecipeRaw, error) {
+	var doc any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON-LD: %v", err)
+	}
+	node, ok := FindSchemaOrgRecipe(doc)
+	if !ok {
+		return nil, fmt.Errorf("no schema.org Recipe found")
+	}
+	return RecipeFromSchemaOrg(node), nil
+}
+
+// SchemaOrgNode is a decoded schema.org object, from JSON-LD or microdata.
+type SchemaOrgNode map[string]any
+
+// IsType reports whether the node's @type matches typeName (e.g. "Recipe")
+func (n SchemaOrgNode) IsType(typeName string) bool {
+	for _, t := range schemaOrgTexts(n["@type"], false) {
+		t = strings.TrimPrefix(strings.TrimPrefix(t, "http://schema.org/"), "https://schema.org/")
+		if strings.EqualFold(t, typeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Text returns the first text value of a property
+func (n SchemaOrgNode) Text(key string) string {
+	return schemaOrgText(n[key])
+}
+
+// Texts returns every text value of a property. When splitCommas is set,
+// comma separated strings ("Dessert, Snack") are split into items.
+func (n SchemaOrgNode) Texts(key string, splitCommas bool) []string {
+	return schemaOrgTexts(n[key], splitCommas)
+}
+
+// Duration parses a duration property, ISO-8601 or free text
+func (n SchemaOrgNode) Duration(key string) time.Duration {
+	return schemaOrgDuration(n[key])
+}
+
+// Yield picks the most descriptive recipeYield ("4 servings" over "4")
+func (n SchemaOrgNode) Yield() string {
+	best := ""
+	for _, text := range n.Texts("recipeYield", false) {
+		if len(text) > len(best) {
+			best = text
+		}
+	}
+	return best
+}
+
+// Ingredients returns the raw recipeIngredient lines
+func (n SchemaOrgNode) Ingredients() []string {
+	if _, ok := n["recipeIngredient"]; ok {
+		return n.Texts("recipeIngredient", false)
+	}
+	return n.Texts("ingredients", false)
+}
+
+// Instructions flattens recipeInstructions into steps
+func (n SchemaOrgNode) Instructions() []string {
+	return schemaOrgInstructions(n["recipeInstructions"])
+}
+
+// InstructionSections returns the named HowToSections of recipeInstructions
+func (n SchemaOrgNode) InstructionSections() []InstructionSection {
+	return schemaOrgInstructionSections(n["recipeInstructions"])
+}
+
+// Rating converts aggregateRating to a 0-5 star rating
+func (n SchemaOrgNode) Rating() int8 {
+	return schemaOrgRating(n["aggregateRating"])
+}
+
+// Image returns the first image URL (from a string, ImageObject or list of either)
+func (n SchemaOrgNode) Image() string {
+	image := n["image"]
+	if list, ok := image.([]any); ok && len(list) > 0 {
+		image = list[0]
+	}
+	switch v := image.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		img := SchemaOrgNode(v)
+		for _, key := range []string{"url", "contentUrl"} {
+			if u := img.Text(key); u != "" {
+				return u
+			}
+		}
+	}
+	return ""
+}
+
+// Diets returns the display names of the suitableForDiet values (e.g. "Vegan")
+func (n SchemaOrgNode) Diets() []string {
+	diets := []string{}
+	for _, text := range n.Texts("suitableForDiet", false) {
+		if name := DietName(text); name != "" {
+			diets = append(diets, name)
+		}
+	}
+	return diets
+}
+
+// Nutrition returns the NutritionInformation properties as text
+func (n SchemaOrgNode) Nutrition() map[string]string {
+	return schemaOrgNutrition(n["nutrition"])
+}
+
+// Notes returns the personal notes of the yummyNotes extension property
+func (n SchemaOrgNode) Notes() string {
+	notes, _ := n["yummyNotes"].(string)
+	return strings.TrimSpace(notes)
+}
+
+// StepNotes returns the step annotations of the yummyStepNotes extension
+// property, skipping malformed entries
+func (n SchemaOrgNode) StepNotes() []StepNote {
+	list, _ := n["yummyStepNotes"].([]any)
+	var notes []StepNote
+	for _, item := range list {
+		entry, _ := item.(map[string]any)
+		step, ok := entry["step"].(float64)
+		note, _ := entry["note"].(string)
+		if !ok || step < 0 || strings.TrimSpace(note) == "" {
+			continue
+		}
+		notes = append(notes, StepNote{Step: int(step), Note: strings.TrimSpace(note)})
+	}
+	return notes
+}
+
+// FindSchemaOrgRecipe walks a decoded JSON-LD document (arrays, @graph and
+// nested objects) and returns the first node whose @type is Recipe.
+func FindSchemaOrgRecipe(doc any) (SchemaOrgNode, bool) {
+	switch v := doc.(type) {
+	case []any:
+		for _, item := range v {
+			if node, ok := FindSchemaOrgRecipe(item); ok {
+				return node, true
+			}
+		}
+	case map[string]any:
+		node := SchemaOrgNode(v)
+		if node.IsType("Recipe") {
+			return node, true
+		}
+		if graph, ok := v["@graph"]; ok {
+			return FindSchemaOrgRecipe(graph)
+		}
+		if entity, ok := v["mainEntity"]; ok {
+			return FindSchemaOrgRecipe(entity)
+		}
+	}
+	return nil, false
+}
+
+// RecipeFromSchemaOrg converts a schema.org Recipe node into a RecipeRaw
+func RecipeFromSchemaOrg(node SchemaOrgNode) *RecipeRaw {
+	recipe := &RecipeRaw{
+		RecipeName:        node.Text("name"),
+		RecipeDescription: node.Text("description"),
+		Metadata: RecipeMetadata{
+			Author:       strings.Join(node.Texts("author", false), ", "),
+			URL:          node.Text("url"),
+			ImageURL:     node.Image(),
+			Language:     node.Text("inLanguage"),
+			Quantity:     node.Yield(),
+			PrepTime:     node.Duration("prepTime"),
+			CookTime:     node.Duration("cookTime"),
+			TotalTime:    node.Duration("totalTime"),
+			Categories:   node.Texts("recipeCategory", true),
+			Cuisines:     node.Texts("recipeCuisine", true),
+			Keywords:     node.Texts("keywords", true),
+			Diets:        node.Diets(),
+			Instructions: node.Instructions(),
+
+			InstructionSections: node.InstructionSections(),
+			Ingredients:         []Ingredient{},
+			Rating:              node.Rating(),
+			Nutrients:           node.Nutrition(),
+			Notes:               node.Notes(),
+			StepNotes:           node.StepNotes(),
+		},
+	}
+
+	for _, line := range node.Ingredients() {
+		ingredient, err := ParseIngredient(line)
+		if err != nil || ingredient.Name == "" {
+			ingredient = Ingredient{Name: line}
+		}
+		recipe.Metadata.Ingredients = append(recipe.Metadata.Ingredients, ingredient)
+	}
+
+	if recipe.Metadata.TotalTime == 0 {
+		recipe.Metadata.TotalTime = recipe.Metadata.PrepTime + recipe.Metadata.CookTime
+	}
+	return recipe
+}
+
+// schemaOrgText returns the text of a JSON-LD value: strings are unescaped,
+// objects yield their name/@value/text, and arrays their first text.
+func schemaOrgText(v any) string {
+	switch val := v.(type) {
+	case string:
+		return strings.Join(strings.Fields(html.UnescapeString(val)), " ")
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case map[string]any:
+		for _, key := range []string{"name", "@value", "text", "url", "@id"} {
+			if text := schemaOrgText(val[key]); text != "" {
+				return text
+			}
+		}
+	case []any:
+		for _, item := range val {
+			if text := schemaOrgText(item); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// schemaOrgTexts returns every text in a JSON-LD value. When splitCommas is
+// set, comma separated strings ("Dessert, Snack") are split into items.
+func schemaOrgTexts(v any, splitCommas bool) []string {
+	var items []any
+	if list, ok := v.([]any); ok {
+		items = list
+	} else if v != nil {
+		items = []any{v}
+	}
+
+	texts := []string{}
+	seen := map[string]bool{}
+	for _, item := range items {
+		text := schemaOrgText(item)
+		parts := []string{text}
+		if splitCommas {
+			parts = strings.Split(text, ",")
+		}
+		for _, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" || seen[strings.ToLower(part)] {
+				continue
+			}
+			seen[strings.ToLower(part)] = true
+			texts = append(texts, part)
+		}
+	}
+	return texts
+}
+
+func schemaOrgDuration(v any) time.Duration {
+	text := schemaOrgText(v)
+	if d, err := ParseISO8601Duration(text); err == nil {
+		return d
+	}
+	return ParseDurationFromString(text)
+}
+
+// schemaOrgInstructions flattens recipeInstructions: plain text (one step per
+// line), lists of strings, HowToStep and nested HowToSection items.
+func schemaOrgInstructions(v any) []string {
+	steps := []string{}
+	switch val := v.(type) {
+	case string:
+		for _, line := range strings.Split(html.UnescapeString(val), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				steps = append(steps, line)
+			}
+		}
+	case []any:
+		for _, item := range val {
+			steps = append(steps, schemaOrgInstructions(item)...)
+		}
+	case map[string]any:
+		if elements, ok := val["itemListElement"]; ok {
+			return schemaOrgInstructions(elements)
+		}
+		text := schemaOrgText(val["text"])
+		if text == "" {
+			text = schemaOrgText(val["name"])
+		}
+		if text != "" {
+			steps = append(steps, text)
+		}
+	}
+	return steps
+}
+
+// schemaOrgInstructionSections maps each named HowToSection to the step it starts at.
+func schemaOrgInstructionSections(v any) []InstructionSection {
+	items, ok := v.([]any)
+	if !ok {
+		items = []any{v}
+	}
+
+	var sections []InstructionSection
+	step := 0
+	for _, item := range items {
+		count := len(schemaOrgInstructions(item))
+		if node, ok := item.(map[string]any); ok && count > 0 {
+			if _, isSection := node["itemListElement"]; isSection {
+				if title := schemaOrgText(node["name"]); title != "" {
+					sections = append(sections, InstructionSection{Title: title, Step: step + 1})
+				}
+			}
+		}
+		step += count
+	}
+	return sections
+}
+
+// schemaOrgRating converts aggregateRating to a 0-5 star rating.
+func schemaOrgRating(v any) int8 {
+	node, ok := v.(map[string]any)
+	if !ok {
+		return 0
+	}
+	value, err := strconv.ParseFloat(schemaOrgText(node["ratingValue"]), 64)
+	if err != nil {
+		return 0
+	}
+	if best, err := strconv.ParseFloat(schemaOrgText(node["bestRating"]), 64); err == nil && best > 0 && best != 5 {
+		value = value / best * 5
+	}
+	return int8(math.Max(0, math.Min(5, math.Round(value))))
+}
+
+// schemaOrgNutrition returns the NutritionInformation properties as text.
+func schemaOrgNutrition(v any) map[string]string {
+	node, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	nutrients := map[string]string{}
+	for key, value := range node {
+		if strings.HasPrefix(key, "@") {
+			continue
+		}
+		if text := schemaOrgText(value); text != "" {
+			nutrients[key] = text
+		}
+	}
+	if len(nutrients) == 0 {
+		return nil
+	}
+	return nutrients
+}
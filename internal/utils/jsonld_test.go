@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H30M": 90 * time.Minute,
+		"PT45M":   45 * time.Minute,
+		"pt20m":   20 * time.Minute,
+		"P1DT2H":  26 * time.Hour,
+		"PT0.5H":  30 * time.Minute,
+		"PT90S":   90 * time.Second,
+	}
+	for input, want := range tests {
+		got, err := ParseISO8601Duration(input)
+		if err != nil || got != want {
+			t.Errorf("ParseISO8601Duration(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"", "P", "PT", "1h", "PTXM"} {
+		if _, err := ParseISO8601Duration(input); err == nil {
+			t.Errorf("ParseISO8601Duration(%q) should fail", input)
+		}
+	}
+
+	if got := FormatISO8601Duration(90 * time.Minute); got != "PT1H30M" {
+		t.Errorf("FormatISO8601Duration(90m) = %q, want PT1H30M", got)
+	}
+}
+
+func TestParseSchemaOrgRecipe(t *testing.T) {
+	doc := `{
+	  "@context": "https://schema.org",
+	  "@graph": [
+	    {"@type": "WebPage", "name": "Ignored"},
+	    {
+	      "@type": ["Recipe"],
+	      "name": "Lemon &amp; Herb Chicken",
+	      "author": [{"@type": "Person", "name": "Ana"}],
+	      "recipeYield": ["4", "4 servings"],
+	      "prepTime": "PT15M",
+	      "cookTime": "PT1H",
+	      "recipeCategory": "Dinner, Main",
+	      "recipeCuisine": ["Greek"],
+	      "recipeIngredient": ["2 tbsp olive oil", "1 lemon (juiced)"],
+	      "recipeInstructions": [
+	        {"@type": "HowToSection", "name": "Marinade", "itemListElement": [
+	          {"@type": "HowToStep", "text": "Mix oil and lemon."}
+	        ]},
+	        {"@type": "HowToStep", "text": "Roast the chicken."},
+	        "Rest for 10 minutes."
+	      ],
+	      "aggregateRating": {"@type": "AggregateRating", "ratingValue": "4.6", "ratingCount": "120"},
+	      "nutrition": {"@type": "NutritionInformation", "calories": "420 kcal"}
+	    }
+	  ]
+	}`
+
+	recipe, err := ParseSchemaOrgRecipe([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseSchemaOrgRecipe: %v", err)
+	}
+
+	if recipe.RecipeName != "Lemon & Herb Chicken" {
+		t.Errorf("name = %q", recipe.RecipeName)
+	}
+	m := recipe.Metadata
+	if m.Author != "Ana" || m.Quantity != "4 servings" {
+		t.Errorf("author/yield = %q/%q", m.Author, m.Quantity)
+	}
+	if m.PrepTime != 15*time.Minute || m.CookTime != time.Hour || m.TotalTime != 75*time.Minute {
+		t.Errorf("times = %v/%v/%v", m.PrepTime, m.CookTime, m.TotalTime)
+	}
+	if !reflect.DeepEqual(m.Categories, []string{"Dinner", "Main"}) || !reflect.DeepEqual(m.Cuisines, []string{"Greek"}) {
+		t.Errorf("categories/cuisines = %q/%q", m.Categories, m.Cuisines)
+	}
+	wantSteps := []string{"Mix oil and lemon.", "Roast the chicken.", "Rest for 10 minutes."}
+	if !reflect.DeepEqual(m.Instructions, wantSteps) {
+		t.Errorf("instructions = %q", m.Instructions)
+	}
+	if len(m.Ingredients) != 2 || m.Ingredients[0].Unit != "tbl" || m.Ingredients[1].Details != "juiced" {
+		t.Errorf("ingredients = %+v", m.Ingredients)
+	}
+	if m.Rating != 5 {
+		t.Errorf("rating = %d, want 5", m.Rating)
+	}
+	if m.Nutrients["calories"] != "420 kcal" {
+		t.Errorf("nutrients = %v", m.Nutrients)
+	}
+}
+
+func TestSchemaOrgRoundTrip(t *testing.T) {
+	recipe := sampleRecipe()
+	recipe.Metadata.Cuisines = []string{"Italian"}
+	// schema.org has no ingredient groups
+	for i := range recipe.Metadata.Ingredients {
+		recipe.Metadata.Ingredients[i].Group = ""
+	}
+
+	content, err := recipe.FormatRecipeJSONLD()
+	if err != nil {
+		t.Fatalf("FormatRecipeJSONLD: %v", err)
+	}
+	if !IsSchemaOrgJSON([]byte(content)) {
+		t.Fatal("exported JSON-LD is not recognised as schema.org")
+	}
+
+	got, err := ParseJSONRecipe(writeTemp(t, "carbonara.jsonld", content), "")
+	if err != nil {
+		t.Fatalf("ParseJSONRecipe: %v", err)
+	}
+	assertRoundTrip(t, recipe, got)
+	if got.Metadata.Rating != recipe.Metadata.Rating {
+		t.Errorf("rating = %d, want %d", got.Metadata.Rating, recipe.Metadata.Rating)
+	}
+	if !reflect.DeepEqual(got.Metadata.Cuisines, recipe.Metadata.Cuisines) {
+		t.Errorf("cuisines = %q", got.Metadata.Cuisines)
+	}
+	if got.Metadata.Notes != recipe.Metadata.Notes {
+		t.Errorf("notes = %q, want %q", got.Metadata.Notes, recipe.Metadata.Notes)
+	}
+	if !reflect.DeepEqual(got.Metadata.StepNotes, recipe.Metadata.StepNotes) {
+		t.Errorf("step notes = %+v, want %+v", got.Metadata.StepNotes, recipe.Metadata.StepNotes)
+	}
+}
+
+func TestToSchemaOrgUnusualSections(t *testing.T) {
+	// Imported JSON may start a section before the first step or list
+	// sections out of order; every step is still exported
+	tests := []struct {
+		name     string
+		sections []InstructionSection
+		want     []string
+	}{
+		{"section at step 0", []InstructionSection{{Step: 0, Title: "Sponge"}},
+			[]string{"HowToSection:Sponge"}},
+		{"out of order", []InstructionSection{{Step: 3, Title: "Filling"}, {Step: 1, Title: "Sponge"}},
+			[]string{"HowToStep:", "HowToStep:", "HowToSection:Sponge"}},
+	}
+	for _, tt := range tests {
+		recipe := RecipeRaw{
+			RecipeName: "Layer Cake",
+			Metadata: RecipeMetadata{
+				Instructions:        []string{"Make the sponge.", "Bake.", "Whip the cream.", "Assemble."},
+				InstructionSections: tt.sections,
+			},
+		}
+
+		var got []string
+		steps := 0
+		for _, howTo := range recipe.ToSchemaOrg().RecipeInstructions {
+			got = append(got, howTo.Type+":"+howTo.Name)
+			if howTo.Type == "HowToSection" {
+				steps += len(howTo.ItemListElement)
+			} else {
+				steps++
+			}
+		}
+		if steps != len(recipe.Metadata.Instructions) {
+			t.Errorf("%s: ToSchemaOrg kept %d of %d steps", tt.name, steps, len(recipe.Metadata.Instructions))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ToSchemaOrg instructions = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
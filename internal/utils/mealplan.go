@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MealSlot is a meal of the day a recipe can be planned for.
+type MealSlot string
+
+const (
+	SlotBreakfast MealSlot = "breakfast"
+	SlotLunch     MealSlot = "lunch"
+	SlotDinner    MealSlot = "dinner"
+)
+
+// MealSlots lists every slot in the order of the day.
+var MealSlots = []MealSlot{SlotBreakfast, SlotLunch, SlotDinner}
+
+// PlanDateLayout is the format plan dates are entered, shown and stored in.
+const PlanDateLayout = "2006-01-02"
+
+// ParseMealSlot parses a slot name, case-insensitively
+func ParseMealSlot(s string) (MealSlot, error) {
+	slot := MealSlot(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range MealSlots {
+		if slot == known {
+			return slot, nil
+		}
+	}
+	return "", fmt.Errorf("unknown meal slot %q (use breakfast, lunch or dinner)", s)
+}
+
+// Label returns the slot name for display ("Dinner")
+func (s MealSlot) Label() string {
+	if s == "" {
+		return ""
+	}
+	return strings.ToUpper(string(s[:1])) + string(s[1:])
+}
+
+// startTime is when the meal is usually eaten, used for calendar events
+func (s MealSlot) startTime() (hour, minute int) {
+	switch s {
+	case SlotBreakfast:
+		return 8, 0
+	case SlotLunch:
+		return 12, 30
+	default:
+		return 19, 0
+	}
+}
+
+// MealPlanEntry is a recipe planned for a day and slot. Date is midnight of
+// the day in local time.
+type MealPlanEntry struct {
+	ID         uint
+	Date       time.Time
+	Slot       MealSlot
+	RecipeID   uint
+	RecipeName string
+}
+
+// slotIndex orders slots through the day, unknown slots last
+func slotIndex(slot MealSlot) int {
+	for i, s := range MealSlots {
+		if s == slot {
+			return i
+		}
+	}
+	return len(MealSlots)
+}
+
+// SortMealPlan orders entries by day, then by slot through the day.
+func SortMealPlan(entries []MealPlanEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Date.Equal(entries[j].Date) {
+			return entries[i].Date.Before(entries[j].Date)
+		}
+		return slotIndex(entries[i].Slot) < slotIndex(entries[j].Slot)
+	})
+}
+
+// StartOfDay returns midnight of t's day
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// WeekStart returns midnight of the Monday of t's week
+func WeekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	return StartOfDay(t).AddDate(0, 0, -offset)
+}
+
+// ParsePlanDate parses "2026-10-20", "today", "tomorrow" or a weekday name
+// ("fri", "friday"), which means its next occurrence from today on.
+func ParsePlanDate(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := StartOfDay(now)
+	switch s {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if s == name || (len(s) >= 3 && strings.HasPrefix(name, s)) {
+			return today.AddDate(0, 0, (int(day)-int(today.Weekday())+7)%7), nil
+		}
+	}
+
+	date, err := time.ParseInLocation(PlanDateLayout, s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD, today, tomorrow or a weekday)", s)
+	}
+	return date, nil
+}
+
+// FormatICS renders plan entries as an iCalendar (RFC 5545) calendar with
+// one event per entry, at the usual time of its meal.
+func FormatICS(entries []MealPlanEntry, now time.Time) string {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(foldICSLine(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//yummy//meal plan//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:Meal plan")
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, entry := range entries {
+		hour, minute := entry.Slot.startTime()
+		start := time.Date(entry.Date.Year(), entry.Date.Month(), entry.Date.Day(), hour, minute, 0, 0, entry.Date.Location())
+
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:yummy-plan-%s-%s-%d@yummy", entry.Date.Format("20060102"), entry.Slot, entry.RecipeID))
+		line("DTSTAMP:" + stamp)
+		// Floating local time: the meal is at 19:00 wherever the calendar is
+		line("DTSTART:" + start.Format("20060102T150405"))
+		line("DURATION:PT1H")
+		line("SUMMARY:" + escapeICSText(entry.Slot.Label()+": "+entry.RecipeName))
+		line("CATEGORIES:" + escapeICSText(entry.Slot.Label()))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeICSText(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+// foldICSLine folds content lines longer than 75 octets, continuing them on
+// lines that start with a space. Multi-byte characters are never split.
+func foldICSLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
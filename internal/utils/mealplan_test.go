@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePlanDate(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 30, 0, 0, time.Local) // a Saturday
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"today", "2026-10-17"},
+		{"tomorrow", "2026-10-18"},
+		{"sat", "2026-10-17"},
+		{"Monday", "2026-10-19"},
+		{"fri", "2026-10-23"},
+		{"2026-12-24", "2026-12-24"},
+	}
+	for _, tt := range tests {
+		got, err := ParsePlanDate(tt.input, now)
+		if err != nil {
+			t.Errorf("ParsePlanDate(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got.Format(PlanDateLayout) != tt.want {
+			t.Errorf("ParsePlanDate(%q) = %s, want %s", tt.input, got.Format(PlanDateLayout), tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "mo", "next week", "2026-13-01"} {
+		if _, err := ParsePlanDate(bad, now); err == nil {
+			t.Errorf("ParsePlanDate(%q) expected an error", bad)
+		}
+	}
+
+	if got := WeekStart(now).Format(PlanDateLayout); got != "2026-10-12" {
+		t.Errorf("WeekStart = %s, want 2026-10-12", got)
+	}
+}
+
+func TestFormatICS(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	entries := []MealPlanEntry{
+		{Date: time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local), Slot: SlotDinner, RecipeID: 3, RecipeName: "Chili, con carne; spicy"},
+		{Date: time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local), Slot: SlotBreakfast, RecipeID: 1, RecipeName: strings.Repeat("Pancakes ", 10)},
+	}
+
+	ics := FormatICS(entries, now)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20261019T190000\r\n",
+		`SUMMARY:Dinner: Chili\, con carne\; spicy` + "\r\n",
+		"UID:yummy-plan-20261019-dinner-3@yummy\r\n",
+		"DTSTART:20261020T080000\r\n",
+		"DTSTAMP:20261017T120000Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("ICS missing %q:\n%s", want, ics)
+		}
+	}
+	if strings.Count(ics, "BEGIN:VEVENT") != 2 {
+		t.Errorf("expected 2 events:\n%s", ics)
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+}
@@ -1,79 +1,78 @@
 package utils
 
-var CorpusMeasures = []string{
-	"tablespoon",
-	"tablespoons",
-	"milliliter",
-	"teaspoon",
-	"teaspoons",
-	"milliliter",
-	"pints",
-	"pound",
-	"pounds",
-	"quart",
-	"quarts",
-	"gram",
-	"grams",
-	"ounce",
-	"ounces",
-	"tsp",
-	"tsps",
-	"tbl",
-	"tbls",
-	"tblsp",
-	"tbs",
-	"tbsp",
-	"tbsps",
-	"clove",
-	"cloves",
-	"can",
-	"cans",
-	"canned",
-	"cup",
-	"cups",
-	"ml",
-	"oz",
-	"c",
-	"g",
-	"oz",
-	"t",
-	"c",
-	"g",
-	"t",
-}
+import "sort"
+
+// CorpusMeasures lists every unit spelling ParseIngredient recognises. It is
+// derived from CorpusMeasuresMap, longest first so "tablespoons" is tried
+// before "tablespoon" and "fl oz" before "oz". The spellings are literal
+// text such as "fl. oz": quote them before using them in a pattern.
+var CorpusMeasures = corpusMeasures()
 
 var CorpusMeasuresMap = map[string]string{
-	"c":           "cup",
-	"can":         "can",
-	"canned":      "can",
-	"cans":        "can",
-	"cup":         "cup",
-	"cups":        "cup",
-	"g":           "gram",
-	"gram":        "gram",
-	"grams":       "gram",
-	"milliliter":  "milliliter",
-	"ml":          "milliliter",
-	"ounce":       "ounce",
-	"ounces":      "ounce",
-	"oz":          "ounce",
-	"pint":        "pint",
-	"pints":       "pint",
-	"pound":       "pound",
-	"pounds":      "pound",
-	"quart":       "quart",
-	"quarts":      "quart",
-	"t":           "tsp",
-	"tablespoon":  "tbl",
-	"tablespoons": "tbl",
-	"tbl":         "tbl",
-	"tbls":        "tbl",
-	"tblsp":       "tbl",
-	"tbs":         "tbl",
-	"tbsp":        "tbl",
-	"tbsps":       "tbl",
-	"teaspoon":    "tsp",
-	"teaspoons":   "tsp",
-	"tsp":         "tsp",
-	"tsps":        "tsp",
+	"c":            "cup",
+	"can":          "can",
+	"canned":       "can",
+	"cans":         "can",
+	"clove":        "clove",
+	"cloves":       "clove",
+	"cup":          "cup",
+	"cups":         "cup",
+	"fl oz":        "fl oz",
+	"fl. oz":       "fl oz",
+	"fluid ounce":  "fl oz",
+	"fluid ounces": "fl oz",
+	"g":            "gram",
+	"gram":         "gram",
+	"grams":        "gram",
+	"kg":           "kilogram",
+	"kilogram":     "kilogram",
+	"kilograms":    "kilogram",
+	"l":            "liter",
+	"lb":           "pound",
+	"lbs":          "pound",
+	"liter":        "liter",
+	"liters":       "liter",
+	"litre":        "liter",
+	"litres":       "liter",
+	"milliliter":   "milliliter",
+	"milliliters":  "milliliter",
+	"millilitre":   "milliliter",
+	"millilitres":  "milliliter",
+	"ml":           "milliliter",
+	"ounce":        "ounce",
+	"ounces":       "ounce",
+	"oz":           "ounce",
+	"pint":         "pint",
+	"pints":        "pint",
+	"pound":        "pound",
+	"pounds":       "pound",
+	"quart":        "quart",
+	"quarts":       "quart",
+	"t":            "tsp",
+	"tablespoon":   "tbl",
+	"tablespoons":  "tbl",
+	"tbl":          "tbl",
+	"tbls":         "tbl",
+	"tblsp":        "tbl",
+	"tbs":          "tbl",
+	"tbsp":         "tbl",
+	"tbsps":        "tbl",
+	"teaspoon":     "tsp",
+	"teaspoons":    "tsp",
+	"tsp":          "tsp",
+	"tsps":         "tsp",
+}
+
+func corpusMeasures() []string {
+	measures := make([]string, 0, len(CorpusMeasuresMap))
+	for measure := range CorpusMeasuresMap {
+		measures = append(measures, measure)
+	}
+	sort.Slice(measures, func(i, j int) bool {
+		if len(measures[i]) != len(measures[j]) {
+			return len(measures[i]) > len(measures[j])
+		}
+		return measures[i] < measures[j]
+	})
+	return measures
 }
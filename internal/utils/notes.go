@@ -0,0 +1,41 @@
+package utils
+
+// StepNote is a personal annotation on an instruction, such as "use less salt
+// next time". Step is 1-based, like InstructionSection.
+type StepNote struct {
+	Step int    `json:"step"`
+	Note string `json:"note"`
+}
+
+// stepNotePrefix marks annotation lines in the edit form instructions field
+const stepNotePrefix = ">"
+
+// StepNoteAt returns the note on the 0-based step index, "" if it has none.
+func (m RecipeMetadata) StepNoteAt(index int) string {
+	for _, note := range m.StepNotes {
+		if note.Step-1 == index {
+			return note.Note
+		}
+	}
+	return ""
+}
+
+// StepNoteList returns the note of every instruction, "" for steps without one.
+func (m RecipeMetadata) StepNoteList() []string {
+	notes := make([]string, len(m.Instructions))
+	for i := range m.Instructions {
+		notes[i] = m.StepNoteAt(i)
+	}
+	return notes
+}
+
+// StepNotesFromList is the inverse of StepNoteList.
+func StepNotesFromList(notes []string) []StepNote {
+	var stepNotes []StepNote
+	for i, note := range notes {
+		if note != "" {
+			stepNotes = append(stepNotes, StepNote{Step: i + 1, Note: note})
+		}
+	}
+	return stepNotes
+}
@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/GarroshIcecream/yummy/internal/nutrition"
+	"github.com/GarroshIcecream/yummy/internal/units"
+)
+
+// nutrientLabels lists the schema.org NutritionInformation properties in
+// display order, together with their human-readable labels.
+var nutrientLabels = []struct{ key, label string }{
+	{"servingSize", "Serving Size"},
+	{"calories", "Calories"},
+	{"fatContent", "Fat"},
+	{"saturatedFatContent", "Saturated Fat"},
+	{"unsaturatedFatContent", "Unsaturated Fat"},
+	{"transFatContent", "Trans Fat"},
+	{"cholesterolContent", "Cholesterol"},
+	{"sodiumContent", "Sodium"},
+	{"carbohydrateContent", "Carbohydrates"},
+	{"fiberContent", "Fiber"},
+	{"sugarContent", "Sugar"},
+	{"proteinContent", "Protein"},
+}
+
+// NutrientLabel returns the display label for a schema.org nutrient key
+// (e.g. "proteinContent" → "Protein"). Unknown keys are returned unchanged.
+func NutrientLabel(key string) string {
+	for _, n := range nutrientLabels {
+		if n.key == key {
+			return n.label
+		}
+	}
+	return key
+}
+
+// NutrientKey is the inverse of NutrientLabel.
+func NutrientKey(label string) string {
+	for _, n := range nutrientLabels {
+		if strings.EqualFold(n.label, label) {
+			return n.key
+		}
+	}
+	return label
+}
+
+// SortedNutrientKeys returns the keys of nutrients with the known schema.org
+// properties first (in display order), followed by any others alphabetically.
+func SortedNutrientKeys(nutrients map[string]string) []string {
+	keys := make([]string, 0, len(nutrients))
+	for _, n := range nutrientLabels {
+		if _, ok := nutrients[n.key]; ok {
+			keys = append(keys, n.key)
+		}
+	}
+
+	var others []string
+	for key := range nutrients {
+		if NutrientLabel(key) == key {
+			others = append(others, key)
+		}
+	}
+	sort.Strings(others)
+	return append(keys, others...)
+}
+
+// DietName turns a schema.org RestrictedDiet (e.g. "https://schema.org/GlutenFreeDiet"
+// or "GlutenFreeDiet") into a display name such as "Gluten Free".
+func DietName(schemaType string) string {
+	name := strings.TrimSpace(schemaType)
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimSuffix(name, "Diet")
+
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// DietSchemaURL is the inverse of DietName ("Gluten Free" → "https://schema.org/GlutenFreeDiet").
+func DietSchemaURL(name string) string {
+	compact := strings.Join(strings.Fields(name), "")
+	if !strings.HasSuffix(compact, "Diet") {
+		compact += "Diet"
+	}
+	return schemaOrgContext + "/" + compact
+}
+
+// gramsPerClove and gramsPerCan weigh the non-convertible units ParseIngredient knows
+const (
+	gramsPerClove = 4
+	gramsPerCan   = 400
+)
+
+// NutritionEstimate is the nutrition of a recipe computed from its
+// ingredients with the offline nutrient table.
+type NutritionEstimate struct {
+	Total    nutrition.Facts // whole recipe
+	Servings float64         // servings Total is for, 0 when the yield is unknown
+	Counted  int             // ingredients included in Total
+	Missing  []string        // ingredients with an amount that could not be counted
+}
+
+// Known reports whether any ingredient could be counted
+func (e NutritionEstimate) Known() bool {
+	return e.Counted > 0
+}
+
+// PerServing returns the facts for one serving; false when the number of
+// servings is unknown.
+func (e NutritionEstimate) PerServing() (nutrition.Facts, bool) {
+	if e.Servings <= 0 {
+		return nutrition.Facts{}, false
+	}
+	return e.Total.Scale(1 / e.Servings), true
+}
+
+// Scaled returns the estimate for the recipe scaled by factor; the facts per
+// serving stay the same.
+func (e NutritionEstimate) Scaled(factor float64) NutritionEstimate {
+	e.Total = e.Total.Scale(factor)
+	e.Servings *= factor
+	return e
+}
+
+// EstimateNutrition adds up the nutrients of the ingredients that have an
+// amount and are in the nutrient table. Amounts are weighed through their
+// unit, the ingredient's density for volumes, or its usual piece weight when
+// counted ("2 eggs"); ranges count as their midpoint. Ingredients without an
+// amount ("salt to taste") are left out. yield gives the number of servings.
+func EstimateNutrition(ingredients []Ingredient, yield string) NutritionEstimate {
+	var estimate NutritionEstimate
+	if servings, ok := ParseServings(yield); ok {
+		estimate.Servings = servings.Min
+	}
+
+	for _, ing := range ingredients {
+		q, ok := ParseQuantity(ing.Amount)
+		if !ok {
+			continue
+		}
+
+		name := ing.Name
+		food, found := nutrition.Lookup(name)
+		if !found && ing.BaseName != "" {
+			name = ing.BaseName
+			food, found = nutrition.Lookup(name)
+		}
+		grams, weighed := 0.0, false
+		if found {
+			grams, weighed = ingredientGrams((q.Min+q.Max)/2, ing.Unit, name, food)
+		}
+		if !weighed {
+			estimate.Missing = append(estimate.Missing, ing.Name)
+			continue
+		}
+
+		estimate.Total = estimate.Total.Add(food.Grams(grams))
+		estimate.Counted++
+	}
+	return estimate
+}
+
+// ingredientGrams weighs an amount of food given in unit
+func ingredientGrams(amount float64, unit string, name string, food nutrition.Food) (float64, bool) {
+	unitName := strings.ToLower(strings.TrimSpace(unit))
+	if normalized, ok := CorpusMeasuresMap[unitName]; ok {
+		unitName = normalized
+	}
+
+	switch unitName {
+	case "":
+		return amount * food.PieceGrams, food.PieceGrams > 0
+	case "clove":
+		return amount * gramsPerClove, true
+	case "can":
+		return amount * gramsPerCan, true
+	}
+
+	from, ok := units.Lookup(unitName)
+	if !ok {
+		return 0, false
+	}
+	density := food.Density
+	if d, ok := units.LookupDensity(name); ok {
+		density = d.Density
+	}
+	gram, _ := units.Lookup("gram")
+	grams, err := units.ConvertWithDensity(amount, from, gram, density)
+	if err != nil {
+		return 0, false
+	}
+	return grams, true
+}
+
+// formatNutritionEstimate renders the estimate as a markdown section, per
+// serving when the number of servings is known and for the whole recipe.
+func formatNutritionEstimate(e NutritionEstimate) string {
+	var s strings.Builder
+	s.WriteString("### 🧮 Estimated Nutrition\n\n")
+
+	perServing, hasServings := e.PerServing()
+	rows := []struct {
+		label string
+		value func(nutrition.Facts) string
+	}{
+		{"Calories", func(f nutrition.Facts) string { return fmt.Sprintf("%.0f kcal", f.Calories) }},
+		{"Fat", func(f nutrition.Facts) string { return formatGrams(f.Fat) }},
+		{"Saturated Fat", func(f nutrition.Facts) string { return formatGrams(f.SaturatedFat) }},
+		{"Carbohydrates", func(f nutrition.Facts) string { return formatGrams(f.Carbohydrates) }},
+		{"Sugar", func(f nutrition.Facts) string { return formatGrams(f.Sugar) }},
+		{"Fiber", func(f nutrition.Facts) string { return formatGrams(f.Fiber) }},
+		{"Protein", func(f nutrition.Facts) string { return formatGrams(f.Protein) }},
+		{"Sodium", func(f nutrition.Facts) string { return fmt.Sprintf("%.0f mg", f.Sodium) }},
+	}
+	for _, row := range rows {
+		if hasServings {
+			s.WriteString(fmt.Sprintf("• %s: **%s** per serving · %s in total\n\n", row.label, row.value(perServing), row.value(e.Total)))
+		} else {
+			s.WriteString(fmt.Sprintf("• %s: **%s** in total\n\n", row.label, row.value(e.Total)))
+		}
+	}
+
+	note := fmt.Sprintf("Estimated from %d of %d ingredients", e.Counted, e.Counted+len(e.Missing))
+	if hasServings {
+		if e.Servings == 1 {
+			note += " for 1 serving"
+		} else {
+			note += fmt.Sprintf(" for %s servings", FormatDecimal(e.Servings))
+		}
+	}
+	if len(e.Missing) > 0 {
+		note += "; not counted: " + strings.Join(e.Missing, ", ")
+	}
+	s.WriteString("*" + note + "*\n\n")
+	return s.String()
+}
+
+// formatGrams shows a weight in grams, with a decimal below 10 g
+func formatGrams(grams float64) string {
+	if grams < 10 {
+		return fmt.Sprintf("%.1f g", grams)
+	}
+	return fmt.Sprintf("%.0f g", grams)
+}
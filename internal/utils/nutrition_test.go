@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateNutrition(t *testing.T) {
+	ingredients := []Ingredient{
+		{Amount: "1 1/2", Unit: "cup", Name: "all-purpose flour"}, // 1.5 × 125 g
+		{Amount: "2", Name: "eggs"},                               // 2 × 50 g
+		{Amount: "250", Unit: "ml", Name: "milk"},
+		{Amount: "1", Unit: "pinch", Name: "saffron"},
+		{Name: "salt", Details: "to taste"},
+	}
+
+	e := EstimateNutrition(ingredients, "Serves 4")
+	if e.Counted != 3 || len(e.Missing) != 1 || e.Missing[0] != "saffron" {
+		t.Fatalf("Counted = %d, Missing = %v", e.Counted, e.Missing)
+	}
+
+	// flour 188 g → 684 kcal, eggs → 143 kcal, milk 257.5 g → 157 kcal
+	if math.Abs(e.Total.Calories-984) > 10 {
+		t.Errorf("Total.Calories = %.0f, want ~984", e.Total.Calories)
+	}
+	perServing, ok := e.PerServing()
+	if !ok || math.Abs(perServing.Calories-e.Total.Calories/4) > 0.01 {
+		t.Errorf("PerServing() = %.0f kcal, %v", perServing.Calories, ok)
+	}
+
+	doubled := e.Scaled(2)
+	if s, _ := doubled.PerServing(); math.Abs(s.Calories-perServing.Calories) > 0.01 {
+		t.Errorf("scaling changed calories per serving: %.0f", s.Calories)
+	}
+
+	if _, ok := EstimateNutrition(ingredients, "").PerServing(); ok {
+		t.Error("expected no per-serving facts without a yield")
+	}
+}
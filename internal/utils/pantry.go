@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PantryDateLayout is the format expiry dates are entered and shown in.
+const PantryDateLayout = "2006-01-02"
+
+// PantryItem is an ingredient on hand. ExpiresAt is nil for items that do
+// not go off.
+type PantryItem struct {
+	ID        uint
+	Name      string
+	Amount    string
+	Unit      string
+	ExpiresAt *time.Time
+}
+
+// PantryMatch is a recipe ranked by how much of its ingredient list the
+// pantry covers.
+type PantryMatch struct {
+	RecipeID   uint
+	RecipeName string
+	Have       []string
+	Missing    []string
+	Coverage   float64 // share of the recipe's ingredients on hand, 0 to 1
+}
+
+// pantryStaples are assumed to always be on hand
+var pantryStaples = map[string]bool{"water": true, "ice": true, "tap water": true}
+
+var pantryExpiryRe = regexp.MustCompile(`(?i)^(.*?),?\s*(?:expires?\s+|exp\s+)?(\d{4}-\d{2}-\d{2})$`)
+
+// ParsePantryEntry parses a pantry line such as "2 kg potatoes", "eggs" or
+// "500 ml milk, expires 2026-10-30".
+func ParsePantryEntry(input string) (PantryItem, error) {
+	input = strings.TrimSpace(input)
+	var expiresAt *time.Time
+	if m := pantryExpiryRe.FindStringSubmatch(input); m != nil {
+		date, err := time.ParseInLocation(PantryDateLayout, m[2], time.Local)
+		if err != nil {
+			return PantryItem{}, fmt.Errorf("invalid expiry date %q: %w", m[2], err)
+		}
+		expiresAt = &date
+		input = strings.TrimSpace(m[1])
+	}
+	if input == "" {
+		return PantryItem{}, fmt.Errorf("missing ingredient name")
+	}
+
+	ing, err := ParseIngredient(input)
+	if err != nil || ing.Name == "" {
+		return PantryItem{}, fmt.Errorf("could not parse pantry item %q", input)
+	}
+	return PantryItem{Name: ing.Name, Amount: ing.Amount, Unit: ing.Unit, ExpiresAt: expiresAt}, nil
+}
+
+// Expired reports whether the item's expiry date lies before the day of now
+func (p PantryItem) Expired(now time.Time) bool {
+	if p.ExpiresAt == nil {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return p.ExpiresAt.Before(today)
+}
+
+// ExpiresWithin reports whether the item expires within the given number of
+// days from now (expired items included).
+func (p PantryItem) ExpiresWithin(now time.Time, days int) bool {
+	if p.ExpiresAt == nil {
+		return false
+	}
+	return p.ExpiresAt.Before(now.AddDate(0, 0, days))
+}
+
+// pantryKey normalises an ingredient name for matching: lower case, single
+// spaces and the last word singular ("Red Onions" → "red onion").
+func pantryKey(name string) string {
+	words := strings.Fields(strings.ToLower(name))
+	if len(words) == 0 {
+		return ""
+	}
+	last := words[len(words)-1]
+	switch {
+	case strings.HasSuffix(last, "ies") && len(last) > 4:
+		last = strings.TrimSuffix(last, "ies") + "y"
+	case strings.HasSuffix(last, "oes"), strings.HasSuffix(last, "ches"), strings.HasSuffix(last, "shes"):
+		last = strings.TrimSuffix(last, "es")
+	case strings.HasSuffix(last, "s") && !strings.HasSuffix(last, "ss") && len(last) > 3:
+		last = strings.TrimSuffix(last, "s")
+	}
+	words[len(words)-1] = last
+	return strings.Join(words, " ")
+}
+
+// containsWords reports whether needle appears in haystack as whole words
+func containsWords(haystack, needle string) bool {
+	return strings.Contains(" "+haystack+" ", " "+needle+" ")
+}
+
+// MatchPantry ranks recipes by how much of their ingredient list (by
+// BaseName, falling back to the name) the pantry covers. A pantry item
+// covers an ingredient it names in whole words, so "bread flour" covers
+// "flour", but not one more specific than itself: "milk" does not cover
+// "coconut milk". Expired items do not count; recipes without ingredients
+// are left out.
+func MatchPantry(pantry []PantryItem, recipes []RecipeRaw, now time.Time) []PantryMatch {
+	var onHand []string
+	for _, item := range pantry {
+		if item.Expired(now) {
+			continue
+		}
+		if key := pantryKey(item.Name); key != "" {
+			onHand = append(onHand, key)
+		}
+	}
+
+	covered := func(key string) bool {
+		if pantryStaples[key] {
+			return true
+		}
+		for _, have := range onHand {
+			if containsWords(have, key) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var matches []PantryMatch
+	for _, recipe := range recipes {
+		match := PantryMatch{RecipeID: recipe.RecipeID, RecipeName: recipe.RecipeName}
+		seen := make(map[string]bool)
+		for _, ing := range recipe.Metadata.Ingredients {
+			name := strings.TrimSpace(ing.BaseName)
+			if name == "" {
+				name = strings.TrimSpace(ing.Name)
+			}
+			key := pantryKey(name)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			if covered(key) {
+				match.Have = append(match.Have, name)
+			} else {
+				match.Missing = append(match.Missing, name)
+			}
+		}
+
+		total := len(match.Have) + len(match.Missing)
+		if total == 0 {
+			continue
+		}
+		match.Coverage = float64(len(match.Have)) / float64(total)
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Coverage != matches[j].Coverage {
+			return matches[i].Coverage > matches[j].Coverage
+		}
+		if len(matches[i].Missing) != len(matches[j].Missing) {
+			return len(matches[i].Missing) < len(matches[j].Missing)
+		}
+		return strings.ToLower(matches[i].RecipeName) < strings.ToLower(matches[j].RecipeName)
+	})
+	return matches
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePantryEntry(t *testing.T) {
+	tests := []struct {
+		input   string
+		name    string
+		amount  string
+		unit    string
+		expires string
+	}{
+		{input: "2 kg potatoes", name: "potatoes", amount: "2", unit: "kilogram"},
+		{input: "eggs", name: "eggs"},
+		{input: "500 ml milk, expires 2026-10-30", name: "milk", amount: "500", unit: "milliliter", expires: "2026-10-30"},
+		{input: "butter 2026-11-02", name: "butter", expires: "2026-11-02"},
+	}
+
+	for _, tt := range tests {
+		item, err := ParsePantryEntry(tt.input)
+		if err != nil {
+			t.Errorf("ParsePantryEntry(%q) error: %v", tt.input, err)
+			continue
+		}
+		if item.Name != tt.name || item.Amount != tt.amount || item.Unit != tt.unit {
+			t.Errorf("ParsePantryEntry(%q) = %q %q %q, want %q %q %q",
+				tt.input, item.Amount, item.Unit, item.Name, tt.amount, tt.unit, tt.name)
+		}
+		var expires string
+		if item.ExpiresAt != nil {
+			expires = item.ExpiresAt.Format(PantryDateLayout)
+		}
+		if expires != tt.expires {
+			t.Errorf("ParsePantryEntry(%q) expires %q, want %q", tt.input, expires, tt.expires)
+		}
+	}
+
+	if _, err := ParsePantryEntry(", 2026-10-30"); err == nil {
+		t.Error("expected an error for an entry without a name")
+	}
+}
+
+func TestMatchPantry(t *testing.T) {
+	now := time.Date(2026, 10, 17, 18, 0, 0, 0, time.Local)
+	yesterday := now.AddDate(0, 0, -1)
+	pantry := []PantryItem{
+		{Name: "flour"},
+		{Name: "Eggs"},
+		{Name: "milk", ExpiresAt: &yesterday},
+		{Name: "tomatoes"},
+	}
+	recipes := []RecipeRaw{
+		{RecipeID: 1, RecipeName: "Pancakes", Metadata: RecipeMetadata{Ingredients: []Ingredient{
+			{Name: "all-purpose flour", BaseName: "flour"},
+			{Name: "egg"},
+			{Name: "milk"},
+		}}},
+		{RecipeID: 2, RecipeName: "Bread", Metadata: RecipeMetadata{Ingredients: []Ingredient{
+			{Name: "bread flour"},
+			{Name: "water"},
+		}}},
+		{RecipeID: 3, RecipeName: "Salad", Metadata: RecipeMetadata{Ingredients: []Ingredient{
+			{Name: "tomato"},
+			{Name: "cucumber"},
+			{Name: "olive oil"},
+			{Name: "feta"},
+		}}},
+		{RecipeID: 4, RecipeName: "Empty"},
+	}
+
+	matches := MatchPantry(pantry, recipes, now)
+	if len(matches) != 3 {
+		t.Fatalf("got %d matches, want 3: %+v", len(matches), matches)
+	}
+
+	// "flour" is not "bread flour", so the bread has only its water
+	wantOrder := []string{"Pancakes", "Bread", "Salad"}
+	wantCoverage := []float64{2.0 / 3, 0.5, 0.25}
+	for i, match := range matches {
+		if match.RecipeName != wantOrder[i] {
+			t.Errorf("match %d = %s, want %s", i, match.RecipeName, wantOrder[i])
+		}
+		if match.Coverage != wantCoverage[i] {
+			t.Errorf("%s coverage = %v, want %v", match.RecipeName, match.Coverage, wantCoverage[i])
+		}
+	}
+	if missing := matches[0].Missing; len(missing) != 1 || missing[0] != "milk" {
+		t.Errorf("Pancakes missing = %v, want [milk] (expired)", missing)
+	}
+}
+
+func TestMatchPantrySpecificity(t *testing.T) {
+	now := time.Date(2026, 10, 17, 18, 0, 0, 0, time.Local)
+	tests := []struct {
+		have       string
+		ingredient string
+		covered    bool
+	}{
+		{"bread flour", "flour", true},
+		{"whole milk", "milk", true},
+		{"flour", "bread flour", false},
+		{"milk", "coconut milk", false},
+		{"sugar", "powdered sugar", false},
+		{"pepper", "red bell pepper", false},
+	}
+	for _, tt := range tests {
+		recipes := []RecipeRaw{{RecipeID: 1, RecipeName: "Test", Metadata: RecipeMetadata{Ingredients: []Ingredient{{Name: tt.ingredient}}}}}
+		matches := MatchPantry([]PantryItem{{Name: tt.have}}, recipes, now)
+		if got := len(matches) == 1 && matches[0].Coverage == 1; got != tt.covered {
+			t.Errorf("pantry %q covers %q = %v, want %v", tt.have, tt.ingredient, got, tt.covered)
+		}
+	}
+}
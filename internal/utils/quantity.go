@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Quantity is a parsed ingredient amount or yield. Single values have
+// Min == Max; ranges such as "2-3" keep both ends.
+type Quantity struct {
+	Min float64
+	Max float64
+}
+
+// unicodeFractions maps the vulgar fraction characters found on recipe sites
+// to their ASCII form.
+var unicodeFractions = map[rune]string{
+	'½': "1/2", '⅓': "1/3", '⅔': "2/3", '¼': "1/4", '¾': "3/4",
+	'⅕': "1/5", '⅖': "2/5", '⅗': "3/5", '⅘': "4/5", '⅙': "1/6", '⅚': "5/6",
+	'⅛': "1/8", '⅜': "3/8", '⅝': "5/8", '⅞': "7/8",
+}
+
+const unicodeFractionChars = "½⅓⅔¼¾⅕⅖⅗⅘⅙⅚⅛⅜⅝⅞"
+
+// quantityPattern matches a single number: a mixed number ("1 1/2"), a
+// unicode fraction ("1½", "½"), a fraction ("1/2") or a decimal ("1.5", "1,5").
+const quantityPattern = `(?:\d+\s+\d+/\d+|\d*[` + unicodeFractionChars + `]|\d+(?:[.,]\d+)?(?:/\d+)?)`
+
+// quantityRangePattern matches a number or a range of two numbers ("2-3", "2 to 3").
+const quantityRangePattern = quantityPattern + `(?:\s*(?:-|–|—|to)\s*` + quantityPattern + `)?`
+
+var (
+	quantityRe      = regexp.MustCompile(`^(?i)` + quantityRangePattern + `$`)
+	quantityTokenRe = regexp.MustCompile(`(?i)` + quantityRangePattern)
+	rangeSplitRe    = regexp.MustCompile(`(?i)\s*(?:-|–|—|\bto\b)\s*`)
+)
+
+// ParseQuantity parses amounts such as "2", "1.5", "1,5", "1/2", "1 1/2",
+// "½", "1½", "2-3" and "2 to 3".
+func ParseQuantity(s string) (Quantity, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || !quantityRe.MatchString(s) {
+		return Quantity{}, false
+	}
+
+	parts := rangeSplitRe.Split(s, 2)
+	min, ok := parseNumber(parts[0])
+	if !ok {
+		return Quantity{}, false
+	}
+	max := min
+	if len(parts) == 2 {
+		if max, ok = parseNumber(parts[1]); !ok {
+			return Quantity{}, false
+		}
+	}
+	if max < min {
+		min, max = max, min
+	}
+	return Quantity{Min: min, Max: max}, true
+}
+
+// parseNumber parses a single value matched by quantityPattern
+func parseNumber(s string) (float64, bool) {
+	for r, ascii := range unicodeFractions {
+		s = strings.ReplaceAll(s, string(r), " "+ascii)
+	}
+
+	total := 0.0
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, false
+	}
+	for _, field := range fields {
+		if num, den, ok := strings.Cut(field, "/"); ok {
+			n, err1 := strconv.ParseFloat(num, 64)
+			d, err2 := strconv.ParseFloat(den, 64)
+			if err1 != nil || err2 != nil || d == 0 {
+				return 0, false
+			}
+			total += n / d
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.Replace(field, ",", ".", 1), 64)
+		if err != nil {
+			return 0, false
+		}
+		total += v
+	}
+	return total, true
+}
+
+// IsRange reports whether the quantity spans two different values
+func (q Quantity) IsRange() bool {
+	return q.Max != q.Min
+}
+
+// Scale multiplies both ends of the quantity by factor
+func (q Quantity) Scale(factor float64) Quantity {
+	return Quantity{Min: q.Min * factor, Max: q.Max * factor}
+}
+
+// String formats the quantity with kitchen-friendly rounding (see FormatAmount)
+func (q Quantity) String() string {
+	return q.Format(FormatAmount)
+}
+
+// Format formats both ends of the quantity with format, e.g. FormatDecimal
+// for metric units.
+func (q Quantity) Format(format func(float64) string) string {
+	min, max := format(q.Min), format(q.Max)
+	if min == max {
+		return min
+	}
+	return min + "-" + max
+}
+
+// FormatAmount rounds a value the way a cook would measure it: small amounts
+// to the nearest eighth or third ("1 1/2", "2/3"), amounts from 10 to the
+// nearest whole number and amounts from 100 to the nearest 5.
+func FormatAmount(v float64) string {
+	switch {
+	case v <= 0:
+		return "0"
+	case v >= 100:
+		return strconv.FormatFloat(math.Round(v/5)*5, 'f', 0, 64)
+	case v >= 10:
+		return strconv.FormatFloat(math.Round(v), 'f', 0, 64)
+	}
+
+	whole := math.Floor(v)
+	frac := v - whole
+	eighths := math.Round(frac * 8)
+	thirds := math.Round(frac * 3)
+
+	num, den := eighths, 8.0
+	if math.Abs(frac-thirds/3) < math.Abs(frac-eighths/8) {
+		num, den = thirds, 3
+	}
+	if num == den {
+		whole, num = whole+1, 0
+	}
+	if whole == 0 && num == 0 {
+		// Never round a real amount away entirely
+		num, den = 1, 8
+	}
+
+	g := gcd(int(num), int(den))
+	fraction := fmt.Sprintf("%d/%d", int(num)/g, int(den)/g)
+	switch {
+	case num == 0:
+		return strconv.Itoa(int(whole))
+	case whole == 0:
+		return fraction
+	default:
+		return fmt.Sprintf("%d %s", int(whole), fraction)
+	}
+}
+
+// FormatDecimal formats metric amounts, where fractions look out of place:
+// below 10 with up to two decimals ("1.25"), otherwise like FormatAmount.
+func FormatDecimal(v float64) string {
+	if v >= 10 {
+		return FormatAmount(v)
+	}
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// ParseServings returns the first number in a free-form yield such as
+// "4 servings", "Serves 4-6" or "12 cookies".
+func ParseServings(yield string) (Quantity, bool) {
+	match := quantityTokenRe.FindString(yield)
+	if match == "" {
+		return Quantity{}, false
+	}
+	return ParseQuantity(match)
+}
+
+// ScaleServings rewrites the first number in a yield string, keeping the
+// surrounding text ("Serves 4" → "Serves 8").
+func ScaleServings(yield string, factor float64) string {
+	loc := quantityTokenRe.FindStringIndex(yield)
+	if loc == nil {
+		return yield
+	}
+	q, ok := ParseQuantity(yield[loc[0]:loc[1]])
+	if !ok {
+		return yield
+	}
+	return yield[:loc[0]] + q.Scale(factor).String() + yield[loc[1]:]
+}
+
+// ScaleAmount scales an ingredient amount string; amounts that cannot be
+// parsed ("a pinch") are returned unchanged.
+func ScaleAmount(amount string, factor float64) string {
+	q, ok := ParseQuantity(amount)
+	if !ok {
+		return amount
+	}
+	return q.Scale(factor).String()
+}
+
+// Scaled returns a copy of the recipe with every ingredient amount and the
+// yield multiplied by factor. A factor of 1 returns an unmodified copy.
+func (r *RecipeRaw) Scaled(factor float64) *RecipeRaw {
+	scaled := *r
+	scaled.Metadata.Ingredients = make([]Ingredient, len(r.Metadata.Ingredients))
+	copy(scaled.Metadata.Ingredients, r.Metadata.Ingredients)
+	if factor == 1 || factor <= 0 {
+		return &scaled
+	}
+
+	for i := range scaled.Metadata.Ingredients {
+		scaled.Metadata.Ingredients[i].Amount = ScaleAmount(scaled.Metadata.Ingredients[i].Amount, factor)
+	}
+	scaled.Metadata.Quantity = ScaleServings(r.Metadata.Quantity, factor)
+	scaled.Metadata.Nutrition = r.Metadata.Nutrition.Scaled(factor)
+	return &scaled
+}
+
+// ScaledToServings returns a copy of the recipe scaled to the given number
+// of servings. It fails when the recipe's own yield is unknown.
+func (r *RecipeRaw) ScaledToServings(servings float64) (*RecipeRaw, error) {
+	if servings <= 0 {
+		return nil, fmt.Errorf("servings must be positive, got %v", servings)
+	}
+	base, ok := ParseServings(r.Metadata.Quantity)
+	if !ok || base.Min <= 0 {
+		return nil, fmt.Errorf("recipe %q has no known number of servings", r.RecipeName)
+	}
+	return r.Scaled(servings / base.Min), nil
+}
@@ -0,0 +1,89 @@
+package utils
+
+import "testing"
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		input    string
+		min, max float64
+	}{
+		{"2", 2, 2},
+		{"1.5", 1.5, 1.5},
+		{"1,5", 1.5, 1.5},
+		{"1/2", 0.5, 0.5},
+		{"1 1/2", 1.5, 1.5},
+		{"½", 0.5, 0.5},
+		{"1½", 1.5, 1.5},
+		{"2-3", 2, 3},
+		{"2 to 3", 2, 3},
+		{"1/2 - 3/4", 0.5, 0.75},
+	}
+	for _, tt := range tests {
+		q, ok := ParseQuantity(tt.input)
+		if !ok || q.Min != tt.min || q.Max != tt.max {
+			t.Errorf("ParseQuantity(%q) = %+v, %v; want {%v %v}", tt.input, q, ok, tt.min, tt.max)
+		}
+	}
+
+	for _, input := range []string{"", "a pinch", "1/0", "some"} {
+		if q, ok := ParseQuantity(input); ok {
+			t.Errorf("ParseQuantity(%q) = %+v, want failure", input, q)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0.5, "1/2"},
+		{0.33, "1/3"},
+		{0.7, "2/3"},
+		{1.5, "1 1/2"},
+		{2.99, "3"},
+		{0.01, "1/8"},
+		{12.4, "12"},
+		{333, "335"},
+	}
+	for _, tt := range tests {
+		if got := FormatAmount(tt.value); got != tt.want {
+			t.Errorf("FormatAmount(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestScaledToServings(t *testing.T) {
+	recipe := &RecipeRaw{
+		RecipeName: "Pancakes",
+		Metadata: RecipeMetadata{
+			Quantity: "Serves 4",
+			Ingredients: []Ingredient{
+				{Amount: "1 1/2", Unit: "cup", Name: "flour"},
+				{Amount: "2-3", Name: "eggs"},
+				{Amount: "", Name: "salt"},
+			},
+		},
+	}
+
+	scaled, err := recipe.ScaledToServings(6)
+	if err != nil {
+		t.Fatalf("ScaledToServings: %v", err)
+	}
+	if scaled.Metadata.Quantity != "Serves 6" {
+		t.Errorf("Quantity = %q", scaled.Metadata.Quantity)
+	}
+	want := []string{"2 1/4", "3-4 1/2", ""}
+	for i, ing := range scaled.Metadata.Ingredients {
+		if ing.Amount != want[i] {
+			t.Errorf("ingredient %d amount = %q, want %q", i, ing.Amount, want[i])
+		}
+	}
+	if recipe.Metadata.Ingredients[0].Amount != "1 1/2" {
+		t.Errorf("original recipe was modified: %q", recipe.Metadata.Ingredients[0].Amount)
+	}
+
+	if _, err := (&RecipeRaw{}).ScaledToServings(2); err == nil {
+		t.Error("expected an error for a recipe without servings")
+	}
+}
@@ -0,0 +1,402 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Query is a parsed recipe filter query: one of QueryAnd, QueryOr,
+// QueryNot, QueryText, QueryFavourite, QueryCompare or QueryCollection. Its
+// String form is a compact s-expression, handy in logs and tests.
+//
+// The grammar, loosest binding first:
+//
+//	query   = term { term }               every term must match
+//	term    = unary { ("OR" | "|") unary } any alternative may match
+//	unary   = "-" unary                    negation
+//	        | "(" query ")"
+//	        | "@fav"
+//	        | "@time" op duration          e.g. @time<30m, @time<=1h30m
+//	        | "@rating" op number          e.g. @rating>=4
+//	        | "@collection" name           a collection or saved search
+//	        | "@" field unary              e.g. @category dinner
+//	        | '"' words '"'                a phrase
+//	        | word
+//	op      = "<" | "<=" | ">" | ">=" | "="
+//
+// @time and @rating never match a recipe without a time or rating, so
+// @time<30m leaves out recipes whose time is not known.
+//
+// A field command applies to the term after it, so @ingredients (chicken OR
+// beef) matches either in the ingredients. A missing closing parenthesis or
+// quote is tolerated, since the query is parsed as it is typed.
+type Query interface {
+	String() string
+}
+
+// QueryAnd matches recipes matching all of its terms
+type QueryAnd []Query
+
+// QueryOr matches recipes matching any of its terms
+type QueryOr []Query
+
+// QueryNot matches recipes not matching its term
+type QueryNot struct {
+	Query Query
+}
+
+// QueryText matches recipes whose text contains every word of Text, by
+// prefix, or the words in order when Phrase is set. Field is the search
+// field to match in, or empty for any.
+type QueryText struct {
+	Field  string
+	Text   string
+	Phrase bool
+}
+
+// QueryFavourite matches favourite recipes
+type QueryFavourite struct{}
+
+// QueryCompare compares a number of the recipe with Value: its total time
+// in minutes ("time") or its rating ("rating"). Recipes without a time or
+// rating never match.
+type QueryCompare struct {
+	Field string
+	Op    string
+	Value float64
+}
+
+// QueryCollection matches the recipes of a collection or saved search,
+// named by its slug or name
+type QueryCollection struct {
+	Name string
+}
+
+// QueryFields maps the query's field commands, without the "@", to the
+// search fields they match in.
+var QueryFields = map[string]string{
+	"author":       "author",
+	"category":     "categories",
+	"description":  "description",
+	"ingredients":  "ingredients",
+	"instructions": "instructions",
+	"name":         "name",
+	"url":          "url",
+}
+
+// QueryCommands lists every command of the query language, as typed
+var QueryCommands = []string{
+	"@author ", "@category ", "@ingredients ", "@description ", "@instructions ",
+	"@name ", "@url ", "@collection ", "@fav", "@time<", "@rating>=",
+}
+
+// queryOps are the comparison operators, longest first so "<=" wins over "<"
+var queryOps = []string{"<=", ">=", "<", ">", "="}
+
+func (q QueryAnd) String() string { return "(and " + joinQueries(q) + ")" }
+func (q QueryOr) String() string  { return "(or " + joinQueries(q) + ")" }
+func (q QueryNot) String() string { return "(not " + q.Query.String() + ")" }
+func (QueryFavourite) String() string {
+	return "@fav"
+}
+
+func (q QueryText) String() string {
+	text := q.Text
+	if q.Phrase {
+		text = strconv.Quote(text)
+	}
+	if q.Field != "" {
+		return q.Field + ":" + text
+	}
+	return text
+}
+
+func (q QueryCollection) String() string {
+	return "collection:" + strconv.Quote(q.Name)
+}
+
+func (q QueryCompare) String() string {
+	return q.Field + q.Op + strconv.FormatFloat(q.Value, 'f', -1, 64)
+}
+
+func joinQueries(queries []Query) string {
+	parts := make([]string, len(queries))
+	for i, q := range queries {
+		parts[i] = q.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseQuery parses a recipe filter query. It returns nil for a query
+// without terms, which matches every recipe.
+func ParseQuery(input string) (Query, error) {
+	p := &queryParser{tokens: tokenizeQuery(input)}
+	q, err := p.parseAnd("")
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return q, nil
+}
+
+// tokenizeQuery splits a query into words, quoted phrases (kept with their
+// opening quote), parentheses, "|" and the "-" of negations
+func tokenizeQuery(input string) []string {
+	var tokens []string
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == '|':
+			tokens = append(tokens, string(r))
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			tokens = append(tokens, "-")
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			tokens = append(tokens, string(runes[i:end]))
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()|"`, runes[end]) {
+				end++
+			}
+			tokens = append(tokens, string(runes[i:end]))
+			i = end
+		}
+	}
+	return tokens
+}
+
+type queryParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *queryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *queryParser) atEnd() bool {
+	return p.pos >= len(p.tokens) || p.tokens[p.pos] == ")"
+}
+
+// parseAnd parses terms up to the end of the query or of its group. Text
+// terms match in field unless they name their own.
+func (p *queryParser) parseAnd(field string) (Query, error) {
+	var terms []Query
+	for !p.atEnd() {
+		term, err := p.parseOr(field)
+		if err != nil {
+			return nil, err
+		}
+		if term != nil {
+			terms = append(terms, term)
+		}
+	}
+	return combineQueries(terms, func(q []Query) Query { return QueryAnd(q) }), nil
+}
+
+func (p *queryParser) parseOr(field string) (Query, error) {
+	var terms []Query
+	for {
+		term, err := p.parseUnary(field)
+		if err != nil {
+			return nil, err
+		}
+		if term != nil {
+			terms = append(terms, term)
+		}
+		if p.peek() != "OR" && p.peek() != "|" {
+			break
+		}
+		p.pos++
+		if p.atEnd() {
+			break // an alternative still being typed
+		}
+	}
+	return combineQueries(terms, func(q []Query) Query { return QueryOr(q) }), nil
+}
+
+func (p *queryParser) parseUnary(field string) (Query, error) {
+	if p.atEnd() {
+		return nil, nil
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+
+	switch {
+	case token == "OR" || token == "|":
+		return nil, nil // an operator without a left side
+	case token == "-":
+		term, err := p.parseUnary(field)
+		if term == nil || err != nil {
+			return nil, err
+		}
+		return QueryNot{Query: term}, nil
+	case token == "(":
+		group, err := p.parseAnd(field)
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() == ")" {
+			p.pos++
+		}
+		return group, nil
+	case strings.HasPrefix(token, "@"):
+		return p.parseCommand(token[1:])
+	case strings.HasPrefix(token, `"`):
+		text := strings.Join(strings.Fields(token[1:]), " ")
+		if !hasWordRune(text) {
+			return nil, nil
+		}
+		return QueryText{Field: field, Text: text, Phrase: true}, nil
+	default:
+		if !hasWordRune(token) {
+			return nil, nil
+		}
+		return QueryText{Field: field, Text: token}, nil
+	}
+}
+
+// parseCommand parses the term of an "@" command, given without the "@"
+func (p *queryParser) parseCommand(command string) (Query, error) {
+	nameEnd := strings.IndexFunc(command, func(r rune) bool { return !unicode.IsLetter(r) })
+	if nameEnd < 0 {
+		nameEnd = len(command)
+	}
+	name, rest := strings.ToLower(command[:nameEnd]), command[nameEnd:]
+
+	switch name {
+	case "fav", "favs", "favourite", "favourites", "favorite", "favorites":
+		if rest != "" {
+			return nil, fmt.Errorf("unexpected %q after @%s", rest, name)
+		}
+		return QueryFavourite{}, nil
+	case "time", "rating":
+		return parseQueryCompare(name, rest)
+	case "collection":
+		if rest != "" {
+			return nil, fmt.Errorf("unexpected %q after @%s", rest, name)
+		}
+		if p.atEnd() || strings.ContainsAny(p.peek()[:1], "(-|@") || p.peek() == "OR" {
+			return nil, fmt.Errorf("@collection needs a name")
+		}
+		collection := strings.TrimSpace(strings.TrimPrefix(p.tokens[p.pos], `"`))
+		p.pos++
+		if collection == "" {
+			return nil, fmt.Errorf("@collection needs a name")
+		}
+		return QueryCollection{Name: collection}, nil
+	}
+
+	field, ok := QueryFields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown filter @%s", name)
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("unexpected %q after @%s", rest, name)
+	}
+	term, err := p.parseUnary(field)
+	if err == nil && term == nil {
+		err = fmt.Errorf("@%s needs a value", name)
+	}
+	return term, err
+}
+
+// parseQueryCompare parses the comparison after @time or @rating
+func parseQueryCompare(name string, comparison string) (Query, error) {
+	var op string
+	for _, candidate := range queryOps {
+		if strings.HasPrefix(comparison, candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("@%s needs a comparison, like @time<30m or @rating>=4", name)
+	}
+
+	valueText := comparison[len(op):]
+	if valueText == "" {
+		return nil, fmt.Errorf("@%s%s needs a value", name, op)
+	}
+
+	var value float64
+	if name == "time" {
+		// A bare number is in minutes
+		if _, err := strconv.ParseFloat(valueText, 64); err == nil {
+			valueText += "m"
+		}
+		d, err := time.ParseDuration(valueText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q, use minutes or a duration like 1h30m", valueText)
+		}
+		value = d.Minutes()
+	} else {
+		var err error
+		if value, err = strconv.ParseFloat(valueText, 64); err != nil {
+			return nil, fmt.Errorf("invalid rating %q", valueText)
+		}
+	}
+	return QueryCompare{Field: name, Op: op, Value: value}, nil
+}
+
+// Compare reports whether the recipe's number satisfies the comparison
+func (q QueryCompare) Compare(recipe RecipeRaw) bool {
+	var actual float64
+	switch q.Field {
+	case "time":
+		total := recipe.Metadata.TotalTime
+		if total <= 0 {
+			total = recipe.Metadata.PrepTime + recipe.Metadata.CookTime
+		}
+		actual = total.Minutes()
+	case "rating":
+		actual = float64(recipe.Metadata.Rating)
+	}
+	if actual <= 0 {
+		return false
+	}
+
+	switch q.Op {
+	case "<":
+		return actual < q.Value
+	case "<=":
+		return actual <= q.Value
+	case ">":
+		return actual > q.Value
+	case ">=":
+		return actual >= q.Value
+	default:
+		return actual == q.Value
+	}
+}
+
+func combineQueries(terms []Query, combine func([]Query) Query) Query {
+	switch len(terms) {
+	case 0:
+		return nil
+	case 1:
+		return terms[0]
+	}
+	return combine(terms)
+}
+
+func hasWordRune(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: "<nil>"},
+		{input: "chicken", want: "chicken"},
+		{input: "chicken curry", want: "(and chicken curry)"},
+		{input: `"chicken breast" lemon`, want: `(and "chicken breast" lemon)`},
+		{input: "@category dinner @ingredients chicken -@ingredients nuts",
+			want: "(and categories:dinner ingredients:chicken (not ingredients:nuts))"},
+		{input: `@author "Jamie Oliver"`, want: `author:"Jamie Oliver"`},
+		{input: "pasta OR risotto", want: "(or pasta risotto)"},
+		{input: "quick pasta | risotto", want: "(and quick (or pasta risotto))"},
+		{input: "@ingredients (chicken OR beef) -spicy", want: "(and (or ingredients:chicken ingredients:beef) (not spicy))"},
+		{input: "-(@fav OR @rating>=4)", want: "(not (or @fav rating>=4))"},
+		{input: "@time<30m @rating>=4", want: "(and time<30 rating>=4)"},
+		{input: "@time<=1h30m", want: "time<=90"},
+		{input: "@time>45", want: "time>45"},
+		{input: "stir-fry", want: "stir-fry"},
+		{input: `@collection "Kids like it" -@collection thanksgiving-2026`,
+			want: `(and collection:"Kids like it" (not collection:"thanksgiving-2026"))`},
+		// Incomplete input while typing
+		{input: `"chicken bre`, want: `"chicken bre"`},
+		{input: "(pasta OR", want: "pasta"},
+		{input: "pasta -", want: "pasta"},
+	}
+
+	for _, tt := range tests {
+		query, err := ParseQuery(tt.input)
+		if err != nil {
+			t.Errorf("ParseQuery(%q) error: %v", tt.input, err)
+			continue
+		}
+		got := "<nil>"
+		if query != nil {
+			got = query.String()
+		}
+		if got != tt.want {
+			t.Errorf("ParseQuery(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"@category", "@colour red", "@time", "@time<soon", "@rating>=", "pasta)", "@collection", "@collection (a OR b)"} {
+		if _, err := ParseQuery(input); err == nil {
+			t.Errorf("ParseQuery(%q) expected an error", input)
+		}
+	}
+}
+
+func TestQueryCompare(t *testing.T) {
+	quick := RecipeRaw{Metadata: RecipeMetadata{PrepTime: 10 * time.Minute, CookTime: 15 * time.Minute, Rating: 4}}
+	slow := RecipeRaw{Metadata: RecipeMetadata{TotalTime: 2 * time.Hour}}
+
+	tests := []struct {
+		query  QueryCompare
+		recipe RecipeRaw
+		want   bool
+	}{
+		{QueryCompare{Field: "time", Op: "<", Value: 30}, quick, true},
+		{QueryCompare{Field: "time", Op: "<", Value: 30}, slow, false},
+		{QueryCompare{Field: "time", Op: ">=", Value: 120}, slow, true},
+		{QueryCompare{Field: "rating", Op: ">=", Value: 4}, quick, true},
+		{QueryCompare{Field: "rating", Op: "=", Value: 4}, quick, true},
+		// Unrated recipes never match
+		{QueryCompare{Field: "rating", Op: "<", Value: 3}, slow, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.query.Compare(tt.recipe); got != tt.want {
+			t.Errorf("%s on %+v = %v, want %v", tt.query, tt.recipe.Metadata, got, tt.want)
+		}
+	}
+}
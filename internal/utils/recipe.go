@@ -10,7 +10,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/GarroshIcecream/yummy/internal/models/common"
 	"github.com/charmbracelet/bubbles/list"
 )
 
@@ -21,13 +20,25 @@ type RecipeMetadata struct {
 	TotalTime    time.Duration
 	Quantity     string
 	URL          string
+	ImageURL     string
+	Language     string
 	Favourite    bool
 	Rating       int8
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	LastCooked   time.Time // day the recipe was last cooked, zero if never
 	Categories   []string
+	Cuisines     []string
+	Keywords     []string
+	Diets        []string          // suitable diets, e.g. "Vegan", "Gluten Free"
+	Nutrients    map[string]string // schema.org nutrient key → value, e.g. "proteinContent" → "7 g"
+	Nutrition    NutritionEstimate // computed from the ingredients when the recipe is saved
 	Instructions []string
 	Ingredients  []Ingredient
+	Notes        string     // personal notes, markdown
+	StepNotes    []StepNote // personal annotations on instructions
+
+	InstructionSections []InstructionSection
 }
 
 type RecipeRaw struct {
@@ -48,72 +59,85 @@ func (i RecipeRaw) Title() string {
 }
 
 func (i RecipeRaw) Description() string {
-	if strings.TrimSpace(i.RecipeDescription) == "" {
-		return i.Metadata.Author
+	description := i.Metadata.Author
+	if strings.TrimSpace(i.RecipeDescription) != "" {
+		description = fmt.Sprintf("%s - %s", i.Metadata.Author, i.RecipeDescription)
 	}
-
-	return fmt.Sprintf("%s - %s", i.Metadata.Author, i.RecipeDescription)
+	if !i.Metadata.LastCooked.IsZero() {
+		description = fmt.Sprintf("🍳 %s · %s", i.Metadata.LastCooked.Format("Jan 2"), description)
+	}
+	return description
 }
 
+// FilterValue identifies the recipe to the recipe list's filter, which
+// searches the cookbook's full-text index: the ID, a tab, then the name.
 func (i RecipeRaw) FilterValue() string {
-	title := i.Title()
-	ingredients := []string{}
-	for _, ing := range i.Metadata.Ingredients {
-		ingredients = append(ingredients, ing.Name)
-	}
-	filterData := map[common.FilterField]any{
-		common.TitleField:       title,
-		common.IngredientsField: ingredients,
-		common.DescriptionField: i.RecipeDescription,
-		common.AuthorField:      i.Metadata.Author,
-		common.CategoryField:    i.Metadata.Categories,
-		common.FavouriteField:   i.IsFavourite,
-		common.URLField:         i.Metadata.URL,
-	}
-
-	jsonBytes, err := json.Marshal(filterData)
-	if err != nil {
-		slog.Error("Failed to marshal filter data", "error", err)
-		return fmt.Sprintf("%s %s %s", i.RecipeName, i.RecipeDescription, i.Metadata.Author)
-	}
+	return fmt.Sprintf("%d\t%s", i.RecipeID, i.RecipeName)
+}
 
-	return string(jsonBytes)
+// metadataValue returns the value for a metadata label in either the legacy
+// table layout ("| 👨‍🍳 Recipe By | Grandma |") or the current export layout
+// ("👨‍🍳 Recipe By  **Grandma**").
+func metadataValue(text string, label string) string {
+	quoted := regexp.QuoteMeta(label)
+	if match := regexp.MustCompile(quoted + `\s*\|\s*(.+?)\s*\|`).FindStringSubmatch(text); len(match) > 1 {
+		return strings.TrimSpace(match[1])
+	}
+	if match := regexp.MustCompile(quoted + `\s+\*\*(.+?)\*\*`).FindStringSubmatch(text); len(match) > 1 {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
 }
 
-// parseMetadataTable extracts metadata from the markdown table
+// parseMetadataTable extracts metadata from the markdown metadata block
 func (r *RecipeRaw) parseMetadataTable(text string) {
-	// Parse author
-	authorMatch := regexp.MustCompile(`👨‍🍳 Recipe By\s*\|\s*(.+?)\s*\|`).FindStringSubmatch(text)
-	if len(authorMatch) > 1 {
-		r.Metadata.Author = strings.TrimSpace(authorMatch[1])
+	if author := metadataValue(text, "👨‍🍳 Recipe By"); author != "" {
+		r.Metadata.Author = author
+	}
+
+	if servings := metadataValue(text, "🍽️ Servings"); servings != "" {
+		r.Metadata.Quantity = servings
+	}
+
+	if totalTime := metadataValue(text, "⏱️ Total Time"); totalTime != "" {
+		r.Metadata.TotalTime = ParseDurationFromString(totalTime)
+	}
+
+	if prepTime := metadataValue(text, "🔪 Prep Time"); prepTime != "" {
+		r.Metadata.PrepTime = ParseDurationFromString(prepTime)
 	}
 
-	// Parse servings
-	servingsMatch := regexp.MustCompile(`🍽️ Servings\s*\|\s*(.+?)\s*\|`).FindStringSubmatch(text)
-	if len(servingsMatch) > 1 {
-		r.Metadata.Quantity = strings.TrimSpace(servingsMatch[1])
+	if cookTime := metadataValue(text, "🔥 Cook Time"); cookTime != "" {
+		r.Metadata.CookTime = ParseDurationFromString(cookTime)
 	}
 
-	// Parse times
-	totalTimeMatch := regexp.MustCompile(`⏱️ Total Time\s*\|\s*(.+?)\s*\|`).FindStringSubmatch(text)
-	if len(totalTimeMatch) > 1 {
-		r.Metadata.TotalTime = ParseDurationFromString(strings.TrimSpace(totalTimeMatch[1]))
+	if rating := metadataValue(text, "⭐ Rating"); rating != "" {
+		r.Metadata.Rating = int8(strings.Count(rating, "★"))
 	}
 
-	prepTimeMatch := regexp.MustCompile(`🔪 Prep Time\s*\|\s*(.+?)\s*\|`).FindStringSubmatch(text)
-	if len(prepTimeMatch) > 1 {
-		r.Metadata.PrepTime = ParseDurationFromString(strings.TrimSpace(prepTimeMatch[1]))
+	if language := metadataValue(text, "🌐 Language"); language != "" {
+		r.Metadata.Language = language
 	}
 
-	cookTimeMatch := regexp.MustCompile(`🔥 Cook Time\s*\|\s*(.+?)\s*\|`).FindStringSubmatch(text)
-	if len(cookTimeMatch) > 1 {
-		r.Metadata.CookTime = ParseDurationFromString(strings.TrimSpace(cookTimeMatch[1]))
+	if diets := metadataValue(text, "🥗 Diet"); diets != "" {
+		r.Metadata.Diets = splitList(diets)
+	}
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(text string) []string {
+	items := []string{}
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
 
-// formatDurationHuman formats a time.Duration into a human-friendly string
+// FormatDurationHuman formats a time.Duration into a human-friendly string
 // like "45 min", "1 hr", or "1 hr 30 min". Returns "" for zero durations.
-func formatDurationHuman(d time.Duration) string {
+func FormatDurationHuman(d time.Duration) string {
 	if d <= 0 {
 		return ""
 	}
@@ -129,9 +153,9 @@ func formatDurationHuman(d time.Duration) string {
 	}
 }
 
-// formatRating formats a rating (0-5) into a star string like "★★★★☆".
+// FormatRating formats a rating (0-5) into a star string like "★★★★☆".
 // Returns "" for unrated (0).
-func formatRating(rating int8) string {
+func FormatRating(rating int8) string {
 	if rating <= 0 {
 		return ""
 	}
@@ -162,7 +186,7 @@ func (r *RecipeRaw) FormatRecipeMarkdown() string {
 	} else if n > 1 {
 		stats = append(stats, fmt.Sprintf("%d steps", n))
 	}
-	if t := formatDurationHuman(r.Metadata.TotalTime); t != "" {
+	if t := FormatDurationHuman(r.Metadata.TotalTime); t != "" {
 		stats = append(stats, t)
 	}
 	if len(stats) > 0 {
@@ -175,6 +199,11 @@ func (r *RecipeRaw) FormatRecipeMarkdown() string {
 		s.WriteString(fmt.Sprintf("> %s\n\n", r.RecipeDescription))
 	}
 
+	// Image
+	if r.Metadata.ImageURL != "" {
+		s.WriteString(fmt.Sprintf("🖼️ ![%s](%s)\n\n", r.RecipeName, r.Metadata.ImageURL))
+	}
+
 	// Metadata as simple key-value pairs (no table)
 	metaRows := []struct{ label, value string }{}
 	if r.Metadata.Author != "" {
@@ -183,18 +212,24 @@ func (r *RecipeRaw) FormatRecipeMarkdown() string {
 	if r.Metadata.Quantity != "" {
 		metaRows = append(metaRows, struct{ label, value string }{"🍽️ Servings", r.Metadata.Quantity})
 	}
-	if t := formatDurationHuman(r.Metadata.TotalTime); t != "" {
+	if t := FormatDurationHuman(r.Metadata.TotalTime); t != "" {
 		metaRows = append(metaRows, struct{ label, value string }{"⏱️ Total Time", t})
 	}
-	if t := formatDurationHuman(r.Metadata.PrepTime); t != "" {
+	if t := FormatDurationHuman(r.Metadata.PrepTime); t != "" {
 		metaRows = append(metaRows, struct{ label, value string }{"🔪 Prep Time", t})
 	}
-	if t := formatDurationHuman(r.Metadata.CookTime); t != "" {
+	if t := FormatDurationHuman(r.Metadata.CookTime); t != "" {
 		metaRows = append(metaRows, struct{ label, value string }{"🔥 Cook Time", t})
 	}
-	if ratingStr := formatRating(r.Metadata.Rating); ratingStr != "" {
+	if ratingStr := FormatRating(r.Metadata.Rating); ratingStr != "" {
 		metaRows = append(metaRows, struct{ label, value string }{"⭐ Rating", ratingStr})
 	}
+	if len(r.Metadata.Diets) > 0 {
+		metaRows = append(metaRows, struct{ label, value string }{"🥗 Diet", strings.Join(r.Metadata.Diets, ", ")})
+	}
+	if r.Metadata.Language != "" {
+		metaRows = append(metaRows, struct{ label, value string }{"🌐 Language", r.Metadata.Language})
+	}
 
 	if len(metaRows) > 0 {
 		s.WriteString("---\n\n")
@@ -206,7 +241,15 @@ func (r *RecipeRaw) FormatRecipeMarkdown() string {
 
 	// Ingredients
 	s.WriteString("### 🥘 Ingredients\n\n")
+	group := ""
 	for _, ing := range r.Metadata.Ingredients {
+		if ing.Group != group {
+			group = ing.Group
+			if group != "" {
+				s.WriteString(fmt.Sprintf("#### %s\n\n", group))
+			}
+		}
+
 		var ingredient strings.Builder
 		ingredient.WriteString("• ")
 
@@ -227,8 +270,20 @@ func (r *RecipeRaw) FormatRecipeMarkdown() string {
 	// Instructions (with ingredient names highlighted)
 	s.WriteString("### 👩‍🍳 Instructions\n\n")
 	for i, inst := range r.Metadata.Instructions {
+		if title, starts := r.Metadata.InstructionSectionAt(i); starts {
+			s.WriteString(fmt.Sprintf("#### %s\n\n", title))
+		}
 		highlighted := HighlightIngredientsInMarkdown(inst, r.Metadata.Ingredients)
 		s.WriteString(fmt.Sprintf("**%d.** %s\n\n", i+1, highlighted))
+		if note := r.Metadata.StepNoteAt(i); note != "" {
+			s.WriteString(fmt.Sprintf("> 💡 %s\n\n", note))
+		}
+	}
+
+	// Personal notes
+	if notes := strings.TrimSpace(r.Metadata.Notes); notes != "" {
+		s.WriteString("### 📝 Notes\n\n")
+		s.WriteString(notes + "\n\n")
 	}
 
 	// Categories
@@ -240,6 +295,28 @@ func (r *RecipeRaw) FormatRecipeMarkdown() string {
 		s.WriteString("\n\n")
 	}
 
+	// Keywords
+	if len(r.Metadata.Keywords) > 0 {
+		s.WriteString("### 🔖 Keywords\n\n")
+		for _, keyword := range r.Metadata.Keywords {
+			s.WriteString(fmt.Sprintf("`%s` ", keyword))
+		}
+		s.WriteString("\n\n")
+	}
+
+	// Nutrition
+	if len(r.Metadata.Nutrients) > 0 {
+		s.WriteString("### 📊 Nutrition\n\n")
+		for _, key := range SortedNutrientKeys(r.Metadata.Nutrients) {
+			s.WriteString(fmt.Sprintf("• %s: **%s**\n\n", NutrientLabel(key), r.Metadata.Nutrients[key]))
+		}
+	}
+
+	// Nutrition estimated from the ingredients
+	if r.Metadata.Nutrition.Known() {
+		s.WriteString(formatNutritionEstimate(r.Metadata.Nutrition))
+	}
+
 	// Source
 	if r.Metadata.URL != "" {
 		s.WriteString("🔗 " + r.Metadata.URL + "\n\n")
@@ -308,6 +385,9 @@ func ParseMarkdownRecipe(filePath string, customName string) (*RecipeRaw, error)
 		slog.Error("Failed to parse instructions", "error", err)
 	}
 	recipeData.Metadata.Instructions = instructions
+	recipeData.Metadata.InstructionSections = ParseInstructionSectionsFromMarkdown(text)
+	recipeData.Metadata.StepNotes = ParseStepNotesFromMarkdown(text)
+	recipeData.Metadata.Notes = ParseNotesFromMarkdown(text)
 
 	// Parse categories
 	categories, err := ParseCategoriesFromMarkdown(text)
@@ -323,35 +403,143 @@ func ParseMarkdownRecipe(filePath string, customName string) (*RecipeRaw, error)
 	}
 	recipeData.Metadata.URL = URL
 
+	// Parse image, keywords and nutrition
+	recipeData.Metadata.ImageURL = ParseImageURLFromMarkdown(text)
+	recipeData.Metadata.Keywords = ParseKeywordsFromMarkdown(text)
+	recipeData.Metadata.Nutrients = ParseNutritionFromMarkdown(text)
+
 	return recipeData, nil
 }
 
-// parseJSONRecipe parses a JSON recipe file
+// IngredientJSON is the JSON shape of a single ingredient in RecipeJSON.
+type IngredientJSON struct {
+	Amount  string `json:"amount"`
+	Unit    string `json:"unit"`
+	Name    string `json:"name"`
+	Details string `json:"details"`
+	Group   string `json:"group,omitempty"`
+}
+
+// RecipeJSON is the JSON shape read by ParseJSONRecipe and written by
+// FormatRecipeJSON, so exported recipes round-trip through import.
+type RecipeJSON struct {
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Author       string               `json:"author"`
+	CookTime     string               `json:"cook_time"`
+	PrepTime     string               `json:"prep_time"`
+	TotalTime    string               `json:"total_time"`
+	Quantity     string               `json:"quantity"`
+	URL          string               `json:"url"`
+	Image        string               `json:"image,omitempty"`
+	Language     string               `json:"language,omitempty"`
+	Rating       int8                 `json:"rating,omitempty"`
+	Favourite    bool                 `json:"favourite,omitempty"`
+	Ingredients  []IngredientJSON     `json:"ingredients"`
+	Instructions []string             `json:"instructions"`
+	Sections     []InstructionSection `json:"instruction_sections,omitempty"`
+	Categories   []string             `json:"categories"`
+	Cuisines     []string             `json:"cuisines,omitempty"`
+	Keywords     []string             `json:"keywords,omitempty"`
+	Diets        []string             `json:"diets,omitempty"`
+	Nutrients    map[string]string    `json:"nutrients,omitempty"`
+	Notes        string               `json:"notes,omitempty"`
+	StepNotes    []StepNote           `json:"step_notes,omitempty"`
+}
+
+// formatDurationShort formats a duration in the compact form accepted by
+// ParseDurationFromString (e.g. "1h30m", "45m"). Returns "" for zero durations.
+func formatDurationShort(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// ToRecipeJSON converts the recipe into its JSON export shape
+func (r *RecipeRaw) ToRecipeJSON() RecipeJSON {
+	ingredients := make([]IngredientJSON, 0, len(r.Metadata.Ingredients))
+	for _, ing := range r.Metadata.Ingredients {
+		ingredients = append(ingredients, IngredientJSON{
+			Amount:  ing.Amount,
+			Unit:    ing.Unit,
+			Name:    ing.Name,
+			Details: ing.Details,
+			Group:   ing.Group,
+		})
+	}
+
+	instructions := r.Metadata.Instructions
+	if instructions == nil {
+		instructions = []string{}
+	}
+	categories := r.Metadata.Categories
+	if categories == nil {
+		categories = []string{}
+	}
+
+	return RecipeJSON{
+		Name:         r.RecipeName,
+		Description:  r.RecipeDescription,
+		Author:       r.Metadata.Author,
+		CookTime:     formatDurationShort(r.Metadata.CookTime),
+		PrepTime:     formatDurationShort(r.Metadata.PrepTime),
+		TotalTime:    formatDurationShort(r.Metadata.TotalTime),
+		Quantity:     r.Metadata.Quantity,
+		URL:          r.Metadata.URL,
+		Image:        r.Metadata.ImageURL,
+		Language:     r.Metadata.Language,
+		Rating:       r.Metadata.Rating,
+		Favourite:    r.Metadata.Favourite,
+		Ingredients:  ingredients,
+		Instructions: instructions,
+		Sections:     r.Metadata.InstructionSections,
+		Categories:   categories,
+		Cuisines:     r.Metadata.Cuisines,
+		Keywords:     r.Metadata.Keywords,
+		Diets:        r.Metadata.Diets,
+		Nutrients:    r.Metadata.Nutrients,
+		Notes:        r.Metadata.Notes,
+		StepNotes:    r.Metadata.StepNotes,
+	}
+}
+
+// FormatRecipeJSON formats the recipe as indented JSON readable by ParseJSONRecipe
+func (r *RecipeRaw) FormatRecipeJSON() (string, error) {
+	data, err := json.MarshalIndent(r.ToRecipeJSON(), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal recipe: %v", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// ParseJSONRecipe parses a JSON recipe file, either in the RecipeJSON shape
+// or as schema.org Recipe JSON-LD
 func ParseJSONRecipe(filePath string, customName string) (*RecipeRaw, error) {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %v", err)
 	}
 
-	var jsonRecipe struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
-		Author      string `json:"author"`
-		CookTime    string `json:"cook_time"`
-		PrepTime    string `json:"prep_time"`
-		TotalTime   string `json:"total_time"`
-		Quantity    string `json:"quantity"`
-		URL         string `json:"url"`
-		Ingredients []struct {
-			Amount  string `json:"amount"`
-			Unit    string `json:"unit"`
-			Name    string `json:"name"`
-			Details string `json:"details"`
-		} `json:"ingredients"`
-		Instructions []string `json:"instructions"`
-		Categories   []string `json:"categories"`
+	if IsSchemaOrgJSON(content) {
+		recipeData, err := ParseSchemaOrgRecipe(content)
+		if err != nil {
+			return nil, err
+		}
+		if customName != "" {
+			recipeData.RecipeName = customName
+		}
+		return recipeData, nil
 	}
 
+	var jsonRecipe RecipeJSON
 	if err := json.Unmarshal(content, &jsonRecipe); err != nil {
 		return nil, fmt.Errorf("failed to parse JSON: %v", err)
 	}
@@ -360,11 +548,24 @@ func ParseJSONRecipe(filePath string, customName string) (*RecipeRaw, error) {
 		RecipeName:        jsonRecipe.Name,
 		RecipeDescription: jsonRecipe.Description,
 		Metadata: RecipeMetadata{
+			Author:       jsonRecipe.Author,
 			Quantity:     jsonRecipe.Quantity,
 			URL:          jsonRecipe.URL,
+			ImageURL:     jsonRecipe.Image,
+			Language:     jsonRecipe.Language,
+			Rating:       min(max(jsonRecipe.Rating, 0), 5),
+			Favourite:    jsonRecipe.Favourite,
 			Ingredients:  []Ingredient{},
 			Instructions: jsonRecipe.Instructions,
 			Categories:   jsonRecipe.Categories,
+			Cuisines:     jsonRecipe.Cuisines,
+			Keywords:     jsonRecipe.Keywords,
+
+			InstructionSections: jsonRecipe.Sections,
+			Diets:               jsonRecipe.Diets,
+			Nutrients:           jsonRecipe.Nutrients,
+			Notes:               jsonRecipe.Notes,
+			StepNotes:           jsonRecipe.StepNotes,
 		},
 	}
 
@@ -385,8 +586,37 @@ func ParseJSONRecipe(filePath string, customName string) (*RecipeRaw, error) {
 			Unit:    ing.Unit,
 			Name:    ing.Name,
 			Details: ing.Details,
+			Group:   ing.Group,
 		})
 	}
 
 	return recipeData, nil
 }
+
+// RecipeFileSlug turns a recipe name into a safe, lowercase file name stem
+// (e.g. "Mom's Mac & Cheese" → "moms_mac_cheese").
+func RecipeFileSlug(name string) string {
+	var b strings.Builder
+	lastUnderscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			b.WriteRune(r)
+			lastUnderscore = false
+		case r == ' ' || r == '_' || r == '&' || r == '/' || r == '.' || r == ',':
+			if !lastUnderscore && b.Len() > 0 {
+				b.WriteRune('_')
+				lastUnderscore = true
+			}
+		case r > 127:
+			b.WriteRune(r)
+			lastUnderscore = false
+		}
+	}
+
+	slug := strings.Trim(b.String(), "_")
+	if slug == "" {
+		return "recipe"
+	}
+	return slug
+}
@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func sampleRecipe() RecipeRaw {
+	return RecipeRaw{
+		RecipeName:        "Spaghetti Carbonara",
+		RecipeDescription: "Creamy Roman pasta.",
+		Metadata: RecipeMetadata{
+			Author:    "Nonna",
+			Quantity:  "4 servings",
+			PrepTime:  10 * time.Minute,
+			CookTime:  15 * time.Minute,
+			TotalTime: 25 * time.Minute,
+			URL:       "https://example.com/carbonara",
+			ImageURL:  "https://example.com/carbonara.jpg",
+			Language:  "it",
+			Rating:    4,
+			Ingredients: []Ingredient{
+				{Amount: "1", Unit: "pound", Name: "spaghetti", Details: "dried"},
+				{Amount: "4", Name: "large eggs", Group: "For the sauce"},
+				{Amount: "1", Unit: "cup", Name: "Pecorino Romano", Details: "grated", Group: "For the sauce"},
+			},
+			Instructions: []string{"Boil the spaghetti.", "Whisk the large eggs with cheese."},
+			Categories:   []string{"pasta", "italian"},
+			Keywords:     []string{"weeknight", "roman"},
+			Diets:        []string{"Vegetarian"},
+			Nutrients:    map[string]string{"calories": "650 kcal", "proteinContent": "28 g"},
+
+			Notes:     "Guanciale beats pancetta.\n\n- Save a cup of pasta water",
+			StepNotes: []StepNote{{Step: 2, Note: "Use less salt next time."}},
+
+			InstructionSections: []InstructionSection{{Title: "For the sauce", Step: 2}},
+		},
+	}
+}
+
+func writeTemp(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func assertRoundTrip(t *testing.T, want RecipeRaw, got *RecipeRaw) {
+	t.Helper()
+	if got.RecipeName != want.RecipeName || got.RecipeDescription != want.RecipeDescription {
+		t.Errorf("name/description = %q/%q, want %q/%q", got.RecipeName, got.RecipeDescription, want.RecipeName, want.RecipeDescription)
+	}
+	w, g := want.Metadata, got.Metadata
+	if g.Author != w.Author || g.Quantity != w.Quantity || g.URL != w.URL {
+		t.Errorf("author/quantity/url = %q/%q/%q", g.Author, g.Quantity, g.URL)
+	}
+	if g.PrepTime != w.PrepTime || g.CookTime != w.CookTime || g.TotalTime != w.TotalTime {
+		t.Errorf("times = %v/%v/%v, want %v/%v/%v", g.PrepTime, g.CookTime, g.TotalTime, w.PrepTime, w.CookTime, w.TotalTime)
+	}
+	if !reflect.DeepEqual(g.Ingredients, w.Ingredients) {
+		t.Errorf("ingredients = %+v, want %+v", g.Ingredients, w.Ingredients)
+	}
+	if !reflect.DeepEqual(g.Instructions, w.Instructions) {
+		t.Errorf("instructions = %q, want %q", g.Instructions, w.Instructions)
+	}
+	if !reflect.DeepEqual(g.InstructionSections, w.InstructionSections) {
+		t.Errorf("instruction sections = %+v, want %+v", g.InstructionSections, w.InstructionSections)
+	}
+	if !reflect.DeepEqual(g.Categories, w.Categories) {
+		t.Errorf("categories = %q, want %q", g.Categories, w.Categories)
+	}
+	if g.ImageURL != w.ImageURL || g.Language != w.Language {
+		t.Errorf("image/language = %q/%q", g.ImageURL, g.Language)
+	}
+	if !reflect.DeepEqual(g.Keywords, w.Keywords) || !reflect.DeepEqual(g.Diets, w.Diets) {
+		t.Errorf("keywords/diets = %q/%q, want %q/%q", g.Keywords, g.Diets, w.Keywords, w.Diets)
+	}
+	if !reflect.DeepEqual(g.Nutrients, w.Nutrients) {
+		t.Errorf("nutrients = %v, want %v", g.Nutrients, w.Nutrients)
+	}
+	if g.Notes != w.Notes || !reflect.DeepEqual(g.StepNotes, w.StepNotes) {
+		t.Errorf("notes/step notes = %q/%+v, want %q/%+v", g.Notes, g.StepNotes, w.Notes, w.StepNotes)
+	}
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	recipe := sampleRecipe()
+	path := writeTemp(t, "carbonara.md", recipe.FormatRecipeMarkdown())
+
+	got, err := ParseMarkdownRecipe(path, "")
+	if err != nil {
+		t.Fatalf("ParseMarkdownRecipe: %v", err)
+	}
+	assertRoundTrip(t, recipe, got)
+	if got.Metadata.Rating != recipe.Metadata.Rating {
+		t.Errorf("rating = %d, want %d", got.Metadata.Rating, recipe.Metadata.Rating)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	recipe := sampleRecipe()
+	recipe.Metadata.Cuisines = []string{"Italian"}
+	recipe.Metadata.Favourite = true
+	content, err := recipe.FormatRecipeJSON()
+	if err != nil {
+		t.Fatalf("FormatRecipeJSON: %v", err)
+	}
+	if !json.Valid([]byte(content)) {
+		t.Fatalf("FormatRecipeJSON produced invalid JSON:\n%s", content)
+	}
+
+	got, err := ParseJSONRecipe(writeTemp(t, "carbonara.json", content), "")
+	if err != nil {
+		t.Fatalf("ParseJSONRecipe: %v", err)
+	}
+	assertRoundTrip(t, recipe, got)
+	if got.Metadata.Rating != recipe.Metadata.Rating {
+		t.Errorf("rating = %d, want %d", got.Metadata.Rating, recipe.Metadata.Rating)
+	}
+	if !got.Metadata.Favourite {
+		t.Error("favourite = false, want true")
+	}
+	if !reflect.DeepEqual(got.Metadata.Cuisines, recipe.Metadata.Cuisines) {
+		t.Errorf("cuisines = %q, want %q", got.Metadata.Cuisines, recipe.Metadata.Cuisines)
+	}
+}
+
+func TestRecipeFileSlug(t *testing.T) {
+	tests := map[string]string{
+		"Spaghetti Carbonara":    "spaghetti_carbonara",
+		"Mom's Apple Pie!":       "moms_apple_pie",
+		"  ":                     "recipe",
+		"Crème brûlée / Classic": "crème_brûlée_classic",
+	}
+	for name, want := range tests {
+		if got := RecipeFileSlug(name); got != want {
+			t.Errorf("RecipeFileSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"strings"
+)
+
+// InstructionSection names a run of instructions ("For the sauce"). It starts
+// at Step (1-based) and lasts until the next section or the end of the recipe.
+type InstructionSection struct {
+	Title string `json:"title"`
+	Step  int    `json:"step"`
+}
+
+// sectionHeadingPrefix marks group/section lines in the edit form text fields
+const sectionHeadingPrefix = "#"
+
+// InstructionSectionAt returns the title of the section containing the
+// 0-based step index, and whether that section starts at this step.
+func (m RecipeMetadata) InstructionSectionAt(index int) (string, bool) {
+	title, starts := "", false
+	for _, section := range m.InstructionSections {
+		if section.Step-1 > index {
+			break
+		}
+		title, starts = section.Title, section.Step-1 == index
+	}
+	return title, starts
+}
+
+// InstructionSectionTitles returns the section title of every instruction,
+// "" for steps before the first section.
+func (m RecipeMetadata) InstructionSectionTitles() []string {
+	titles := make([]string, len(m.Instructions))
+	for i := range m.Instructions {
+		titles[i], _ = m.InstructionSectionAt(i)
+	}
+	return titles
+}
+
+// InstructionSectionsFromTitles is the inverse of InstructionSectionTitles:
+// a section starts wherever the per-step title changes to a non-empty value.
+func InstructionSectionsFromTitles(titles []string) []InstructionSection {
+	var sections []InstructionSection
+	previous := ""
+	for i, title := range titles {
+		if title != previous && title != "" {
+			sections = append(sections, InstructionSection{Title: title, Step: i + 1})
+		}
+		previous = title
+	}
+	return sections
+}
+
+// FormatIngredientsText renders ingredients one per line for editing, with
+// "# Group" lines introducing each ingredient group.
+func FormatIngredientsText(ingredients []Ingredient) string {
+	var lines []string
+	group := ""
+	for _, ing := range ingredients {
+		if ing.Group != group {
+			group = ing.Group
+			if group != "" {
+				lines = append(lines, sectionHeadingPrefix+" "+group)
+			}
+		}
+		lines = append(lines, FormatIngredientLine(ing))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseIngredientsText parses text written by FormatIngredientsText. Lines that
+// are unchanged keep the original ingredient (including its BaseName).
+func ParseIngredientsText(text string, original []Ingredient) []Ingredient {
+	unchanged := make(map[string]Ingredient, len(original))
+	for _, ing := range original {
+		unchanged[FormatIngredientLine(ing)] = ing
+	}
+
+	ingredients := []Ingredient{}
+	group := ""
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, sectionHeadingPrefix):
+			group = strings.TrimSpace(strings.TrimLeft(line, sectionHeadingPrefix))
+			continue
+		}
+
+		ingredient, ok := unchanged[line]
+		if !ok {
+			var err error
+			ingredient, err = ParseIngredient(line)
+			if err != nil || ingredient.Name == "" {
+				ingredient = Ingredient{Name: line}
+			}
+		}
+		ingredient.Group = group
+		ingredients = append(ingredients, ingredient)
+	}
+	return ingredients
+}
+
+// FormatInstructionsText renders instructions one step per line for editing,
+// with "# Section" lines introducing each section and "> note" lines after
+// annotated steps.
+func FormatInstructionsText(metadata RecipeMetadata) string {
+	var lines []string
+	for i, step := range metadata.Instructions {
+		if title, starts := metadata.InstructionSectionAt(i); starts {
+			lines = append(lines, sectionHeadingPrefix+" "+title)
+		}
+		lines = append(lines, step)
+		if note := metadata.StepNoteAt(i); note != "" {
+			lines = append(lines, stepNotePrefix+" "+note)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseInstructionsText parses text written by FormatInstructionsText. Note
+// lines annotate the step above them; several are joined, and those before
+// the first step are dropped.
+func ParseInstructionsText(text string) ([]string, []InstructionSection, []StepNote) {
+	instructions := []string{}
+	var titles, notes []string
+	title := ""
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, sectionHeadingPrefix):
+			title = strings.TrimSpace(strings.TrimLeft(line, sectionHeadingPrefix))
+			continue
+		case strings.HasPrefix(line, stepNotePrefix):
+			note := strings.TrimSpace(strings.TrimLeft(line, stepNotePrefix))
+			if last := len(notes) - 1; last >= 0 && note != "" {
+				notes[last] = strings.TrimSpace(notes[last] + " " + note)
+			}
+			continue
+		}
+		instructions = append(instructions, line)
+		titles = append(titles, title)
+		notes = append(notes, "")
+	}
+	return instructions, InstructionSectionsFromTitles(titles), StepNotesFromList(notes)
+}
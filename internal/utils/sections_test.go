@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIngredientsTextRoundTrip(t *testing.T) {
+	original := []Ingredient{
+		{Amount: "2", Unit: "cup", Name: "flour", BaseName: "flour"},
+		{Amount: "1", Unit: "cup", Name: "tomato sauce", Group: "For the topping"},
+		{Name: "basil", Details: "torn", Group: "For the topping"},
+	}
+
+	text := FormatIngredientsText(original)
+	want := "2 cup flour\n# For the topping\n1 cup tomato sauce\nbasil (torn)"
+	if text != want {
+		t.Fatalf("FormatIngredientsText() = %q, want %q", text, want)
+	}
+
+	got := ParseIngredientsText(text+"\n3 tablespoon olive oil", original)
+	if !reflect.DeepEqual(got[:3], original) {
+		t.Errorf("unchanged lines = %+v, want %+v", got[:3], original)
+	}
+	if added := got[3]; added.Name != "olive oil" || added.Amount != "3" || added.Group != "For the topping" {
+		t.Errorf("added line = %+v", added)
+	}
+}
+
+func TestInstructionsTextRoundTrip(t *testing.T) {
+	metadata := RecipeMetadata{
+		Instructions:        []string{"Preheat the oven.", "Knead the dough.", "Rest it.", "Spread the sauce."},
+		InstructionSections: []InstructionSection{{Title: "Dough", Step: 2}, {Title: "Topping", Step: 4}},
+		StepNotes:           []StepNote{{Step: 2, Note: "Use less flour next time."}},
+	}
+
+	text := FormatInstructionsText(metadata)
+	instructions, sections, notes := ParseInstructionsText(text)
+	if !reflect.DeepEqual(instructions, metadata.Instructions) || !reflect.DeepEqual(sections, metadata.InstructionSections) {
+		t.Errorf("ParseInstructionsText(%q) = %q, %+v", text, instructions, sections)
+	}
+	if !reflect.DeepEqual(notes, metadata.StepNotes) {
+		t.Errorf("ParseInstructionsText(%q) notes = %+v, want %+v", text, notes, metadata.StepNotes)
+	}
+
+	if title, starts := metadata.InstructionSectionAt(2); title != "Dough" || starts {
+		t.Errorf("InstructionSectionAt(2) = %q, %v", title, starts)
+	}
+	if title, starts := metadata.InstructionSectionAt(0); title != "" || starts {
+		t.Errorf("InstructionSectionAt(0) = %q, %v", title, starts)
+	}
+}
+
+func TestParseInstructionsTextNotes(t *testing.T) {
+	text := "> dropped\nMix.\n> Sift first.\n>   and chill\nBake.\n>"
+	_, _, notes := ParseInstructionsText(text)
+	want := []StepNote{{Step: 1, Note: "Sift first. and chill"}}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("ParseInstructionsText(%q) notes = %+v, want %+v", text, notes, want)
+	}
+}
@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GarroshIcecream/yummy/internal/units"
+)
+
+// ShoppingItem is one line of a shopping list: an ingredient merged across
+// every recipe on the list that needs it.
+type ShoppingItem struct {
+	ID       uint
+	Name     string
+	BaseName string
+	Amount   string
+	Unit     string
+	Extra    string // amounts that could not be added up ("a pinch")
+	Aisle    string
+	Checked  bool
+	Recipes  []string // names of the recipes the item is needed for
+}
+
+// ShoppingListRecipe is a recipe on a shopping list together with the factor
+// its ingredients are scaled by.
+type ShoppingListRecipe struct {
+	RecipeID   uint
+	RecipeName string
+	Scale      float64
+}
+
+type ShoppingList struct {
+	ID        uint
+	Name      string
+	CreatedAt time.Time
+	Recipes   []ShoppingListRecipe
+	Items     []ShoppingItem
+}
+
+// ShoppingAisleGroup is the items of one store aisle.
+type ShoppingAisleGroup struct {
+	Aisle string
+	Items []ShoppingItem
+}
+
+// shoppingAccumulator collects the amounts of one merged item
+type shoppingAccumulator struct {
+	item     ShoppingItem
+	unit     units.Unit // set when the unit is convertible
+	quantity Quantity
+	counted  bool            // quantity holds at least one parsed amount
+	extras   []shoppingExtra // amounts that could not be added up
+}
+
+// shoppingExtra is an amount that could not be parsed ("a pinch") or
+// converted, kept with its own unit
+type shoppingExtra struct {
+	amount string
+	unit   string
+}
+
+func (e shoppingExtra) String() string {
+	return strings.TrimSpace(e.amount + " " + e.unit)
+}
+
+// BuildShoppingItems merges the ingredients of the given (already scaled)
+// recipes into shopping items. Ingredients are merged by BaseName (or name)
+// and unit; amounts in convertible units are summed in the unit seen first
+// ("1 cup" + "4 tbl" milk → "1 1/4 cup"), going between volume and weight
+// when the ingredient's density is known.
+func BuildShoppingItems(recipes []*RecipeRaw) []ShoppingItem {
+	var order []string
+	merged := make(map[string]*shoppingAccumulator)
+
+	for _, recipe := range recipes {
+		for _, ing := range recipe.Metadata.Ingredients {
+			name := strings.TrimSpace(ing.BaseName)
+			if name == "" {
+				name = strings.TrimSpace(ing.Name)
+			}
+			if name == "" {
+				continue
+			}
+
+			unitName := strings.ToLower(strings.TrimSpace(ing.Unit))
+			if normalized, ok := CorpusMeasuresMap[unitName]; ok {
+				unitName = normalized
+			}
+			unit, convertible := units.Lookup(unitName)
+			density, hasDensity := units.LookupDensity(name)
+			unitKey := unitName
+			switch {
+			case convertible && hasDensity:
+				// Volumes and weights of the same ingredient can be added up
+				unitKey = "measure"
+			case convertible:
+				unitKey = fmt.Sprintf("dimension:%d", unit.Dimension)
+			}
+
+			key := strings.ToLower(name) + "|" + unitKey
+			acc, exists := merged[key]
+			if !exists {
+				acc = &shoppingAccumulator{
+					item: ShoppingItem{
+						Name:     ing.Name,
+						BaseName: ing.BaseName,
+						Unit:     unitName,
+						Aisle:    AisleFor(name),
+					},
+					unit: unit,
+				}
+				merged[key] = acc
+				order = append(order, key)
+			} else if !strings.EqualFold(acc.item.Name, ing.Name) && ing.BaseName != "" {
+				// Different spellings of the same ingredient: show the core name
+				acc.item.Name = ing.BaseName
+			}
+			acc.add(ing.Amount, unitName, unit, convertible, density.Density)
+			acc.addRecipe(recipe.RecipeName)
+		}
+	}
+
+	items := make([]ShoppingItem, 0, len(order))
+	for _, key := range order {
+		items = append(items, merged[key].result())
+	}
+	SortShoppingItems(items)
+	return items
+}
+
+func (a *shoppingAccumulator) add(amount, unitName string, unit units.Unit, convertible bool, density float64) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return
+	}
+	q, ok := ParseQuantity(amount)
+	if !ok {
+		a.extras = append(a.extras, shoppingExtra{amount: amount, unit: unitName})
+		return
+	}
+	if convertible && unit.Name != a.unit.Name {
+		min, errMin := units.ConvertWithDensity(q.Min, unit, a.unit, density)
+		max, errMax := units.ConvertWithDensity(q.Max, unit, a.unit, density)
+		if errMin != nil || errMax != nil {
+			a.extras = append(a.extras, shoppingExtra{amount: amount, unit: unitName})
+			return
+		}
+		q = Quantity{Min: min, Max: max}
+	}
+	a.quantity = Quantity{Min: a.quantity.Min + q.Min, Max: a.quantity.Max + q.Max}
+	a.counted = true
+}
+
+func (a *shoppingAccumulator) addRecipe(name string) {
+	for _, existing := range a.item.Recipes {
+		if existing == name {
+			return
+		}
+	}
+	a.item.Recipes = append(a.item.Recipes, name)
+}
+
+// result renders the summed amount in the item's unit and the amounts that
+// could not be added up after it, each with its own unit. Without a summed
+// amount the first of those takes its place.
+func (a *shoppingAccumulator) result() ShoppingItem {
+	item := a.item
+	extras := a.extras
+	switch {
+	case a.counted && a.unit.Metric():
+		item.Amount = a.quantity.Format(FormatDecimal)
+	case a.counted:
+		item.Amount = a.quantity.String()
+	case len(extras) > 0:
+		item.Amount, item.Unit = extras[0].amount, extras[0].unit
+		extras = extras[1:]
+	}
+
+	rest := make([]string, len(extras))
+	for i, extra := range extras {
+		rest[i] = extra.String()
+	}
+	item.Extra = strings.Join(rest, " + ")
+	return item
+}
+
+// AmountText renders the amount of an item with its unit, followed by the
+// amounts that could not be added up: "1 1/4 cup + a pinch".
+func (item ShoppingItem) AmountText() string {
+	parts := make([]string, 0, 2)
+	if amount := strings.TrimSpace(item.Amount + " " + item.Unit); amount != "" {
+		parts = append(parts, amount)
+	}
+	if extra := strings.TrimSpace(item.Extra); extra != "" {
+		parts = append(parts, extra)
+	}
+	return strings.Join(parts, " + ")
+}
+
+// SortShoppingItems orders items by aisle (in store order), then by name.
+func SortShoppingItems(items []ShoppingItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ai, aj := aisleIndex(items[i].Aisle), aisleIndex(items[j].Aisle)
+		if ai != aj {
+			return ai < aj
+		}
+		return strings.ToLower(items[i].Name) < strings.ToLower(items[j].Name)
+	})
+}
+
+func aisleIndex(aisle string) int {
+	for i, a := range Aisles {
+		if a == aisle {
+			return i
+		}
+	}
+	return len(Aisles)
+}
+
+// GroupShoppingItems groups sorted items by aisle, keeping their order.
+func GroupShoppingItems(items []ShoppingItem) []ShoppingAisleGroup {
+	var groups []ShoppingAisleGroup
+	for _, item := range items {
+		if len(groups) == 0 || groups[len(groups)-1].Aisle != item.Aisle {
+			groups = append(groups, ShoppingAisleGroup{Aisle: item.Aisle})
+		}
+		groups[len(groups)-1].Items = append(groups[len(groups)-1].Items, item)
+	}
+	return groups
+}
+
+// FormatShoppingItemLine renders an item as "2 cup flour".
+func FormatShoppingItemLine(item ShoppingItem) string {
+	return strings.TrimSpace(item.AmountText() + " " + strings.TrimSpace(item.Name))
+}
+
+// RecipeSummary lists the list's recipes, with their scale factor when it is not 1.
+func (l ShoppingList) RecipeSummary() string {
+	names := make([]string, 0, len(l.Recipes))
+	for _, recipe := range l.Recipes {
+		name := recipe.RecipeName
+		if recipe.Scale > 0 && recipe.Scale != 1 {
+			name += " (×" + FormatAmount(recipe.Scale) + ")"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// FormatMarkdown renders the list as a markdown checklist grouped by aisle.
+func (l ShoppingList) FormatMarkdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# 🛒 %s\n\n", l.Name)
+	if summary := l.RecipeSummary(); summary != "" {
+		fmt.Fprintf(&b, "*For: %s*\n\n", summary)
+	}
+	for _, group := range GroupShoppingItems(l.Items) {
+		fmt.Fprintf(&b, "## %s\n\n", group.Aisle)
+		for _, item := range group.Items {
+			check := " "
+			if item.Checked {
+				check = "x"
+			}
+			fmt.Fprintf(&b, "- [%s] %s\n", check, FormatShoppingItemLine(item))
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// FormatText renders the list as plain text grouped by aisle.
+func (l ShoppingList) FormatText() string {
+	var b strings.Builder
+	b.WriteString(l.Name + "\n")
+	if summary := l.RecipeSummary(); summary != "" {
+		b.WriteString("For: " + summary + "\n")
+	}
+	for _, group := range GroupShoppingItems(l.Items) {
+		b.WriteString("\n" + strings.ToUpper(group.Aisle) + "\n")
+		for _, item := range group.Items {
+			check := "[ ]"
+			if item.Checked {
+				check = "[x]"
+			}
+			b.WriteString(check + " " + FormatShoppingItemLine(item) + "\n")
+		}
+	}
+	return b.String()
+}
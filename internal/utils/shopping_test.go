@@ -0,0 +1,111 @@
+package utils
+
+import "testing"
+
+func TestBuildShoppingItems(t *testing.T) {
+	pancakes := &RecipeRaw{
+		RecipeName: "Pancakes",
+		Metadata: RecipeMetadata{Ingredients: []Ingredient{
+			{Amount: "1", Unit: "cup", Name: "milk"},
+			{Amount: "1 1/2", Unit: "cup", Name: "all-purpose flour", BaseName: "flour"},
+			{Amount: "2", Name: "eggs"},
+			{Name: "salt"},
+		}},
+	}
+	crepes := &RecipeRaw{
+		RecipeName: "Crepes",
+		Metadata: RecipeMetadata{Ingredients: []Ingredient{
+			{Amount: "4", Unit: "tbsp", Name: "milk"},
+			{Amount: "1/2", Unit: "cup", Name: "flour", BaseName: "flour"},
+			{Amount: "1", Name: "eggs"},
+			{Amount: "a pinch", Name: "salt"},
+		}},
+	}
+
+	items := BuildShoppingItems([]*RecipeRaw{pancakes, crepes})
+	got := make(map[string]ShoppingItem)
+	for _, item := range items {
+		got[item.Name] = item
+	}
+
+	want := map[string]string{
+		"milk":  "1 1/4 cup milk",
+		"flour": "2 cup flour",
+		"eggs":  "3 eggs",
+		"salt":  "a pinch salt",
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for name, line := range want {
+		item, ok := got[name]
+		if !ok {
+			t.Errorf("missing item %q", name)
+			continue
+		}
+		if FormatShoppingItemLine(item) != line {
+			t.Errorf("item %q = %q, want %q", name, FormatShoppingItemLine(item), line)
+		}
+	}
+
+	if got["milk"].Aisle != AisleDairy || got["flour"].Aisle != AislePantry || got["salt"].Aisle != AisleSpices {
+		t.Errorf("unexpected aisles: %+v", items)
+	}
+	if len(got["eggs"].Recipes) != 2 {
+		t.Errorf("eggs recipes = %v", got["eggs"].Recipes)
+	}
+	if items[0].Aisle != AisleDairy {
+		t.Errorf("items not sorted by aisle: first is %+v", items[0])
+	}
+}
+
+func TestBuildShoppingItemsExtras(t *testing.T) {
+	pancakes := &RecipeRaw{
+		RecipeName: "Pancakes",
+		Metadata: RecipeMetadata{Ingredients: []Ingredient{
+			{Amount: "1", Unit: "cup", Name: "milk"},
+			{Amount: "a splash", Unit: "tbsp", Name: "milk"},
+			{Amount: "4", Unit: "tbsp", Name: "milk"},
+			{Amount: "a pinch", Name: "salt"},
+		}},
+	}
+	crepes := &RecipeRaw{
+		RecipeName: "Crepes",
+		Metadata: RecipeMetadata{Ingredients: []Ingredient{
+			{Amount: "to taste", Name: "salt"},
+		}},
+	}
+
+	items := BuildShoppingItems([]*RecipeRaw{pancakes, crepes})
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2: %+v", len(items), items)
+	}
+	milk, salt := items[0], items[1]
+	if milk.Amount != "1 1/4" || milk.Unit != "cup" || milk.Extra != "a splash tbl" {
+		t.Errorf("milk = %q %q + %q, want 1 1/4 cup + a splash tbl", milk.Amount, milk.Unit, milk.Extra)
+	}
+	if got := FormatShoppingItemLine(milk); got != "1 1/4 cup + a splash tbl milk" {
+		t.Errorf("milk line = %q, want 1 1/4 cup + a splash tbl milk", got)
+	}
+	if got := FormatShoppingItemLine(salt); got != "a pinch + to taste salt" {
+		t.Errorf("salt line = %q, want a pinch + to taste salt", got)
+	}
+}
+
+func TestAisleFor(t *testing.T) {
+	tests := map[string]string{
+		"red bell pepper": AisleProduce,
+		"black pepper":    AisleSpices,
+		"garlic cloves":   AisleProduce,
+		"peanut butter":   AislePantry,
+		"unsalted butter": AisleDairy,
+		"chicken thighs":  AisleMeat,
+		"frozen peas":     AisleFrozen,
+		"mystery gadget":  AisleOther,
+	}
+	for name, want := range tests {
+		if got := AisleFor(name); got != want {
+			t.Errorf("AisleFor(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
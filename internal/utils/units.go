@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/GarroshIcecream/yummy/internal/units"
+)
+
+// ConvertIngredient converts an ingredient's amount into the given unit
+// system. Ingredients without a parseable amount or a convertible unit
+// (e.g. "2 cloves garlic") are returned unchanged.
+func ConvertIngredient(ing Ingredient, system units.System) Ingredient {
+	q, ok := ParseQuantity(ing.Amount)
+	if !ok {
+		return ing
+	}
+	unitName := strings.ToLower(ing.Unit)
+	if normalized, exists := CorpusMeasuresMap[unitName]; exists {
+		unitName = normalized
+	}
+	from, ok := units.Lookup(unitName)
+	if !ok {
+		return ing
+	}
+
+	name := ing.BaseName
+	if name == "" {
+		name = ing.Name
+	}
+	to, ok := units.Target(q.Max, from, name, system)
+	if !ok {
+		return ing
+	}
+
+	density, _ := units.LookupDensity(name)
+	min, err := units.ConvertWithDensity(q.Min, from, to, density.Density)
+	if err != nil {
+		return ing
+	}
+	max, err := units.ConvertWithDensity(q.Max, from, to, density.Density)
+	if err != nil {
+		return ing
+	}
+
+	converted := Quantity{Min: min, Max: max}
+	if to.Metric() {
+		ing.Amount = converted.Format(FormatDecimal)
+	} else {
+		ing.Amount = converted.String()
+	}
+	ing.Unit = to.Name
+	return ing
+}
+
+// ConvertedTo returns a copy of the recipe with every ingredient shown in the
+// given unit system. units.Original returns an unmodified copy.
+func (r *RecipeRaw) ConvertedTo(system units.System) *RecipeRaw {
+	converted := *r
+	converted.Metadata.Ingredients = make([]Ingredient, len(r.Metadata.Ingredients))
+	for i, ing := range r.Metadata.Ingredients {
+		converted.Metadata.Ingredients[i] = ConvertIngredient(ing, system)
+	}
+	return &converted
+}
@@ -49,55 +49,110 @@ func WrapTextToWidth(text string, width int) string {
 	return strings.Join(lines, "\n")
 }
 
+// markdownSection returns the body of the first section whose heading line
+// contains one of the given titles, up to the next markdown heading. Both the
+// legacy ("## 🥘 Ingredients") and current ("### 🥘 Ingredients") export
+// headings are accepted by passing every title variant.
+func markdownSection(text string, titles ...string) (string, bool) {
+	for _, title := range titles {
+		heading := regexp.MustCompile(`(?m)^#{2,3} ` + regexp.QuoteMeta(title) + `\s*$`)
+		loc := heading.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+
+		body := text[loc[1]:]
+		// Deeper headings (e.g. ingredient groups) belong to the section
+		if next := regexp.MustCompile(`(?m)^#{1,3} `).FindStringIndex(body); next != nil {
+			body = body[:next[0]]
+		}
+		return body, true
+	}
+	return "", false
+}
+
+// stripMarkdownEmphasis removes bold/italic markers added by the exporter
+// (e.g. "**2 cup** *flour*" → "2 cup flour").
+func stripMarkdownEmphasis(text string) string {
+	text = strings.ReplaceAll(text, "**", "")
+	text = strings.ReplaceAll(text, "*", "")
+	return strings.Join(strings.Fields(text), " ")
+}
+
 // parseInstructions extracts instructions from the markdown
 func ParseInstructionsFromMarkdown(text string) ([]string, error) {
-	instructionsStart := strings.Index(text, "## 👩‍🍳 Cooking Instructions")
-	if instructionsStart == -1 {
+	instructionsSection, ok := markdownSection(text, "👩‍🍳 Cooking Instructions", "👩‍🍳 Instructions")
+	if !ok {
 		return []string{}, fmt.Errorf("no instructions found")
 	}
 
-	// Find the end of instructions section
-	instructionsEnd := strings.Index(text[instructionsStart:], "\n## ")
-	if instructionsEnd == -1 {
-		instructionsEnd = len(text)
-	} else {
-		instructionsEnd += instructionsStart
+	instructions, _, _ := parseInstructionLines(instructionsSection)
+	return instructions, nil
+}
+
+// ParseInstructionSectionsFromMarkdown extracts the instruction sections
+// ("#### For the sauce" headings between numbered steps) from the markdown
+func ParseInstructionSectionsFromMarkdown(text string) []InstructionSection {
+	instructionsSection, ok := markdownSection(text, "👩‍🍳 Cooking Instructions", "👩‍🍳 Instructions")
+	if !ok {
+		return nil
 	}
+	_, titles, _ := parseInstructionLines(instructionsSection)
+	return InstructionSectionsFromTitles(titles)
+}
+
+// ParseStepNotesFromMarkdown extracts the step annotations ("> 💡 note" lines
+// after numbered steps) from the markdown
+func ParseStepNotesFromMarkdown(text string) []StepNote {
+	instructionsSection, ok := markdownSection(text, "👩‍🍳 Cooking Instructions", "👩‍🍳 Instructions")
+	if !ok {
+		return nil
+	}
+	_, _, notes := parseInstructionLines(instructionsSection)
+	return StepNotesFromList(notes)
+}
 
-	instructionsSection := text[instructionsStart:instructionsEnd]
+// ParseNotesFromMarkdown extracts the personal notes section from the markdown
+func ParseNotesFromMarkdown(text string) string {
+	section, _ := markdownSection(text, "📝 Notes")
+	return strings.TrimSpace(section)
+}
 
-	// Parse numbered instructions
-	lines := strings.Split(instructionsSection, "\n")
+// parseInstructionLines returns the numbered steps of an instructions section
+// together with the section heading each step falls under and its note
+func parseInstructionLines(section string) ([]string, []string, []string) {
 	instructions := []string{}
+	titles := []string{}
+	notes := []string{}
+	title := ""
 	numberedItem := regexp.MustCompile(`^(?:\*\*)?\d+\.(?:\*\*)?\s*(.+)$`)
-	for _, line := range lines {
+	for _, line := range strings.Split(section, "\n") {
 		line = strings.TrimSpace(line)
+		if match := ingredientGroupHeadingRe.FindStringSubmatch(line); match != nil {
+			title = strings.TrimSpace(match[1])
+			continue
+		}
 		if match := numberedItem.FindStringSubmatch(line); len(match) > 1 {
-			instructions = append(instructions, strings.TrimSpace(match[1]))
+			// Ingredient highlighting adds bold markers on export; drop them
+			instructions = append(instructions, strings.TrimSpace(strings.ReplaceAll(match[1], "**", "")))
+			titles = append(titles, title)
+			notes = append(notes, "")
+			continue
+		}
+		if note, ok := strings.CutPrefix(line, "> 💡"); ok && len(notes) > 0 {
+			notes[len(notes)-1] = strings.TrimSpace(note)
 		}
 	}
-
-	return instructions, nil
+	return instructions, titles, notes
 }
 
 // parseCategories extracts categories from the markdown
 func ParseCategoriesFromMarkdown(text string) ([]string, error) {
-	// Find the categories section
-	categoriesStart := strings.Index(text, "## 🏷️ Recipe Type")
-	if categoriesStart == -1 {
+	categoriesSection, ok := markdownSection(text, "🏷️ Recipe Type", "🏷️ Categories")
+	if !ok {
 		return []string{}, fmt.Errorf("no categories found")
 	}
 
-	// Find the end of categories section
-	categoriesEnd := strings.Index(text[categoriesStart:], "\n## ")
-	if categoriesEnd == -1 {
-		categoriesEnd = len(text)
-	} else {
-		categoriesEnd += categoriesStart
-	}
-
-	categoriesSection := text[categoriesStart:categoriesEnd]
-
 	// Extract categories from backticks
 	re := regexp.MustCompile("`([^`]+)`")
 	matches := re.FindAllStringSubmatch(categoriesSection, -1)
@@ -111,12 +166,54 @@ func ParseCategoriesFromMarkdown(text string) ([]string, error) {
 	return categories, nil
 }
 
+// ParseKeywordsFromMarkdown extracts keywords from the markdown
+func ParseKeywordsFromMarkdown(text string) []string {
+	keywords := []string{}
+	section, ok := markdownSection(text, "🔖 Keywords")
+	if !ok {
+		return keywords
+	}
+	for _, match := range regexp.MustCompile("`([^`]+)`").FindAllStringSubmatch(section, -1) {
+		keywords = append(keywords, strings.TrimSpace(match[1]))
+	}
+	return keywords
+}
+
+// ParseNutritionFromMarkdown extracts nutrition facts ("• Protein: **7 g**"),
+// keyed by their schema.org property names
+func ParseNutritionFromMarkdown(text string) map[string]string {
+	section, ok := markdownSection(text, "📊 Nutrition")
+	if !ok {
+		return nil
+	}
+	nutrients := map[string]string{}
+	for _, match := range regexp.MustCompile(`(?m)^• (.+?):\s+\*\*(.+?)\*\*\s*$`).FindAllStringSubmatch(section, -1) {
+		nutrients[NutrientKey(strings.TrimSpace(match[1]))] = strings.TrimSpace(match[2])
+	}
+	if len(nutrients) == 0 {
+		return nil
+	}
+	return nutrients
+}
+
+// ParseImageURLFromMarkdown extracts the recipe image URL from the markdown
+func ParseImageURLFromMarkdown(text string) string {
+	if match := regexp.MustCompile(`(?m)^🖼️ !\[[^\]]*\]\((\S+?)\)`).FindStringSubmatch(text); len(match) > 1 {
+		return match[1]
+	}
+	return ""
+}
+
 // parseSourceURL extracts the source URL from the markdown
 func ParseSourceURLFromMarkdown(text string) (string, error) {
 	urlMatch := regexp.MustCompile(`🔗 \[View Original Recipe\]\((.+?)\)`).FindStringSubmatch(text)
 	if len(urlMatch) > 1 {
 		return strings.TrimSpace(urlMatch[1]), nil
 	}
+	urlMatch = regexp.MustCompile(`(?m)^🔗 (\S+)\s*$`).FindStringSubmatch(text)
+	if len(urlMatch) > 1 {
+		return strings.TrimSpace(urlMatch[1]), nil
+	}
 	return "", fmt.Errorf("no URL found")
 }
 
@@ -129,26 +226,33 @@ func ParseDurationFromString(durationStr string) time.Duration {
 	// Handle common duration formats
 	durationStr = strings.ToLower(strings.TrimSpace(durationStr))
 
+	// ISO-8601 durations as used by schema.org (e.g. "pt1h30m")
+	if strings.HasPrefix(durationStr, "p") {
+		if duration, err := ParseISO8601Duration(durationStr); err == nil {
+			return duration
+		}
+	}
+
 	// Try to parse as Go duration first
 	if duration, err := time.ParseDuration(durationStr); err == nil {
 		return duration
 	}
 
-	// Handle "X hours Y minutes" format
-	if match := regexp.MustCompile(`(\d+)\s*hours?\s*(\d+)\s*minutes?`).FindStringSubmatch(durationStr); len(match) > 2 {
+	// Handle "X hours Y minutes" format (also "X hr Y min")
+	if match := regexp.MustCompile(`(\d+)\s*(?:hours?|hrs?)\s*(\d+)\s*(?:minutes?|mins?)`).FindStringSubmatch(durationStr); len(match) > 2 {
 		hours, _ := strconv.Atoi(match[1])
 		minutes, _ := strconv.Atoi(match[2])
 		return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
 	}
 
 	// Handle "X hours" format
-	if match := regexp.MustCompile(`(\d+)\s*hours?`).FindStringSubmatch(durationStr); len(match) > 1 {
+	if match := regexp.MustCompile(`(\d+)\s*(?:hours?|hrs?)`).FindStringSubmatch(durationStr); len(match) > 1 {
 		hours, _ := strconv.Atoi(match[1])
 		return time.Duration(hours) * time.Hour
 	}
 
 	// Handle "X minutes" format
-	if match := regexp.MustCompile(`(\d+)\s*minutes?`).FindStringSubmatch(durationStr); len(match) > 1 {
+	if match := regexp.MustCompile(`(\d+)\s*(?:minutes?|mins?)`).FindStringSubmatch(durationStr); len(match) > 1 {
 		minutes, _ := strconv.Atoi(match[1])
 		return time.Duration(minutes) * time.Minute
 	}